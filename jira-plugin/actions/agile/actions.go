@@ -0,0 +1,564 @@
+package agile
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	sdkv2Models "github.com/sorenhq/go-plugin-sdk/gosdk/models"
+
+	"github.com/sorenhq/jira-plugin/actions/handler"
+	"github.com/sorenhq/jira-plugin/client"
+	"github.com/sorenhq/jira-plugin/credentials"
+)
+
+// validSprintStates are the sprint states accepted by the Agile API
+var validSprintStates = map[string]bool{
+	"active": true,
+	"future": true,
+	"closed": true,
+}
+
+// GetActions returns all Agile (board and sprint) actions
+func GetActions() []sdkv2Models.Action {
+	return []sdkv2Models.Action{
+		{
+			Method:      "boards.list",
+			Title:       "List Boards",
+			Description: "List Agile (Scrum/Kanban) boards, optionally for a single project",
+			Form: sdkv2Models.ActionFormBuilder{
+				Jsonui: map[string]any{
+					"type": "VerticalLayout",
+					"elements": []map[string]any{
+						{
+							"type":  "Control",
+							"scope": "#/properties/projectKey",
+						},
+						{
+							"type":  "Control",
+							"scope": "#/properties/startAt",
+						},
+						{
+							"type":  "Control",
+							"scope": "#/properties/maxResults",
+						},
+					},
+				},
+				Jsonschema: map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"projectKey": map[string]any{
+							"type":        "string",
+							"title":       "Project Key",
+							"description": "Optional project key or ID to list boards for",
+						},
+						"startAt": map[string]any{
+							"type":        "integer",
+							"title":       "Start At",
+							"description": "Index of the first board to return (for pagination)",
+							"default":     0,
+						},
+						"maxResults": map[string]any{
+							"type":        "integer",
+							"title":       "Max Results",
+							"description": "Maximum number of boards to return",
+							"default":     50,
+						},
+					},
+				},
+			},
+			RequestHandler: ListBoardsHandler,
+		},
+		{
+			Method:      "sprints.list",
+			Title:       "List Sprints",
+			Description: "List the sprints of an Agile board",
+			Form: sdkv2Models.ActionFormBuilder{
+				Jsonui: map[string]any{
+					"type": "VerticalLayout",
+					"elements": []map[string]any{
+						{
+							"type":  "Control",
+							"scope": "#/properties/boardId",
+						},
+						{
+							"type":  "Control",
+							"scope": "#/properties/state",
+						},
+					},
+				},
+				Jsonschema: map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"boardId": map[string]any{
+							"type":        "integer",
+							"title":       "Board ID",
+							"description": "The ID of the board",
+						},
+						"state": map[string]any{
+							"type":        "string",
+							"title":       "State",
+							"description": "Optional sprint state filter",
+							"enum":        []string{"", "active", "future", "closed"},
+						},
+					},
+					"required": []string{"boardId"},
+				},
+			},
+			RequestHandler: ListSprintsHandler,
+		},
+		{
+			Method:      "sprints.move-issues",
+			Title:       "Move Issues to Sprint",
+			Description: "Move issues into a sprint (sent to Jira in batches of 50)",
+			Form: sdkv2Models.ActionFormBuilder{
+				Jsonui: map[string]any{
+					"type": "VerticalLayout",
+					"elements": []map[string]any{
+						{
+							"type":  "Control",
+							"scope": "#/properties/sprintId",
+						},
+						{
+							"type":  "Control",
+							"scope": "#/properties/issueKeys",
+						},
+					},
+				},
+				Jsonschema: map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"sprintId": map[string]any{
+							"type":        "integer",
+							"title":       "Sprint ID",
+							"description": "The ID of the sprint to move the issues into",
+						},
+						"issueKeys": map[string]any{
+							"type":        "array",
+							"title":       "Issue Keys",
+							"description": "The keys of the issues to move (e.g., COM-123)",
+							"items": map[string]any{
+								"type": "string",
+							},
+						},
+					},
+					"required": []string{"sprintId", "issueKeys"},
+				},
+			},
+			RequestHandler: MoveIssuesToSprintHandler,
+		},
+		{
+			Method:      "sprints.create",
+			Title:       "Create Sprint",
+			Description: "Create a new (future) sprint on a board",
+			Form: sdkv2Models.ActionFormBuilder{
+				Jsonui: map[string]any{
+					"type": "VerticalLayout",
+					"elements": []map[string]any{
+						{
+							"type":  "Control",
+							"scope": "#/properties/boardId",
+						},
+						{
+							"type":  "Control",
+							"scope": "#/properties/name",
+						},
+						{
+							"type":  "Control",
+							"scope": "#/properties/startDate",
+						},
+						{
+							"type":  "Control",
+							"scope": "#/properties/endDate",
+						},
+						{
+							"type":  "Control",
+							"scope": "#/properties/goal",
+						},
+					},
+				},
+				Jsonschema: map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"boardId": map[string]any{
+							"type":        "integer",
+							"title":       "Board ID",
+							"description": "The ID of the board the sprint belongs to",
+						},
+						"name": map[string]any{
+							"type":        "string",
+							"title":       "Name",
+							"description": "The sprint name",
+						},
+						"startDate": map[string]any{
+							"type":        "string",
+							"title":       "Start Date",
+							"description": "Optional start date (RFC 3339, e.g. 2024-12-31T09:00:00Z, or YYYY-MM-DD)",
+						},
+						"endDate": map[string]any{
+							"type":        "string",
+							"title":       "End Date",
+							"description": "Optional end date (RFC 3339, e.g. 2025-01-14T17:00:00Z, or YYYY-MM-DD)",
+						},
+						"goal": map[string]any{
+							"type":        "string",
+							"title":       "Goal",
+							"description": "Optional sprint goal",
+							"format":      "textarea",
+						},
+					},
+					"required": []string{"boardId", "name"},
+				},
+			},
+			RequestHandler: CreateSprintHandler,
+		},
+		{
+			Method:      "sprints.transition",
+			Title:       "Start or Close Sprint",
+			Description: "Start a sprint (active) or complete it (closed)",
+			Form: sdkv2Models.ActionFormBuilder{
+				Jsonui: map[string]any{
+					"type": "VerticalLayout",
+					"elements": []map[string]any{
+						{
+							"type":  "Control",
+							"scope": "#/properties/sprintId",
+						},
+						{
+							"type":  "Control",
+							"scope": "#/properties/state",
+						},
+						{
+							"type":  "Control",
+							"scope": "#/properties/startDate",
+						},
+						{
+							"type":  "Control",
+							"scope": "#/properties/endDate",
+						},
+					},
+				},
+				Jsonschema: map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"sprintId": map[string]any{
+							"type":        "integer",
+							"title":       "Sprint ID",
+							"description": "The ID of the sprint",
+						},
+						"state": map[string]any{
+							"type":        "string",
+							"title":       "State",
+							"description": "The new sprint state",
+							"enum":        []string{"active", "closed"},
+						},
+						"startDate": map[string]any{
+							"type":        "string",
+							"title":       "Start Date",
+							"description": "Start date, required when starting a sprint that has none (RFC 3339 or YYYY-MM-DD)",
+						},
+						"endDate": map[string]any{
+							"type":        "string",
+							"title":       "End Date",
+							"description": "End date, required when starting a sprint that has none (RFC 3339 or YYYY-MM-DD)",
+						},
+					},
+					"required": []string{"sprintId", "state"},
+				},
+			},
+			RequestHandler: TransitionSprintHandler,
+		},
+	}
+}
+
+// parseSprintDate parses an optional sprint date given as RFC 3339, Jira's own format or YYYY-MM-DD.
+// An empty string yields the zero time.
+func parseSprintDate(raw string) (time.Time, error) {
+	if raw == "" {
+		return time.Time{}, nil
+	}
+	for _, layout := range []string{time.RFC3339, client.JiraTimeFormat, "2006-01-02"} {
+		if t, err := time.Parse(layout, raw); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date '%s': use a format like 2024-12-31T09:00:00Z or 2024-12-31", raw)
+}
+
+// ListBoardsHandler handles the boards.list action
+func ListBoardsHandler(msg *nats.Msg) {
+	handler.Run(msg, "boards.list", func(ctx context.Context, creds *credentials.JiraCredentials, body map[string]any, progress handler.ProgressFunc) map[string]any {
+		// Extract form fields
+		projectKey, _ := body["projectKey"].(string)
+		startAt := handler.IntValue(body, "startAt", 0)
+		maxResults := handler.IntValue(body, "maxResults", 50)
+		if maxResults <= 0 {
+			maxResults = 50
+		}
+		if startAt < 0 {
+			startAt = 0
+		}
+
+		// Create Jira client and fetch boards
+		jiraClient := client.NewJiraClient(creds)
+		boardsResult, err := jiraClient.ListBoards(ctx, projectKey, startAt, maxResults)
+		if err != nil {
+			log.Printf("Failed to list boards: %v", err)
+			return map[string]any{
+				"error":   client.ErrorCode(err),
+				"message": fmt.Sprintf("Failed to list boards: %v", err),
+			}
+		}
+
+		boards, _ := boardsResult["values"].([]interface{})
+		isLast, _ := boardsResult["isLast"].(bool)
+
+		result := map[string]any{
+			"result":     "success",
+			"message":    fmt.Sprintf("Successfully retrieved %d boards", len(boards)),
+			"boards":     boards,
+			"count":      len(boards),
+			"startAt":    startAt,
+			"maxResults": maxResults,
+			"total":      boardsResult["total"],
+			"isLast":     isLast,
+		}
+		return result
+	})
+}
+
+// ListSprintsHandler handles the sprints.list action
+func ListSprintsHandler(msg *nats.Msg) {
+	handler.Run(msg, "sprints.list", func(ctx context.Context, creds *credentials.JiraCredentials, body map[string]any, progress handler.ProgressFunc) map[string]any {
+		// Extract form fields
+		boardId := handler.IntValue(body, "boardId", 0)
+		state, _ := body["state"].(string)
+
+		// Validate required fields
+		if boardId <= 0 {
+			return map[string]any{
+				"error":   "validation_error",
+				"message": "Board ID is required",
+			}
+		}
+		// state may be a comma-separated list, e.g. "active,future"
+		for _, s := range strings.Split(state, ",") {
+			s = strings.TrimSpace(s)
+			if s != "" && !validSprintStates[s] {
+				return map[string]any{
+					"error":   "validation_error",
+					"message": fmt.Sprintf("Invalid sprint state %q: must be active, future or closed", s),
+				}
+			}
+		}
+
+		// Create Jira client and fetch sprints
+		jiraClient := client.NewJiraClient(creds)
+		sprints, err := jiraClient.ListSprints(ctx, boardId, state)
+		if err != nil {
+			log.Printf("Failed to list sprints: %v", err)
+			if errors.Is(err, client.ErrNotFound) {
+				return map[string]any{
+					"error":   "not_found",
+					"message": fmt.Sprintf("Board %d not found", boardId),
+				}
+			}
+			return map[string]any{
+				"error":   client.ErrorCode(err),
+				"message": fmt.Sprintf("Failed to list sprints: %v", err),
+			}
+		}
+
+		result := map[string]any{
+			"result":  "success",
+			"message": fmt.Sprintf("Successfully retrieved %d sprints for board %d", len(sprints), boardId),
+			"boardId": boardId,
+			"sprints": sprints,
+			"count":   len(sprints),
+		}
+		return result
+	})
+}
+
+// MoveIssuesToSprintHandler handles the sprints.move-issues action
+func MoveIssuesToSprintHandler(msg *nats.Msg) {
+	handler.Run(msg, "sprints.move-issues", func(ctx context.Context, creds *credentials.JiraCredentials, body map[string]any, progress handler.ProgressFunc) map[string]any {
+		// Extract form fields
+		sprintId := handler.IntValue(body, "sprintId", 0)
+		issueKeys := handler.StringSlice(body, "issueKeys")
+
+		// Validate required fields
+		if sprintId <= 0 {
+			return map[string]any{
+				"error":   "validation_error",
+				"message": "Sprint ID is required",
+			}
+		}
+		if len(issueKeys) == 0 {
+			return map[string]any{
+				"error":   "validation_error",
+				"message": "At least one issue key is required",
+			}
+		}
+
+		// Create Jira client and move the issues
+		jiraClient := client.NewJiraClient(creds)
+		err := jiraClient.MoveIssuesToSprint(ctx, sprintId, issueKeys)
+		if err != nil {
+			log.Printf("Failed to move issues to sprint: %v", err)
+			if errors.Is(err, client.ErrNotFound) {
+				return map[string]any{
+					"error":   "not_found",
+					"message": fmt.Sprintf("Sprint %d not found", sprintId),
+				}
+			}
+			return map[string]any{
+				"error":   client.ErrorCode(err),
+				"message": fmt.Sprintf("Failed to move issues to sprint: %v", err),
+			}
+		}
+
+		result := map[string]any{
+			"result":    "success",
+			"message":   fmt.Sprintf("Moved %d issues to sprint %d", len(issueKeys), sprintId),
+			"sprintId":  sprintId,
+			"issueKeys": issueKeys,
+			"count":     len(issueKeys),
+		}
+		return result
+	})
+}
+
+// CreateSprintHandler handles the sprints.create action
+func CreateSprintHandler(msg *nats.Msg) {
+	handler.Run(msg, "sprints.create", func(ctx context.Context, creds *credentials.JiraCredentials, body map[string]any, progress handler.ProgressFunc) map[string]any {
+		// Extract form fields
+		boardId := handler.IntValue(body, "boardId", 0)
+		name, _ := body["name"].(string)
+		startDateRaw, _ := body["startDate"].(string)
+		endDateRaw, _ := body["endDate"].(string)
+		goal, _ := body["goal"].(string)
+
+		// Validate required fields
+		if boardId <= 0 || strings.TrimSpace(name) == "" {
+			return map[string]any{
+				"error":   "validation_error",
+				"message": "Board ID and sprint name are required",
+			}
+		}
+		startDate, err := parseSprintDate(startDateRaw)
+		if err != nil {
+			return map[string]any{"error": "validation_error", "message": fmt.Sprintf("Start date: %v", err)}
+		}
+		endDate, err := parseSprintDate(endDateRaw)
+		if err != nil {
+			return map[string]any{"error": "validation_error", "message": fmt.Sprintf("End date: %v", err)}
+		}
+		if !startDate.IsZero() && !endDate.IsZero() && !endDate.After(startDate) {
+			return map[string]any{
+				"error":   "validation_error",
+				"message": "End date must be after start date",
+			}
+		}
+
+		// Create Jira client and create the sprint
+		jiraClient := client.NewJiraClient(creds)
+		sprint, err := jiraClient.CreateSprint(ctx, boardId, name, startDate, endDate, goal)
+		if err != nil {
+			log.Printf("Failed to create sprint: %v", err)
+			return map[string]any{
+				"error":   client.ErrorCode(err),
+				"message": fmt.Sprintf("Failed to create sprint: %v", err),
+			}
+		}
+
+		result := map[string]any{
+			"result":   "success",
+			"message":  fmt.Sprintf("Sprint %s created on board %d", name, boardId),
+			"sprintId": sprint["id"],
+			"sprint":   sprint,
+		}
+		return result
+	})
+}
+
+// TransitionSprintHandler handles the sprints.transition action
+func TransitionSprintHandler(msg *nats.Msg) {
+	handler.Run(msg, "sprints.transition", func(ctx context.Context, creds *credentials.JiraCredentials, body map[string]any, progress handler.ProgressFunc) map[string]any {
+		// Extract form fields
+		sprintId := handler.IntValue(body, "sprintId", 0)
+		state, _ := body["state"].(string)
+		startDateRaw, _ := body["startDate"].(string)
+		endDateRaw, _ := body["endDate"].(string)
+
+		// Validate required fields
+		if sprintId <= 0 {
+			return map[string]any{
+				"error":   "validation_error",
+				"message": "Sprint ID is required",
+			}
+		}
+		if state != "active" && state != "closed" {
+			return map[string]any{
+				"error":   "validation_error",
+				"message": "State must be active (start the sprint) or closed (complete it)",
+			}
+		}
+		startDate, err := parseSprintDate(startDateRaw)
+		if err != nil {
+			return map[string]any{"error": "validation_error", "message": fmt.Sprintf("Start date: %v", err)}
+		}
+		endDate, err := parseSprintDate(endDateRaw)
+		if err != nil {
+			return map[string]any{"error": "validation_error", "message": fmt.Sprintf("End date: %v", err)}
+		}
+		// Jira only starts a sprint that has both dates
+		if state == "active" && (startDate.IsZero() || endDate.IsZero()) {
+			return map[string]any{
+				"error":   "validation_error",
+				"message": "Start date and end date are required to start a sprint",
+			}
+		}
+		if state == "active" && !endDate.After(startDate) {
+			return map[string]any{
+				"error":   "validation_error",
+				"message": "End date must be after start date",
+			}
+		}
+
+		// Create Jira client and transition the sprint
+		jiraClient := client.NewJiraClient(creds)
+		sprint, err := jiraClient.TransitionSprint(ctx, sprintId, state, startDate, endDate)
+		if err != nil {
+			log.Printf("Failed to transition sprint: %v", err)
+			if errors.Is(err, client.ErrNotFound) {
+				return map[string]any{
+					"error":   "not_found",
+					"message": fmt.Sprintf("Sprint %d not found", sprintId),
+				}
+			}
+			return map[string]any{
+				"error":   client.ErrorCode(err),
+				"message": fmt.Sprintf("Failed to transition sprint: %v", err),
+			}
+		}
+
+		message := fmt.Sprintf("Sprint %d started", sprintId)
+		if state == "closed" {
+			message = fmt.Sprintf("Sprint %d closed", sprintId)
+		}
+
+		result := map[string]any{
+			"result":   "success",
+			"message":  message,
+			"sprintId": sprintId,
+			"state":    state,
+			"sprint":   sprint,
+		}
+		return result
+	})
+}
@@ -0,0 +1,70 @@
+package handler
+
+import (
+	"strconv"
+	"strings"
+)
+
+// IntValue safely extracts an integer value from the request body.
+// JSON numbers are decoded as float64, so both float64 and int are accepted.
+func IntValue(body map[string]any, key string, defaultValue int) int {
+	switch v := body[key].(type) {
+	case float64:
+		return int(v)
+	case int:
+		return v
+	}
+	return defaultValue
+}
+
+// StringSlice safely extracts a string array from the request body
+func StringSlice(body map[string]any, key string) []string {
+	raw, ok := body[key].([]interface{})
+	if !ok {
+		return nil
+	}
+	values := make([]string, 0, len(raw))
+	for _, item := range raw {
+		if str, ok := item.(string); ok && str != "" {
+			values = append(values, str)
+		}
+	}
+	return values
+}
+
+// BoolValue safely extracts a boolean value from the request body.
+// UI clients may send checkboxes as bool, as "true"/"false" strings, or as 1/0,
+// so all of these forms are accepted. Missing or unparseable values are false.
+func BoolValue(body map[string]any, key string) bool {
+	switch v := body[key].(type) {
+	case bool:
+		return v
+	case string:
+		parsed, err := strconv.ParseBool(strings.TrimSpace(v))
+		return err == nil && parsed
+	case float64:
+		return v != 0
+	case int:
+		return v != 0
+	}
+	return false
+}
+
+// BatchOutcome completes the result of a batch action with the shape shared by all of
+// them: "succeeded", "failed" and "total" counts, and "result" set to "success" when
+// every item succeeded, "partial_success" when some failed, or "failed" when none
+// succeeded. Per-item outcomes stay in the action's own list (e.g. "issues").
+func BatchOutcome(result map[string]any, succeeded, failed int) map[string]any {
+	result["succeeded"] = succeeded
+	result["failed"] = failed
+	result["total"] = succeeded + failed
+	switch {
+	case failed == 0:
+		result["result"] = "success"
+	case succeeded == 0:
+		result["result"] = "failed"
+	default:
+		result["result"] = "partial_success"
+	}
+	return result
+}
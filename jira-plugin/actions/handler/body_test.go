@@ -0,0 +1,59 @@
+package handler
+
+import "testing"
+
+func TestGetBoolValue(t *testing.T) {
+	tests := []struct {
+		name  string
+		value any
+		want  bool
+	}{
+		{"bool true", true, true},
+		{"bool false", false, false},
+		{"string true", "true", true},
+		{"string TRUE with spaces", " TRUE ", true},
+		{"string false", "false", false},
+		{"string 1", "1", true},
+		{"string garbage", "yes please", false},
+		{"JSON number 1", float64(1), true},
+		{"JSON number 0", float64(0), false},
+		{"int 1", 1, true},
+		{"null", nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := map[string]any{"deleteSubtasks": tt.value}
+			if got := BoolValue(body, "deleteSubtasks"); got != tt.want {
+				t.Errorf("BoolValue(%#v) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+
+	t.Run("missing", func(t *testing.T) {
+		if BoolValue(map[string]any{}, "deleteSubtasks") {
+			t.Error("BoolValue of a missing key = true, want false")
+		}
+	})
+}
+
+func TestBatchOutcome(t *testing.T) {
+	tests := []struct {
+		succeeded, failed int
+		want              string
+	}{
+		{3, 0, "success"},
+		{2, 1, "partial_success"},
+		{0, 2, "failed"},
+		// An empty batch (e.g. a query matching no issues) has the same shape
+		{0, 0, "success"},
+	}
+	for _, tt := range tests {
+		result := BatchOutcome(map[string]any{"issues": map[string]any{}}, tt.succeeded, tt.failed)
+		if result["result"] != tt.want || result["succeeded"] != tt.succeeded || result["failed"] != tt.failed || result["total"] != tt.succeeded+tt.failed {
+			t.Errorf("BatchOutcome(%d, %d) = %v, want result %q with the counts", tt.succeeded, tt.failed, result, tt.want)
+		}
+		if _, ok := result["issues"]; !ok {
+			t.Errorf("BatchOutcome dropped the per-item list: %v", result)
+		}
+	}
+}
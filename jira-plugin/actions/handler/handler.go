@@ -0,0 +1,166 @@
+// Package handler holds what every action package shares: the request handling
+// around each action and helpers to read action request bodies.
+package handler
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/bytedance/sonic"
+	"github.com/nats-io/nats.go"
+	sdkv2 "github.com/sorenhq/go-plugin-sdk/gosdk"
+	sdkv2Models "github.com/sorenhq/go-plugin-sdk/gosdk/models"
+
+	"github.com/sorenhq/jira-plugin/client"
+	"github.com/sorenhq/jira-plugin/credentials"
+	"github.com/sorenhq/jira-plugin/progress"
+	"github.com/sorenhq/jira-plugin/shutdown"
+)
+
+// actionTimeout bounds how long a single action may spend calling Jira
+const actionTimeout = 2 * time.Minute
+
+// ProgressFunc reports intermediate progress (0-100) of a running action to the platform
+type ProgressFunc = progress.Func
+
+// ActionFunc runs an action with the space's credentials and the request body,
+// returning the result published for the job
+type ActionFunc func(ctx context.Context, creds *credentials.JiraCredentials, body map[string]any, progress ProgressFunc) map[string]any
+
+// Run handles an action request that responds directly: it parses the body, loads the
+// space's credentials (for the profile named in the body, if any), accepts the job,
+// runs actionFunc and publishes its result
+func Run(msg *nats.Msg, actionName string, actionFunc ActionFunc) {
+	// Extract spaceId from the NATS message subject
+	spaceID := SpaceIDFromSubject(msg.Subject)
+	log.Printf("Action %s called for space '%s' (extracted from subject: %s)", actionName, spaceID, msg.Subject)
+	log.Printf("Message data length: %d bytes", len(msg.Data))
+	client.Debugf("Message data content: %s", client.RedactJSON(msg.Data))
+
+	// Track the job so a shutdown waits for it; new jobs are refused while shutting down
+	if !shutdown.Begin() {
+		log.Printf("Action %s rejected for space '%s': plugin is shutting down", actionName, spaceID)
+		sdkv2.RejectWithBody(msg, map[string]any{
+			"error":   "shutting_down",
+			"message": "The Jira plugin is shutting down, please retry shortly",
+		})
+		return
+	}
+	defer shutdown.End()
+
+	// Handle empty or missing request body (for actions with no form fields)
+	var requestData sdkv2Models.ActionRequestContent
+	var body map[string]any = make(map[string]any)
+
+	if len(msg.Data) > 0 {
+		err := sonic.Unmarshal(msg.Data, &requestData)
+		if err != nil {
+			log.Printf("Failed to unmarshal action request: %v", err)
+			sdkv2.RejectWithBody(msg, map[string]any{
+				"error":   "invalid_request",
+				"message": "Failed to parse request",
+			})
+			return
+		}
+		// Use the body from requestData if available, otherwise use empty map
+		if requestData.Body != nil {
+			body = requestData.Body
+		}
+	} else {
+		log.Printf("Empty message body for action %s, using empty body map", actionName)
+	}
+
+	// Get credentials storage instance
+	credsStorage := credentials.GetCredentialsStorage()
+
+	// Any action may name a credentials profile; it is consumed here so it never
+	// reaches the Jira payload built from the body
+	profile, _ := body["profile"].(string)
+	delete(body, "profile")
+	profile = strings.TrimSpace(profile)
+	if profile == "" {
+		profile = credentials.DefaultProfile
+	}
+
+	// Jira offers no way for API tokens or personal access tokens to act as another
+	// user, so runAsAccountId is refused rather than ignored: ignoring it would
+	// silently perform the action as the credentials' own user
+	if runAs, _ := body["runAsAccountId"].(string); strings.TrimSpace(runAs) != "" {
+		log.Printf("Action %s rejected for space '%s': runAsAccountId is not supported", actionName, spaceID)
+		sdkv2.RejectWithBody(msg, map[string]any{
+			"error":   "not_supported",
+			"message": "runAsAccountId is not supported: Jira API tokens and personal access tokens cannot act as another user. Onboard a separate profile with that user's credentials instead",
+			"action":  actionName,
+		})
+		return
+	}
+
+	// Check if credentials exist for this space and profile
+	if !credsStorage.HasCredentialsProfile(spaceID, profile) {
+		errorMsg := fmt.Sprintf("Jira credentials not configured for space '%s'. Please complete the onboarding process first.", spaceID)
+		if spaceID == "" {
+			errorMsg = "Jira credentials not configured. Please complete the onboarding process first."
+		}
+		if profile != credentials.DefaultProfile {
+			errorMsg = fmt.Sprintf("Jira credentials profile '%s' not configured for space '%s'. Please complete the onboarding process for this profile first.", profile, spaceID)
+		}
+
+		log.Printf("Action %s rejected for space '%s': %s", actionName, spaceID, errorMsg)
+		sdkv2.RejectWithBody(msg, map[string]any{
+			"error":   "credentials_not_configured",
+			"message": errorMsg,
+			"action":  actionName,
+			"spaceId": spaceID,
+			"profile": profile,
+		})
+		return
+	}
+
+	// Get credentials
+	creds, err := credsStorage.GetCredentialsProfile(spaceID, profile)
+	if err != nil {
+		log.Printf("Failed to get credentials: %v", err)
+		sdkv2.RejectWithBody(msg, map[string]any{
+			"error":   "credentials_error",
+			"message": fmt.Sprintf("Failed to retrieve credentials: %v", err),
+		})
+		return
+	}
+
+	// Handshake via SDK (stores entityId and responds)
+	jobID := sdkv2.Accept(msg)
+	if jobID == "" {
+		sdkv2.RejectWithBody(msg, map[string]any{
+			"error":   "job_creation_failed",
+			"message": "Failed to create job",
+		})
+		return
+	}
+
+	// Execute and complete; the deadline cancels in-flight Jira calls that run too long
+	ctx, cancel := context.WithTimeout(client.WithAction(context.Background(), actionName), actionTimeout)
+	defer cancel()
+	result := actionFunc(ctx, creds, body, progress.ForJob(jobID))
+	if plugin := sdkv2.GetPlugin(); plugin != nil {
+		plugin.Done(jobID, result)
+	} else {
+		log.Printf("Failed to publish result: plugin instance not found")
+	}
+}
+
+// SpaceIDFromSubject extracts the entityId (spaceId) from NATS message subject
+// Subject pattern: soren.v2.bin.{entityId}.{pluginId}.{path} or soren.cpu.bin.{entityId}.{pluginId}.{path}
+func SpaceIDFromSubject(subject string) string {
+	parts := strings.Split(subject, ".")
+	// Look for "bin" in the subject, entityId should be right after it
+	for i, part := range parts {
+		if part == "bin" && i+1 < len(parts) {
+			return parts[i+1]
+		}
+	}
+	// If pattern doesn't match, return empty string (will use default)
+	return ""
+}
@@ -0,0 +1,17 @@
+package handler
+
+import "testing"
+
+func TestSpaceIDFromSubject(t *testing.T) {
+	tests := map[string]string{
+		"soren.v2.bin.space-1.plugin-1.issues.create": "space-1",
+		"soren.cpu.bin.space-2.plugin-1.system.ping":  "space-2",
+		"soren.v2.bin":           "",
+		"soren.v2.other.space-1": "",
+	}
+	for subject, want := range tests {
+		if got := SpaceIDFromSubject(subject); got != want {
+			t.Errorf("SpaceIDFromSubject(%q) = %q, want %q", subject, got, want)
+		}
+	}
+}
@@ -1,16 +1,35 @@
 package issues
 
 import (
+	"context"
+	"encoding/base64"
+	"errors"
 	"fmt"
 	"log"
+	"maps"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/nats-io/nats.go"
 	sdkv2Models "github.com/sorenhq/go-plugin-sdk/gosdk/models"
 
+	"github.com/sorenhq/jira-plugin/actions/handler"
 	"github.com/sorenhq/jira-plugin/client"
 	"github.com/sorenhq/jira-plugin/credentials"
 )
 
+// defaultBulkTransitionIssues and maxBulkTransitionIssues are the default and largest
+// maxIssues of issues.bulk-transition
+const (
+	defaultBulkTransitionIssues = 100
+	maxBulkTransitionIssues     = 1000
+)
+
+// bulkTransitionSearchPageSize is the number of issues requested per search page when
+// collecting the issues to transition
+const bulkTransitionSearchPageSize = 100
+
 // GetActions returns all issue-related actions
 func GetActions() []sdkv2Models.Action {
 	return []sdkv2Models.Action{
@@ -38,6 +57,30 @@ func GetActions() []sdkv2Models.Action {
 							"type":  "Control",
 							"scope": "#/properties/description",
 						},
+						{
+							"type":  "Control",
+							"scope": "#/properties/priority",
+						},
+						{
+							"type":  "Control",
+							"scope": "#/properties/labels",
+						},
+						{
+							"type":  "Control",
+							"scope": "#/properties/components",
+						},
+						{
+							"type":  "Control",
+							"scope": "#/properties/assignee",
+						},
+						{
+							"type":  "Control",
+							"scope": "#/properties/originalEstimate",
+						},
+						{
+							"type":  "Control",
+							"scope": "#/properties/remainingEstimate",
+						},
 						{
 							"type":  "Control",
 							"scope": "#/properties/additionalFields",
@@ -45,6 +88,14 @@ func GetActions() []sdkv2Models.Action {
 								"format": "json",
 							},
 						},
+						{
+							"type":  "Control",
+							"scope": "#/properties/idempotencyKey",
+						},
+						{
+							"type":  "Control",
+							"scope": "#/properties/validate",
+						},
 					},
 				},
 				Jsonschema: map[string]any{
@@ -53,7 +104,7 @@ func GetActions() []sdkv2Models.Action {
 						"projectKey": map[string]any{
 							"type":        "string",
 							"title":       "Project Key",
-							"description": "The project key (e.g., PROJ)",
+							"description": "The project key (e.g., PROJ). Defaults to the project chosen during onboarding",
 						},
 						"issueType": map[string]any{
 							"type":        "string",
@@ -71,76 +122,222 @@ func GetActions() []sdkv2Models.Action {
 							"title":       "Description",
 							"description": "Issue description",
 						},
+						"priority": map[string]any{
+							"type":        "string",
+							"title":       "Priority (Optional)",
+							"description": "Priority name (e.g., High)",
+						},
+						"labels": map[string]any{
+							"type":        "array",
+							"title":       "Labels (Optional)",
+							"description": "Labels to set on the issue (labels cannot contain spaces)",
+							"items": map[string]any{
+								"type": "string",
+							},
+						},
+						"components": map[string]any{
+							"type":        "array",
+							"title":       "Components (Optional)",
+							"description": "Names of the project components the issue belongs to",
+							"items": map[string]any{
+								"type": "string",
+							},
+						},
+						"assignee": map[string]any{
+							"type":        "string",
+							"title":       "Assignee (Optional)",
+							"description": "Account ID of the user to assign the issue to",
+						},
+						"originalEstimate": map[string]any{
+							"type":        "string",
+							"title":       "Original Estimate (Optional)",
+							"description": "Time tracking estimate for the issue in Jira's duration format (e.g., 2w 3d 4h)",
+						},
+						"remainingEstimate": map[string]any{
+							"type":        "string",
+							"title":       "Remaining Estimate (Optional)",
+							"description": "Remaining time in Jira's duration format (e.g., 1d 4h); defaults to the original estimate",
+						},
 						"additionalFields": map[string]any{
 							"type":                 "object",
 							"title":                "Additional Fields",
 							"description":          "Additional Jira fields as key-value pairs (JSON object). Examples: {\"duedate\": \"2024-12-31\"}, {\"priority\": {\"name\": \"High\"}}, {\"assignee\": {\"accountId\": \"user-id\"}}. Field names should match Jira field IDs or names.",
 							"additionalProperties": true,
 						},
+						"idempotencyKey": map[string]any{
+							"type":        "string",
+							"title":       "Idempotency Key (Optional)",
+							"description": "A unique key for this request. Resubmitting the same key within the idempotency window (10 minutes by default) returns the issue already created instead of creating a duplicate",
+						},
+						"validate": map[string]any{
+							"type":        "boolean",
+							"title":       "Validate First",
+							"description": "Check the project, issue type and required fields against Jira's create metadata before creating, and list valid options on failure (adds a lookup)",
+							"default":     false,
+						},
 					},
-					"required":             []string{"projectKey", "issueType", "summary"},
+					"required":             []string{"issueType", "summary"},
 					"additionalProperties": true, // Allow any additional properties for flexibility
 				},
 			},
 			RequestHandler: CreateIssueHandler,
 		},
 		{
-			Method:      "issues.delete",
-			Title:       "Delete Issue",
-			Description: "Delete an issue from Jira by issue key or ID",
+			Method:      "issues.createmeta",
+			Title:       "Get Create Fields",
+			Description: "List the required and optional fields (with allowed values for selects) of the create screen for an issue type in a project, to build a create form",
 			Form: sdkv2Models.ActionFormBuilder{
 				Jsonui: map[string]any{
 					"type": "VerticalLayout",
 					"elements": []map[string]any{
 						{
 							"type":  "Control",
-							"scope": "#/properties/issueKey",
+							"scope": "#/properties/projectKey",
 						},
 						{
 							"type":  "Control",
-							"scope": "#/properties/deleteSubtasks",
+							"scope": "#/properties/issueType",
+						},
+						{
+							"type":  "Control",
+							"scope": "#/properties/refresh",
 						},
 					},
 				},
 				Jsonschema: map[string]any{
 					"type": "object",
 					"properties": map[string]any{
-						"issueKey": map[string]any{
+						"projectKey": map[string]any{
 							"type":        "string",
-							"title":       "Issue Key or ID",
-							"description": "The issue key (e.g., COM-123) or issue ID",
+							"title":       "Project Key",
+							"description": "The project key (e.g., PROJ)",
 						},
-						"deleteSubtasks": map[string]any{
+						"issueType": map[string]any{
+							"type":        "string",
+							"title":       "Issue Type",
+							"description": "Issue type name (e.g., Task) or ID",
+						},
+						"refresh": map[string]any{
 							"type":        "boolean",
-							"title":       "Delete Subtasks",
-							"description": "If true, delete subtasks when deleting the issue",
+							"title":       "Refresh",
+							"description": "Bypass the metadata cache",
 							"default":     false,
 						},
 					},
-					"required": []string{"issueKey"},
+					"required": []string{"projectKey", "issueType"},
 				},
 			},
-			RequestHandler: DeleteIssueHandler,
+			RequestHandler: CreateMetaHandler,
 		},
 		{
-			Method:      "issues.comment",
-			Title:       "Add Comment",
-			Description: "Add a comment to a Jira issue",
+			Method:      "fields.list",
+			Title:       "List Fields",
+			Description: "List the Jira system and custom fields with their IDs, to find the customfield IDs to use in additionalFields",
 			Form: sdkv2Models.ActionFormBuilder{
 				Jsonui: map[string]any{
 					"type": "VerticalLayout",
 					"elements": []map[string]any{
 						{
 							"type":  "Control",
-							"scope": "#/properties/issueKey",
+							"scope": "#/properties/query",
 						},
 						{
 							"type":  "Control",
-							"scope": "#/properties/commentBody",
+							"scope": "#/properties/customOnly",
 						},
 						{
 							"type":  "Control",
-							"scope": "#/properties/visibility",
+							"scope": "#/properties/refresh",
+						},
+					},
+				},
+				Jsonschema: map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"query": map[string]any{
+							"type":        "string",
+							"title":       "Query (Optional)",
+							"description": "Only return fields whose name contains this text (case-insensitive), e.g. story points",
+						},
+						"customOnly": map[string]any{
+							"type":        "boolean",
+							"title":       "Custom Fields Only",
+							"description": "Only return custom fields",
+							"default":     false,
+						},
+						"refresh": map[string]any{
+							"type":        "boolean",
+							"title":       "Refresh",
+							"description": "Bypass the cached field list and fetch it from Jira again",
+							"default":     false,
+						},
+					},
+				},
+			},
+			RequestHandler: ListFieldsHandler,
+		},
+		{
+			Method:      "issues.bulk-create",
+			Title:       "Bulk Create Issues",
+			Description: "Create several issues at once (sent to Jira in batches of 50)",
+			Form: sdkv2Models.ActionFormBuilder{
+				Jsonui: map[string]any{
+					"type": "VerticalLayout",
+					"elements": []map[string]any{
+						{
+							"type":  "Control",
+							"scope": "#/properties/issues",
+						},
+					},
+				},
+				Jsonschema: map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"issues": map[string]any{
+							"type":        "array",
+							"title":       "Issues",
+							"description": "The issues to create; each needs projectKey, issueType and summary, with optional description and additionalFields",
+							"items": map[string]any{
+								"type": "object",
+								"properties": map[string]any{
+									"projectKey":       map[string]any{"type": "string", "title": "Project Key"},
+									"issueType":        map[string]any{"type": "string", "title": "Issue Type"},
+									"summary":          map[string]any{"type": "string", "title": "Summary"},
+									"description":      map[string]any{"type": "string", "title": "Description"},
+									"additionalFields": map[string]any{"type": "object", "title": "Additional Fields", "additionalProperties": true},
+								},
+								"required": []string{"projectKey", "issueType", "summary"},
+							},
+						},
+					},
+					"required": []string{"issues"},
+				},
+			},
+			RequestHandler: BulkCreateIssuesHandler,
+		},
+		{
+			Method:      "issues.subtask.create",
+			Title:       "Create Subtask",
+			Description: "Create a subtask under an existing issue",
+			Form: sdkv2Models.ActionFormBuilder{
+				Jsonui: map[string]any{
+					"type": "VerticalLayout",
+					"elements": []map[string]any{
+						{
+							"type":  "Control",
+							"scope": "#/properties/parentKey",
+						},
+						{
+							"type":  "Control",
+							"scope": "#/properties/summary",
+						},
+						{
+							"type":  "Control",
+							"scope": "#/properties/description",
+						},
+						{
+							"type":  "Control",
+							"scope": "#/properties/issueType",
 						},
 						{
 							"type":  "Control",
@@ -154,236 +351,4081 @@ func GetActions() []sdkv2Models.Action {
 				Jsonschema: map[string]any{
 					"type": "object",
 					"properties": map[string]any{
-						"issueKey": map[string]any{
+						"parentKey": map[string]any{
 							"type":        "string",
-							"title":       "Issue Key or ID",
-							"description": "The issue key (e.g., COM-123) or issue ID",
+							"title":       "Parent Issue Key",
+							"description": "Key of the parent issue (e.g., COM-123)",
 						},
-						"commentBody": map[string]any{
+						"summary": map[string]any{
 							"type":        "string",
-							"title":       "Comment",
-							"description": "The comment text to add",
-							"format":      "textarea",
+							"title":       "Summary",
+							"description": "Subtask summary/title",
 						},
-						"visibility": map[string]any{
-							"type":        "object",
-							"title":       "Visibility (Optional)",
-							"description": "Comment visibility settings. Example: {\"type\": \"role\", \"value\": \"Administrators\"} or {\"type\": \"group\", \"value\": \"jira-developers\"}",
-							"properties": map[string]any{
-								"type": map[string]any{
-									"type":        "string",
-									"title":       "Visibility Type",
-									"description": "Type of visibility: 'role' or 'group'",
-									"enum":        []string{"role", "group"},
-								},
-								"value": map[string]any{
-									"type":        "string",
-									"title":       "Visibility Value",
-									"description": "Role or group name",
-								},
-							},
+						"description": map[string]any{
+							"type":        "string",
+							"title":       "Description (Optional)",
+							"description": "Subtask description",
+						},
+						"issueType": map[string]any{
+							"type":        "string",
+							"title":       "Issue Type (Optional)",
+							"description": "Subtask issue type name; defaults to the project's subtask type",
 						},
 						"additionalFields": map[string]any{
 							"type":                 "object",
-							"title":                "Additional Fields",
-							"description":          "Additional Jira comment fields as key-value pairs (JSON object). Can be used for custom fields or future Jira API extensions.",
+							"title":                "Additional Fields (Optional)",
+							"description":          "Additional Jira fields as key-value pairs (JSON object), as for issues.create",
 							"additionalProperties": true,
 						},
 					},
-					"required":             []string{"issueKey", "commentBody"},
-					"additionalProperties": true, // Allow any additional properties for flexibility
+					"required": []string{"parentKey", "summary"},
 				},
 			},
-			RequestHandler: AddCommentHandler,
+			RequestHandler: CreateSubtaskHandler,
 		},
-	}
-}
-
-// CreateIssueHandler handles the issues.create action
-func CreateIssueHandler(msg *nats.Msg) {
-	handleActionWithCredentialsCheckSync(msg, "issues.create", func(creds *credentials.JiraCredentials, body map[string]any) map[string]any {
-		// Extract core form fields
-		projectKey, _ := body["projectKey"].(string)
-		issueType, _ := body["issueType"].(string)
-		summary, _ := body["summary"].(string)
-		description, _ := body["description"].(string)
-
-		// Extract additionalFields if provided (as object)
-		var additionalFields map[string]interface{}
-		if additionalFieldsRaw, ok := body["additionalFields"]; ok {
-			if afMap, ok := additionalFieldsRaw.(map[string]interface{}); ok {
-				additionalFields = afMap
-			} else if afMap, ok := additionalFieldsRaw.(map[string]any); ok {
-				// Convert map[string]any to map[string]interface{}
-				additionalFields = make(map[string]interface{})
-				for k, v := range afMap {
-					additionalFields[k] = v
-				}
-			}
-		}
-
-		// Also check for any other fields that might have been passed directly
-		// (for backward compatibility and flexibility)
-		knownFields := map[string]bool{
-			"projectKey":       true,
-			"issueType":        true,
-			"summary":          true,
-			"description":      true,
-			"additionalFields": true,
-		}
-
-		// Merge any other fields that aren't in the known list into additionalFields
-		if additionalFields == nil {
-			additionalFields = make(map[string]interface{})
-		}
-		for key, value := range body {
-			if !knownFields[key] && value != nil && value != "" {
-				additionalFields[key] = value
-			}
-		}
-
-		// Validate required fields
-		if projectKey == "" {
-			return map[string]any{
-				"error":   "validation_error",
-				"message": "Project key is required",
-			}
-		}
-		if issueType == "" {
-			return map[string]any{
-				"error":   "validation_error",
-				"message": "Issue type is required",
-			}
-		}
-		if summary == "" {
-			return map[string]any{
-				"error":   "validation_error",
-				"message": "Summary is required",
-			}
-		}
-
-		// Create Jira client and create issue
-		jiraClient := client.NewJiraClient(creds)
-		issue, err := jiraClient.CreateIssue(projectKey, issueType, summary, description, additionalFields)
-		if err != nil {
-			log.Printf("Failed to create issue: %v", err)
-			return map[string]any{
-				"error":   "jira_api_error",
-				"message": fmt.Sprintf("Failed to create issue: %v", err),
-			}
-		}
-
-		// Extract issue key from response
-		issueKey, _ := issue["key"].(string)
-		issueId, _ := issue["id"].(string)
-
-		log.Printf("Successfully created Jira issue: %s (ID: %s)", issueKey, issueId)
-
-		result := map[string]any{
-			"result":   "success",
-			"message":  "Issue created successfully",
-			"issueKey": issueKey,
-			"issueId":  issueId,
-			"issue":    issue,
-		}
-		return result
-	})
-}
-
-// DeleteIssueHandler handles the issues.delete action
-func DeleteIssueHandler(msg *nats.Msg) {
-	handleActionWithCredentialsCheckSync(msg, "issues.delete", func(creds *credentials.JiraCredentials, body map[string]any) map[string]any {
-		// Extract form fields
-		issueKey, _ := body["issueKey"].(string)
-		deleteSubtasks := false
-		if ds, ok := body["deleteSubtasks"].(bool); ok {
-			deleteSubtasks = ds
-		}
-
-		// Validate required fields
-		if issueKey == "" {
-			return map[string]any{
+		{
+			Method:      "issues.get",
+			Title:       "Get Issue",
+			Description: "Get a single Jira issue by issue key or ID",
+			Form: sdkv2Models.ActionFormBuilder{
+				Jsonui: map[string]any{
+					"type": "VerticalLayout",
+					"elements": []map[string]any{
+						{
+							"type":  "Control",
+							"scope": "#/properties/issueKey",
+						},
+						{
+							"type":  "Control",
+							"scope": "#/properties/fields",
+						},
+						{
+							"type":  "Control",
+							"scope": "#/properties/expand",
+						},
+						{
+							"type":  "Control",
+							"scope": "#/properties/rendered",
+						},
+					},
+				},
+				Jsonschema: map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"issueKey": map[string]any{
+							"type":        "string",
+							"title":       "Issue Key or ID",
+							"description": "The issue key (e.g., COM-123) or issue ID",
+						},
+						"fields": map[string]any{
+							"type":        "array",
+							"title":       "Fields (Optional)",
+							"description": "Issue fields to return (e.g., summary, status, assignee). Returns all fields if empty.",
+							"items": map[string]any{
+								"type": "string",
+							},
+						},
+						"expand": map[string]any{
+							"type":        "array",
+							"title":       "Expand (Optional)",
+							"description": "Additional data to include (e.g., renderedFields, transitions, changelog)",
+							"items": map[string]any{
+								"type": "string",
+							},
+						},
+						"rendered": map[string]any{
+							"type":        "boolean",
+							"title":       "Rendered HTML",
+							"description": "Also return the description rendered as HTML (renderedDescription). Jira renders it on request, so this is slower",
+							"default":     false,
+						},
+					},
+					"required": []string{"issueKey"},
+				},
+			},
+			RequestHandler: GetIssueHandler,
+		},
+		{
+			Method:      "issues.changelog",
+			Title:       "Get Issue Changelog",
+			Description: "Get the change history of an issue (who changed which fields, and when)",
+			Form: sdkv2Models.ActionFormBuilder{
+				Jsonui: map[string]any{
+					"type": "VerticalLayout",
+					"elements": []map[string]any{
+						{
+							"type":  "Control",
+							"scope": "#/properties/issueKey",
+						},
+						{
+							"type":  "Control",
+							"scope": "#/properties/startAt",
+						},
+						{
+							"type":  "Control",
+							"scope": "#/properties/maxResults",
+						},
+					},
+				},
+				Jsonschema: map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"issueKey": map[string]any{
+							"type":        "string",
+							"title":       "Issue Key or ID",
+							"description": "The issue key (e.g., COM-123) or issue ID",
+						},
+						"startAt": map[string]any{
+							"type":        "integer",
+							"title":       "Start At",
+							"description": "Index of the first history entry to return (for pagination)",
+							"default":     0,
+						},
+						"maxResults": map[string]any{
+							"type":        "integer",
+							"title":       "Max Results",
+							"description": "Maximum number of history entries to return",
+							"default":     100,
+						},
+					},
+					"required": []string{"issueKey"},
+				},
+			},
+			RequestHandler: GetIssueChangelogHandler,
+		},
+		{
+			Method:      "issues.update",
+			Title:       "Update Issue",
+			Description: "Update fields of an existing Jira issue",
+			Form: sdkv2Models.ActionFormBuilder{
+				Jsonui: map[string]any{
+					"type": "VerticalLayout",
+					"elements": []map[string]any{
+						{
+							"type":  "Control",
+							"scope": "#/properties/issueKey",
+						},
+						{
+							"type":  "Control",
+							"scope": "#/properties/summary",
+						},
+						{
+							"type":  "Control",
+							"scope": "#/properties/description",
+						},
+						{
+							"type":  "Control",
+							"scope": "#/properties/originalEstimate",
+						},
+						{
+							"type":  "Control",
+							"scope": "#/properties/remainingEstimate",
+						},
+						{
+							"type":  "Control",
+							"scope": "#/properties/additionalFields",
+							"options": map[string]any{
+								"format": "json",
+							},
+						},
+					},
+				},
+				Jsonschema: map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"issueKey": map[string]any{
+							"type":        "string",
+							"title":       "Issue Key or ID",
+							"description": "The issue key (e.g., COM-123) or issue ID",
+						},
+						"summary": map[string]any{
+							"type":        "string",
+							"title":       "Summary",
+							"description": "New issue summary/title (leave empty to keep the current value)",
+						},
+						"description": map[string]any{
+							"type":        "string",
+							"title":       "Description",
+							"description": "New issue description (leave empty to keep the current value)",
+						},
+						"originalEstimate": map[string]any{
+							"type":        "string",
+							"title":       "Original Estimate (Optional)",
+							"description": "New original estimate in Jira's duration format (e.g., 2w 3d 4h); leave empty to keep the current value",
+						},
+						"remainingEstimate": map[string]any{
+							"type":        "string",
+							"title":       "Remaining Estimate (Optional)",
+							"description": "New remaining estimate in Jira's duration format (e.g., 1d 4h); leave empty to keep the current value",
+						},
+						"additionalFields": map[string]any{
+							"type":                 "object",
+							"title":                "Additional Fields",
+							"description":          "Additional Jira fields to update as key-value pairs (JSON object). Examples: {\"duedate\": \"2024-12-31\"}, {\"priority\": {\"name\": \"High\"}}. Field names should match Jira field IDs or names.",
+							"additionalProperties": true,
+						},
+					},
+					"required":             []string{"issueKey"},
+					"additionalProperties": true, // Allow any additional properties for flexibility
+				},
+			},
+			RequestHandler: UpdateIssueHandler,
+		},
+		{
+			Method:      "issues.move",
+			Title:       "Move Issue",
+			Description: "Change the issue type of an issue (moving to another project is not supported by the REST API)",
+			Form: sdkv2Models.ActionFormBuilder{
+				Jsonui: map[string]any{
+					"type": "VerticalLayout",
+					"elements": []map[string]any{
+						{
+							"type":  "Control",
+							"scope": "#/properties/issueKey",
+						},
+						{
+							"type":  "Control",
+							"scope": "#/properties/issueType",
+						},
+						{
+							"type":  "Control",
+							"scope": "#/properties/projectKey",
+						},
+					},
+				},
+				Jsonschema: map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"issueKey": map[string]any{
+							"type":        "string",
+							"title":       "Issue Key or ID",
+							"description": "The issue key (e.g., COM-123) or issue ID",
+						},
+						"issueType": map[string]any{
+							"type":        "string",
+							"title":       "Target Issue Type",
+							"description": "Name or ID of the issue type to change to (e.g., Bug)",
+						},
+						"projectKey": map[string]any{
+							"type":        "string",
+							"title":       "Target Project (Optional)",
+							"description": "Target project key; only the issue's current project is supported",
+						},
+					},
+					"required": []string{"issueKey", "issueType"},
+				},
+			},
+			RequestHandler: MoveIssueHandler,
+		},
+		{
+			Method:      "issues.transition",
+			Title:       "Transition Issue",
+			Description: "Move a Jira issue through its workflow (e.g. To Do → In Progress → Done)",
+			Form: sdkv2Models.ActionFormBuilder{
+				Jsonui: map[string]any{
+					"type": "VerticalLayout",
+					"elements": []map[string]any{
+						{
+							"type":  "Control",
+							"scope": "#/properties/issueKey",
+						},
+						{
+							"type":  "Control",
+							"scope": "#/properties/transition",
+						},
+						{
+							"type":  "Control",
+							"scope": "#/properties/resolution",
+						},
+					},
+				},
+				Jsonschema: map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"issueKey": map[string]any{
+							"type":        "string",
+							"title":       "Issue Key or ID",
+							"description": "The issue key (e.g., COM-123) or issue ID",
+						},
+						"transition": map[string]any{
+							"type":        "string",
+							"title":       "Transition",
+							"description": "Transition name (e.g., In Progress) or transition ID",
+						},
+						"resolution": map[string]any{
+							"type":        "string",
+							"title":       "Resolution (Optional)",
+							"description": "Resolution name to set during the transition (e.g., Done, Won't Do)",
+						},
+					},
+					"required": []string{"issueKey", "transition"},
+				},
+			},
+			RequestHandler: TransitionIssueHandler,
+		},
+		{
+			Method:      "issues.bulk-transition",
+			Title:       "Bulk Transition Issues",
+			Description: "Run a workflow transition on every issue matching a JQL query (e.g., close all resolved issues); failures are reported per issue",
+			Form: sdkv2Models.ActionFormBuilder{
+				Jsonui: map[string]any{
+					"type": "VerticalLayout",
+					"elements": []map[string]any{
+						{
+							"type":  "Control",
+							"scope": "#/properties/jql",
+						},
+						{
+							"type":  "Control",
+							"scope": "#/properties/transition",
+						},
+						{
+							"type":  "Control",
+							"scope": "#/properties/resolution",
+						},
+						{
+							"type":  "Control",
+							"scope": "#/properties/maxIssues",
+						},
+					},
+				},
+				Jsonschema: map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"jql": map[string]any{
+							"type":        "string",
+							"title":       "JQL",
+							"description": "JQL query selecting the issues to transition (e.g., project = PROJ AND status = Resolved)",
+							"format":      "textarea",
+						},
+						"transition": map[string]any{
+							"type":        "string",
+							"title":       "Transition",
+							"description": "Transition name (e.g., Close) or ID; resolved separately for each issue",
+						},
+						"resolution": map[string]any{
+							"type":        "string",
+							"title":       "Resolution (Optional)",
+							"description": "Resolution to set during the transition (e.g., Done)",
+						},
+						"maxIssues": map[string]any{
+							"type":        "integer",
+							"title":       "Max Issues",
+							"description": "Maximum number of matching issues to transition (at most 1000)",
+							"default":     100,
+						},
+					},
+					"required": []string{"jql", "transition"},
+				},
+			},
+			RequestHandler: BulkTransitionIssuesHandler,
+		},
+		{
+			Method:      "issues.transitions.list",
+			Title:       "List Issue Transitions",
+			Description: "List the workflow transitions currently available for a Jira issue, with the status each one leads to",
+			Form: sdkv2Models.ActionFormBuilder{
+				Jsonui: map[string]any{
+					"type": "VerticalLayout",
+					"elements": []map[string]any{
+						{
+							"type":  "Control",
+							"scope": "#/properties/issueKey",
+						},
+					},
+				},
+				Jsonschema: map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"issueKey": map[string]any{
+							"type":        "string",
+							"title":       "Issue Key or ID",
+							"description": "The issue key (e.g., COM-123) or issue ID",
+						},
+					},
+					"required": []string{"issueKey"},
+				},
+			},
+			RequestHandler: ListTransitionsHandler,
+		},
+		{
+			Method:      "issues.assign",
+			Title:       "Assign Issue",
+			Description: "Assign a Jira issue to a user, to the default assignee, or unassign it",
+			Form: sdkv2Models.ActionFormBuilder{
+				Jsonui: map[string]any{
+					"type": "VerticalLayout",
+					"elements": []map[string]any{
+						{
+							"type":  "Control",
+							"scope": "#/properties/issueKey",
+						},
+						{
+							"type":  "Control",
+							"scope": "#/properties/accountId",
+						},
+						{
+							"type":  "Control",
+							"scope": "#/properties/name",
+						},
+					},
+				},
+				Jsonschema: map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"issueKey": map[string]any{
+							"type":        "string",
+							"title":       "Issue Key or ID",
+							"description": "The issue key (e.g., COM-123) or issue ID",
+						},
+						"accountId": map[string]any{
+							"type":        "string",
+							"title":       "Account ID",
+							"description": "Account ID of the assignee. Use -1 for the project's default assignee, or leave empty to unassign.",
+						},
+						"name": map[string]any{
+							"type":        "string",
+							"title":       "Username (Jira Server)",
+							"description": "Username of the assignee for Jira Server/Data Center. Used only when Account ID is empty.",
+						},
+					},
+					"required": []string{"issueKey"},
+				},
+			},
+			RequestHandler: AssignIssueHandler,
+		},
+		{
+			Method:      "issues.search",
+			Title:       "Search Issues",
+			Description: "Search for Jira issues using JQL",
+			Form: sdkv2Models.ActionFormBuilder{
+				Jsonui: map[string]any{
+					"type": "VerticalLayout",
+					"elements": []map[string]any{
+						{
+							"type":  "Control",
+							"scope": "#/properties/jql",
+						},
+						{
+							"type":  "Control",
+							"scope": "#/properties/maxResults",
+						},
+						{
+							"type":  "Control",
+							"scope": "#/properties/startAt",
+						},
+						{
+							"type":  "Control",
+							"scope": "#/properties/fields",
+						},
+						{
+							"type":  "Control",
+							"scope": "#/properties/expand",
+						},
+					},
+				},
+				Jsonschema: map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"jql": map[string]any{
+							"type":        "string",
+							"title":       "JQL",
+							"description": "JQL query (e.g., project = PROJ AND status = \"In Progress\")",
+							"format":      "textarea",
+						},
+						"maxResults": map[string]any{
+							"type":        "integer",
+							"title":       "Max Results",
+							"description": "Maximum number of issues to return",
+							"default":     50,
+						},
+						"startAt": map[string]any{
+							"type":        "integer",
+							"title":       "Start At",
+							"description": "Index of the first issue to return (for pagination)",
+							"default":     0,
+						},
+						"fields": map[string]any{
+							"type":        "array",
+							"title":       "Fields (Optional)",
+							"description": "Issue fields to return (e.g., summary, status, assignee). Returns all navigable fields if empty.",
+							"items": map[string]any{
+								"type": "string",
+							},
+						},
+						"expand": map[string]any{
+							"type":        "array",
+							"title":       "Expand (Optional)",
+							"description": "Additional data to include for each issue (e.g., renderedFields, transitions, changelog)",
+							"items": map[string]any{
+								"type": "string",
+							},
+						},
+					},
+					"required": []string{"jql"},
+				},
+			},
+			RequestHandler: SearchIssuesHandler,
+		},
+		{
+			Method:      "issues.validate-jql",
+			Title:       "Validate JQL",
+			Description: "Check whether a JQL query is valid without running it",
+			Form: sdkv2Models.ActionFormBuilder{
+				Jsonui: map[string]any{
+					"type": "VerticalLayout",
+					"elements": []map[string]any{
+						{
+							"type":  "Control",
+							"scope": "#/properties/jql",
+						},
+					},
+				},
+				Jsonschema: map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"jql": map[string]any{
+							"type":        "string",
+							"title":       "JQL",
+							"description": "JQL query to validate (e.g., project = PROJ AND status = \"In Progress\")",
+							"format":      "textarea",
+						},
+					},
+					"required": []string{"jql"},
+				},
+			},
+			RequestHandler: ValidateJQLHandler,
+		},
+		{
+			Method:      "filters.list",
+			Title:       "List Filters",
+			Description: "List the saved filters visible to the connected user, with their owner and JQL",
+			Form: sdkv2Models.ActionFormBuilder{
+				Jsonui: map[string]any{
+					"type": "VerticalLayout",
+					"elements": []map[string]any{
+						{
+							"type":  "Control",
+							"scope": "#/properties/maxResults",
+						},
+						{
+							"type":  "Control",
+							"scope": "#/properties/startAt",
+						},
+					},
+				},
+				Jsonschema: map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"maxResults": map[string]any{
+							"type":        "integer",
+							"title":       "Max Results",
+							"description": "Maximum number of filters to return",
+							"default":     50,
+						},
+						"startAt": map[string]any{
+							"type":        "integer",
+							"title":       "Start At",
+							"description": "Index of the first filter to return (for pagination)",
+							"default":     0,
+						},
+					},
+				},
+			},
+			RequestHandler: ListFiltersHandler,
+		},
+
+		{
+			Method:      "filters.run",
+			Title:       "Run Filter",
+			Description: "Search for issues using a saved filter's JQL",
+			Form: sdkv2Models.ActionFormBuilder{
+				Jsonui: map[string]any{
+					"type": "VerticalLayout",
+					"elements": []map[string]any{
+						{
+							"type":  "Control",
+							"scope": "#/properties/filterId",
+						},
+						{
+							"type":  "Control",
+							"scope": "#/properties/maxResults",
+						},
+						{
+							"type":  "Control",
+							"scope": "#/properties/startAt",
+						},
+						{
+							"type":  "Control",
+							"scope": "#/properties/fields",
+						},
+						{
+							"type":  "Control",
+							"scope": "#/properties/expand",
+						},
+					},
+				},
+				Jsonschema: map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"filterId": map[string]any{
+							"type":        "string",
+							"title":       "Filter ID",
+							"description": "ID of the saved filter (the number in the filter URL, e.g. 10042)",
+						},
+						"maxResults": map[string]any{
+							"type":        "integer",
+							"title":       "Max Results",
+							"description": "Maximum number of issues to return",
+							"default":     50,
+						},
+						"startAt": map[string]any{
+							"type":        "integer",
+							"title":       "Start At",
+							"description": "Index of the first issue to return (for pagination)",
+							"default":     0,
+						},
+						"fields": map[string]any{
+							"type":        "array",
+							"title":       "Fields (Optional)",
+							"description": "Issue fields to return (e.g., summary, status, assignee). Returns all navigable fields if empty.",
+							"items": map[string]any{
+								"type": "string",
+							},
+						},
+						"expand": map[string]any{
+							"type":        "array",
+							"title":       "Expand (Optional)",
+							"description": "Additional data to include for each issue (e.g., renderedFields, transitions, changelog)",
+							"items": map[string]any{
+								"type": "string",
+							},
+						},
+					},
+					"required": []string{"filterId"},
+				},
+			},
+			RequestHandler: RunFilterHandler,
+		},
+		{
+			Method:      "issues.delete",
+			Title:       "Delete Issue",
+			Description: "Delete an issue from Jira by issue key or ID",
+			Form: sdkv2Models.ActionFormBuilder{
+				Jsonui: map[string]any{
+					"type": "VerticalLayout",
+					"elements": []map[string]any{
+						{
+							"type":  "Control",
+							"scope": "#/properties/issueKey",
+						},
+						{
+							"type":  "Control",
+							"scope": "#/properties/deleteSubtasks",
+						},
+					},
+				},
+				Jsonschema: map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"issueKey": map[string]any{
+							"type":        "string",
+							"title":       "Issue Key or ID",
+							"description": "The issue key (e.g., COM-123) or issue ID",
+						},
+						"deleteSubtasks": map[string]any{
+							"type":        "boolean",
+							"title":       "Delete Subtasks",
+							"description": "If true, delete subtasks when deleting the issue",
+							"default":     false,
+						},
+					},
+					"required": []string{"issueKey"},
+				},
+			},
+			RequestHandler: DeleteIssueHandler,
+		},
+		{
+			Method:      "issues.bulk-delete",
+			Title:       "Bulk Delete Issues",
+			Description: "Delete several issues at once; failures are reported per issue",
+			Form: sdkv2Models.ActionFormBuilder{
+				Jsonui: map[string]any{
+					"type": "VerticalLayout",
+					"elements": []map[string]any{
+						{
+							"type":  "Control",
+							"scope": "#/properties/issueKeys",
+						},
+						{
+							"type":  "Control",
+							"scope": "#/properties/deleteSubtasks",
+						},
+					},
+				},
+				Jsonschema: map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"issueKeys": map[string]any{
+							"type":        "array",
+							"title":       "Issue Keys",
+							"description": "Keys or IDs of the issues to delete (e.g., COM-123)",
+							"items":       map[string]any{"type": "string"},
+						},
+						"deleteSubtasks": map[string]any{
+							"type":        "boolean",
+							"title":       "Delete Subtasks",
+							"description": "If true, delete subtasks when deleting each issue",
+							"default":     false,
+						},
+					},
+					"required": []string{"issueKeys"},
+				},
+			},
+			RequestHandler: BulkDeleteIssuesHandler,
+		},
+		{
+			Method:      "issues.comment",
+			Title:       "Add Comment",
+			Description: "Add a comment to a Jira issue",
+			Form: sdkv2Models.ActionFormBuilder{
+				Jsonui: map[string]any{
+					"type": "VerticalLayout",
+					"elements": []map[string]any{
+						{
+							"type":  "Control",
+							"scope": "#/properties/issueKey",
+						},
+						{
+							"type":  "Control",
+							"scope": "#/properties/commentBody",
+						},
+						{
+							"type":  "Control",
+							"scope": "#/properties/visibility",
+						},
+						{
+							"type":  "Control",
+							"scope": "#/properties/internal",
+						},
+						{
+							"type":  "Control",
+							"scope": "#/properties/additionalFields",
+							"options": map[string]any{
+								"format": "json",
+							},
+						},
+					},
+				},
+				Jsonschema: map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"issueKey": map[string]any{
+							"type":        "string",
+							"title":       "Issue Key or ID",
+							"description": "The issue key (e.g., COM-123) or issue ID",
+						},
+						"commentBody": map[string]any{
+							"type":        "string",
+							"title":       "Comment",
+							"description": "The comment text to add",
+							"format":      "textarea",
+						},
+						"visibility": map[string]any{
+							"type":        "object",
+							"title":       "Visibility (Optional)",
+							"description": "Comment visibility settings. Example: {\"type\": \"role\", \"value\": \"Administrators\"} or {\"type\": \"group\", \"value\": \"jira-developers\"}",
+							"properties": map[string]any{
+								"type": map[string]any{
+									"type":        "string",
+									"title":       "Visibility Type",
+									"description": "Type of visibility: 'role' or 'group'",
+									"enum":        []string{"role", "group"},
+								},
+								"value": map[string]any{
+									"type":        "string",
+									"title":       "Visibility Value",
+									"description": "Role or group name",
+								},
+							},
+						},
+						"internal": map[string]any{
+							"type":        "boolean",
+							"title":       "Internal",
+							"description": "Jira Service Management only: make the comment internal (visible to agents, not the customer). Rejected on other projects",
+							"default":     false,
+						},
+						"additionalFields": map[string]any{
+							"type":                 "object",
+							"title":                "Additional Fields",
+							"description":          "Additional Jira comment fields as key-value pairs (JSON object). Can be used for custom fields or future Jira API extensions.",
+							"additionalProperties": true,
+						},
+					},
+					"required":             []string{"issueKey", "commentBody"},
+					"additionalProperties": true, // Allow any additional properties for flexibility
+				},
+			},
+			RequestHandler: AddCommentHandler,
+		},
+		{
+			Method:      "issues.bulk-comment",
+			Title:       "Bulk Add Comment",
+			Description: "Add the same comment to several issues; failures are reported per issue",
+			Form: sdkv2Models.ActionFormBuilder{
+				Jsonui: map[string]any{
+					"type": "VerticalLayout",
+					"elements": []map[string]any{
+						{
+							"type":  "Control",
+							"scope": "#/properties/issueKeys",
+						},
+						{
+							"type":  "Control",
+							"scope": "#/properties/commentBody",
+						},
+					},
+				},
+				Jsonschema: map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"issueKeys": map[string]any{
+							"type":        "array",
+							"title":       "Issue Keys",
+							"description": "Keys or IDs of the issues to comment on (e.g., COM-123)",
+							"items":       map[string]any{"type": "string"},
+						},
+						"commentBody": map[string]any{
+							"type":        "string",
+							"title":       "Comment",
+							"description": "The comment text to add to every issue",
+							"format":      "textarea",
+						},
+					},
+					"required": []string{"issueKeys", "commentBody"},
+				},
+			},
+			RequestHandler: BulkAddCommentHandler,
+		},
+		{
+			Method:      "issues.comments.list",
+			Title:       "List Comments",
+			Description: "List the comments on a Jira issue",
+			Form: sdkv2Models.ActionFormBuilder{
+				Jsonui: map[string]any{
+					"type": "VerticalLayout",
+					"elements": []map[string]any{
+						{
+							"type":  "Control",
+							"scope": "#/properties/issueKey",
+						},
+						{
+							"type":  "Control",
+							"scope": "#/properties/maxResults",
+						},
+						{
+							"type":  "Control",
+							"scope": "#/properties/startAt",
+						},
+					},
+				},
+				Jsonschema: map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"issueKey": map[string]any{
+							"type":        "string",
+							"title":       "Issue Key or ID",
+							"description": "The issue key (e.g., COM-123) or issue ID",
+						},
+						"maxResults": map[string]any{
+							"type":        "integer",
+							"title":       "Max Results",
+							"description": "Maximum number of comments to return",
+							"default":     50,
+						},
+						"startAt": map[string]any{
+							"type":        "integer",
+							"title":       "Start At",
+							"description": "Index of the first comment to return (for pagination)",
+							"default":     0,
+						},
+					},
+					"required": []string{"issueKey"},
+				},
+			},
+			RequestHandler: ListCommentsHandler,
+		},
+		{
+			Method:      "issues.comment.update",
+			Title:       "Update Comment",
+			Description: "Update the text of an existing comment on a Jira issue",
+			Form: sdkv2Models.ActionFormBuilder{
+				Jsonui: map[string]any{
+					"type": "VerticalLayout",
+					"elements": []map[string]any{
+						{
+							"type":  "Control",
+							"scope": "#/properties/issueKey",
+						},
+						{
+							"type":  "Control",
+							"scope": "#/properties/commentId",
+						},
+						{
+							"type":  "Control",
+							"scope": "#/properties/commentBody",
+						},
+					},
+				},
+				Jsonschema: map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"issueKey": map[string]any{
+							"type":        "string",
+							"title":       "Issue Key or ID",
+							"description": "The issue key (e.g., COM-123) or issue ID",
+						},
+						"commentId": map[string]any{
+							"type":        "string",
+							"title":       "Comment ID",
+							"description": "The ID of the comment to update",
+						},
+						"commentBody": map[string]any{
+							"type":        "string",
+							"title":       "Comment",
+							"description": "The new comment text",
+							"format":      "textarea",
+						},
+					},
+					"required": []string{"issueKey", "commentId", "commentBody"},
+				},
+			},
+			RequestHandler: UpdateCommentHandler,
+		},
+		{
+			Method:      "issues.comment.delete",
+			Title:       "Delete Comment",
+			Description: "Delete a comment from a Jira issue",
+			Form: sdkv2Models.ActionFormBuilder{
+				Jsonui: map[string]any{
+					"type": "VerticalLayout",
+					"elements": []map[string]any{
+						{
+							"type":  "Control",
+							"scope": "#/properties/issueKey",
+						},
+						{
+							"type":  "Control",
+							"scope": "#/properties/commentId",
+						},
+					},
+				},
+				Jsonschema: map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"issueKey": map[string]any{
+							"type":        "string",
+							"title":       "Issue Key or ID",
+							"description": "The issue key (e.g., COM-123) or issue ID",
+						},
+						"commentId": map[string]any{
+							"type":        "string",
+							"title":       "Comment ID",
+							"description": "The ID of the comment to delete",
+						},
+					},
+					"required": []string{"issueKey", "commentId"},
+				},
+			},
+			RequestHandler: DeleteCommentHandler,
+		},
+		{
+			Method:      "issues.attachment.add",
+			Title:       "Add Attachment",
+			Description: "Upload a file as an attachment to a Jira issue",
+			Form: sdkv2Models.ActionFormBuilder{
+				Jsonui: map[string]any{
+					"type": "VerticalLayout",
+					"elements": []map[string]any{
+						{
+							"type":  "Control",
+							"scope": "#/properties/issueKey",
+						},
+						{
+							"type":  "Control",
+							"scope": "#/properties/filename",
+						},
+						{
+							"type":  "Control",
+							"scope": "#/properties/content",
+						},
+					},
+				},
+				Jsonschema: map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"issueKey": map[string]any{
+							"type":        "string",
+							"title":       "Issue Key or ID",
+							"description": "The issue key (e.g., COM-123) or issue ID",
+						},
+						"filename": map[string]any{
+							"type":        "string",
+							"title":       "File Name",
+							"description": "Name of the attachment (e.g., report.pdf)",
+						},
+						"content": map[string]any{
+							"type":        "string",
+							"title":       "Content",
+							"description": "Base64-encoded file content",
+							"format":      "textarea",
+						},
+					},
+					"required": []string{"issueKey", "filename", "content"},
+				},
+			},
+			RequestHandler: AddAttachmentHandler,
+		},
+		{
+			Method:      "issues.attachments.list",
+			Title:       "List Attachments",
+			Description: "List the attachments of a Jira issue with their size, type and download URL",
+			Form: sdkv2Models.ActionFormBuilder{
+				Jsonui: map[string]any{
+					"type": "VerticalLayout",
+					"elements": []map[string]any{
+						{
+							"type":  "Control",
+							"scope": "#/properties/issueKey",
+						},
+					},
+				},
+				Jsonschema: map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"issueKey": map[string]any{
+							"type":        "string",
+							"title":       "Issue Key or ID",
+							"description": "The issue key (e.g., COM-123) or issue ID",
+						},
+					},
+					"required": []string{"issueKey"},
+				},
+			},
+			RequestHandler: ListAttachmentsHandler,
+		},
+
+		{
+			Method:      "issues.attachment.download",
+			Title:       "Download Attachment",
+			Description: "Download a Jira attachment (up to 10 MB) as base64-encoded content",
+			Form: sdkv2Models.ActionFormBuilder{
+				Jsonui: map[string]any{
+					"type": "VerticalLayout",
+					"elements": []map[string]any{
+						{
+							"type":  "Control",
+							"scope": "#/properties/attachmentId",
+						},
+					},
+				},
+				Jsonschema: map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"attachmentId": map[string]any{
+							"type":        "string",
+							"title":       "Attachment ID",
+							"description": "The attachment ID (see issues.attachments.list)",
+						},
+					},
+					"required": []string{"attachmentId"},
+				},
+			},
+			RequestHandler: DownloadAttachmentHandler,
+		},
+		{
+			Method:      "issues.worklog.add",
+			Title:       "Log Work",
+			Description: "Log time spent against a Jira issue",
+			Form: sdkv2Models.ActionFormBuilder{
+				Jsonui: map[string]any{
+					"type": "VerticalLayout",
+					"elements": []map[string]any{
+						{
+							"type":  "Control",
+							"scope": "#/properties/issueKey",
+						},
+						{
+							"type":  "Control",
+							"scope": "#/properties/timeSpent",
+						},
+						{
+							"type":  "Control",
+							"scope": "#/properties/started",
+						},
+						{
+							"type":  "Control",
+							"scope": "#/properties/comment",
+						},
+					},
+				},
+				Jsonschema: map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"issueKey": map[string]any{
+							"type":        "string",
+							"title":       "Issue Key or ID",
+							"description": "The issue key (e.g., COM-123) or issue ID",
+						},
+						"timeSpent": map[string]any{
+							"type":        "string",
+							"title":       "Time Spent",
+							"description": "Time spent in Jira format (e.g., 3h 30m, 1d, 45m)",
+						},
+						"started": map[string]any{
+							"type":        "string",
+							"title":       "Started (Optional)",
+							"description": "When the work started (e.g., 2024-12-31T09:00:00Z). Defaults to now.",
+							"format":      "date-time",
+						},
+						"comment": map[string]any{
+							"type":        "string",
+							"title":       "Comment (Optional)",
+							"description": "Description of the work done",
+							"format":      "textarea",
+						},
+					},
+					"required": []string{"issueKey", "timeSpent"},
+				},
+			},
+			RequestHandler: AddWorklogHandler,
+		},
+		{
+			Method:      "issues.notify",
+			Title:       "Notify About Issue",
+			Description: "Email a notification about a Jira issue to its reporter, assignee, watchers, specific users or groups",
+			Form: sdkv2Models.ActionFormBuilder{
+				Jsonui: map[string]any{
+					"type": "VerticalLayout",
+					"elements": []map[string]any{
+						{
+							"type":  "Control",
+							"scope": "#/properties/issueKey",
+						},
+						{
+							"type":  "Control",
+							"scope": "#/properties/subject",
+						},
+						{
+							"type":  "Control",
+							"scope": "#/properties/textBody",
+						},
+						{
+							"type":  "Control",
+							"scope": "#/properties/notifyReporter",
+						},
+						{
+							"type":  "Control",
+							"scope": "#/properties/notifyAssignee",
+						},
+						{
+							"type":  "Control",
+							"scope": "#/properties/notifyWatchers",
+						},
+						{
+							"type":  "Control",
+							"scope": "#/properties/users",
+						},
+						{
+							"type":  "Control",
+							"scope": "#/properties/groups",
+						},
+					},
+				},
+				Jsonschema: map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"issueKey": map[string]any{
+							"type":        "string",
+							"title":       "Issue Key or ID",
+							"description": "The issue key (e.g., COM-123) or issue ID",
+						},
+						"subject": map[string]any{
+							"type":        "string",
+							"title":       "Subject",
+							"description": "Email subject. Defaults to the issue key and summary",
+						},
+						"textBody": map[string]any{
+							"type":        "string",
+							"title":       "Message",
+							"description": "Plain-text email body",
+							"format":      "textarea",
+						},
+						"notifyReporter": map[string]any{
+							"type":        "boolean",
+							"title":       "Notify Reporter",
+							"description": "Send the notification to the issue reporter",
+							"default":     false,
+						},
+						"notifyAssignee": map[string]any{
+							"type":        "boolean",
+							"title":       "Notify Assignee",
+							"description": "Send the notification to the issue assignee",
+							"default":     false,
+						},
+						"notifyWatchers": map[string]any{
+							"type":        "boolean",
+							"title":       "Notify Watchers",
+							"description": "Send the notification to the issue watchers",
+							"default":     false,
+						},
+						"users": map[string]any{
+							"type":        "array",
+							"title":       "Users (Optional)",
+							"description": "Account IDs of additional users to notify",
+							"items": map[string]any{
+								"type": "string",
+							},
+						},
+						"groups": map[string]any{
+							"type":        "array",
+							"title":       "Groups (Optional)",
+							"description": "Names of groups to notify",
+							"items": map[string]any{
+								"type": "string",
+							},
+						},
+					},
+					"required": []string{"issueKey", "textBody"},
+				},
+			},
+			RequestHandler: NotifyIssueHandler,
+		},
+		{
+			Method:      "issues.link",
+			Title:       "Link Issues",
+			Description: "Link two Jira issues together (e.g. blocks, relates to)",
+			Form: sdkv2Models.ActionFormBuilder{
+				Jsonui: map[string]any{
+					"type": "VerticalLayout",
+					"elements": []map[string]any{
+						{
+							"type":  "Control",
+							"scope": "#/properties/inwardIssueKey",
+						},
+						{
+							"type":  "Control",
+							"scope": "#/properties/outwardIssueKey",
+						},
+						{
+							"type":  "Control",
+							"scope": "#/properties/linkType",
+						},
+						{
+							"type":  "Control",
+							"scope": "#/properties/comment",
+						},
+					},
+				},
+				Jsonschema: map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"inwardIssueKey": map[string]any{
+							"type":        "string",
+							"title":       "Inward Issue Key",
+							"description": "The inward issue of the link (e.g., for \"blocks\": the issue that is blocked)",
+						},
+						"outwardIssueKey": map[string]any{
+							"type":        "string",
+							"title":       "Outward Issue Key",
+							"description": "The outward issue of the link (e.g., for \"blocks\": the issue that blocks)",
+						},
+						"linkType": map[string]any{
+							"type":        "string",
+							"title":       "Link Type",
+							"description": "Name of the link type (e.g., Blocks, Relates, Duplicate). Use issues.link.types to list valid names.",
+						},
+						"comment": map[string]any{
+							"type":        "string",
+							"title":       "Comment (Optional)",
+							"description": "Comment to add along with the link",
+							"format":      "textarea",
+						},
+					},
+					"required": []string{"inwardIssueKey", "outwardIssueKey", "linkType"},
+				},
+			},
+			RequestHandler: LinkIssuesHandler,
+		},
+		{
+			Method:      "issues.link.types",
+			Title:       "List Link Types",
+			Description: "List the issue link types available in your Jira instance",
+			Form: sdkv2Models.ActionFormBuilder{
+				Jsonui:     map[string]any{},
+				Jsonschema: map[string]any{"type": "object", "properties": map[string]any{}},
+			},
+			RequestHandler: ListLinkTypesHandler,
+		},
+		{
+			Method:      "issues.watchers.add",
+			Title:       "Add Watcher",
+			Description: "Add a user as a watcher of a Jira issue",
+			Form: sdkv2Models.ActionFormBuilder{
+				Jsonui: map[string]any{
+					"type": "VerticalLayout",
+					"elements": []map[string]any{
+						{
+							"type":  "Control",
+							"scope": "#/properties/issueKey",
+						},
+						{
+							"type":  "Control",
+							"scope": "#/properties/accountId",
+						},
+					},
+				},
+				Jsonschema: map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"issueKey": map[string]any{
+							"type":        "string",
+							"title":       "Issue Key or ID",
+							"description": "The issue key (e.g., COM-123) or issue ID",
+						},
+						"accountId": map[string]any{
+							"type":        "string",
+							"title":       "Account ID",
+							"description": "Account ID of the user to add as a watcher",
+						},
+					},
+					"required": []string{"issueKey", "accountId"},
+				},
+			},
+			RequestHandler: AddWatcherHandler,
+		},
+		{
+			Method:      "issues.watchers.remove",
+			Title:       "Remove Watcher",
+			Description: "Remove a user from the watchers of a Jira issue",
+			Form: sdkv2Models.ActionFormBuilder{
+				Jsonui: map[string]any{
+					"type": "VerticalLayout",
+					"elements": []map[string]any{
+						{
+							"type":  "Control",
+							"scope": "#/properties/issueKey",
+						},
+						{
+							"type":  "Control",
+							"scope": "#/properties/accountId",
+						},
+					},
+				},
+				Jsonschema: map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"issueKey": map[string]any{
+							"type":        "string",
+							"title":       "Issue Key or ID",
+							"description": "The issue key (e.g., COM-123) or issue ID",
+						},
+						"accountId": map[string]any{
+							"type":        "string",
+							"title":       "Account ID",
+							"description": "Account ID of the watcher to remove",
+						},
+					},
+					"required": []string{"issueKey", "accountId"},
+				},
+			},
+			RequestHandler: RemoveWatcherHandler,
+		},
+		{
+			Method:      "issues.watchers.list",
+			Title:       "List Watchers",
+			Description: "List the watchers of a Jira issue",
+			Form: sdkv2Models.ActionFormBuilder{
+				Jsonui: map[string]any{
+					"type": "VerticalLayout",
+					"elements": []map[string]any{
+						{
+							"type":  "Control",
+							"scope": "#/properties/issueKey",
+						},
+					},
+				},
+				Jsonschema: map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"issueKey": map[string]any{
+							"type":        "string",
+							"title":       "Issue Key or ID",
+							"description": "The issue key (e.g., COM-123) or issue ID",
+						},
+					},
+					"required": []string{"issueKey"},
+				},
+			},
+			RequestHandler: ListWatchersHandler,
+		},
+		{
+			Method:      "issues.watchers.am-i-watching",
+			Title:       "Am I Watching?",
+			Description: "Check whether the connected user is watching a Jira issue",
+			Form: sdkv2Models.ActionFormBuilder{
+				Jsonui: map[string]any{
+					"type": "VerticalLayout",
+					"elements": []map[string]any{
+						{
+							"type":  "Control",
+							"scope": "#/properties/issueKey",
+						},
+					},
+				},
+				Jsonschema: map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"issueKey": map[string]any{
+							"type":        "string",
+							"title":       "Issue Key or ID",
+							"description": "The issue key (e.g., COM-123) or issue ID",
+						},
+					},
+					"required": []string{"issueKey"},
+				},
+			},
+			RequestHandler: AmIWatchingHandler,
+		},
+		{
+			Method:      "issues.vote",
+			Title:       "Vote for Issue",
+			Description: "Vote for a Jira issue as the connected user",
+			Form: sdkv2Models.ActionFormBuilder{
+				Jsonui: map[string]any{
+					"type": "VerticalLayout",
+					"elements": []map[string]any{
+						{
+							"type":  "Control",
+							"scope": "#/properties/issueKey",
+						},
+					},
+				},
+				Jsonschema: map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"issueKey": map[string]any{
+							"type":        "string",
+							"title":       "Issue Key or ID",
+							"description": "The issue key (e.g., COM-123) or issue ID",
+						},
+					},
+					"required": []string{"issueKey"},
+				},
+			},
+			RequestHandler: AddVoteHandler,
+		},
+
+		{
+			Method:      "issues.unvote",
+			Title:       "Remove Vote",
+			Description: "Withdraw the connected user's vote for a Jira issue",
+			Form: sdkv2Models.ActionFormBuilder{
+				Jsonui: map[string]any{
+					"type": "VerticalLayout",
+					"elements": []map[string]any{
+						{
+							"type":  "Control",
+							"scope": "#/properties/issueKey",
+						},
+					},
+				},
+				Jsonschema: map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"issueKey": map[string]any{
+							"type":        "string",
+							"title":       "Issue Key or ID",
+							"description": "The issue key (e.g., COM-123) or issue ID",
+						},
+					},
+					"required": []string{"issueKey"},
+				},
+			},
+			RequestHandler: RemoveVoteHandler,
+		},
+
+		{
+			Method:      "issues.votes",
+			Title:       "Get Votes",
+			Description: "Get the vote count of a Jira issue, whether the connected user voted, and the voters",
+			Form: sdkv2Models.ActionFormBuilder{
+				Jsonui: map[string]any{
+					"type": "VerticalLayout",
+					"elements": []map[string]any{
+						{
+							"type":  "Control",
+							"scope": "#/properties/issueKey",
+						},
+					},
+				},
+				Jsonschema: map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"issueKey": map[string]any{
+							"type":        "string",
+							"title":       "Issue Key or ID",
+							"description": "The issue key (e.g., COM-123) or issue ID",
+						},
+					},
+					"required": []string{"issueKey"},
+				},
+			},
+			RequestHandler: GetVotesHandler,
+		},
+		{
+			Method:      "issues.labels.add",
+			Title:       "Add Labels",
+			Description: "Add labels to a Jira issue without replacing existing labels",
+			Form: sdkv2Models.ActionFormBuilder{
+				Jsonui: map[string]any{
+					"type": "VerticalLayout",
+					"elements": []map[string]any{
+						{
+							"type":  "Control",
+							"scope": "#/properties/issueKey",
+						},
+						{
+							"type":  "Control",
+							"scope": "#/properties/add",
+						},
+					},
+				},
+				Jsonschema: map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"issueKey": map[string]any{
+							"type":        "string",
+							"title":       "Issue Key or ID",
+							"description": "The issue key (e.g., COM-123) or issue ID",
+						},
+						"add": map[string]any{
+							"type":        "array",
+							"title":       "Labels to Add",
+							"description": "Labels to add to the issue (labels cannot contain spaces)",
+							"items": map[string]any{
+								"type": "string",
+							},
+						},
+					},
+					"required": []string{"issueKey", "add"},
+				},
+			},
+			RequestHandler: AddLabelsHandler,
+		},
+		{
+			Method:      "issues.labels.remove",
+			Title:       "Remove Labels",
+			Description: "Remove labels from a Jira issue",
+			Form: sdkv2Models.ActionFormBuilder{
+				Jsonui: map[string]any{
+					"type": "VerticalLayout",
+					"elements": []map[string]any{
+						{
+							"type":  "Control",
+							"scope": "#/properties/issueKey",
+						},
+						{
+							"type":  "Control",
+							"scope": "#/properties/remove",
+						},
+					},
+				},
+				Jsonschema: map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"issueKey": map[string]any{
+							"type":        "string",
+							"title":       "Issue Key or ID",
+							"description": "The issue key (e.g., COM-123) or issue ID",
+						},
+						"remove": map[string]any{
+							"type":        "array",
+							"title":       "Labels to Remove",
+							"description": "Labels to remove from the issue",
+							"items": map[string]any{
+								"type": "string",
+							},
+						},
+					},
+					"required": []string{"issueKey", "remove"},
+				},
+			},
+			RequestHandler: RemoveLabelsHandler,
+		},
+		{
+			Method:      "issues.labels.suggest",
+			Title:       "Suggest Labels",
+			Description: "Find existing Jira labels matching a query, to reuse them instead of creating near-duplicates",
+			Form: sdkv2Models.ActionFormBuilder{
+				Jsonui: map[string]any{
+					"type": "VerticalLayout",
+					"elements": []map[string]any{
+						{
+							"type":  "Control",
+							"scope": "#/properties/query",
+						},
+						{
+							"type":  "Control",
+							"scope": "#/properties/maxResults",
+						},
+					},
+				},
+				Jsonschema: map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"query": map[string]any{
+							"type":        "string",
+							"title":       "Query",
+							"description": "Text the labels should match (e.g., back for backend)",
+						},
+						"maxResults": map[string]any{
+							"type":        "integer",
+							"title":       "Max Results",
+							"description": "Maximum number of labels to return (at most 50)",
+							"default":     20,
+						},
+					},
+				},
+			},
+			RequestHandler: SuggestLabelsHandler,
+		},
+	}
+}
+
+// CreateIssueHandler handles the issues.create action
+func CreateIssueHandler(msg *nats.Msg) {
+	handler.Run(msg, "issues.create", func(ctx context.Context, creds *credentials.JiraCredentials, body map[string]any, progress handler.ProgressFunc) map[string]any {
+		// Extract core form fields
+		projectKey, _ := body["projectKey"].(string)
+		issueType, _ := body["issueType"].(string)
+		summary, _ := body["summary"].(string)
+		description, _ := body["description"].(string)
+		idempotencyKey, _ := body["idempotencyKey"].(string)
+		idempotencyKey = strings.TrimSpace(idempotencyKey)
+		validate := handler.BoolValue(body, "validate")
+
+		// Extract additionalFields if provided (as object)
+		var additionalFields map[string]interface{}
+		if additionalFieldsRaw, ok := body["additionalFields"]; ok {
+			if afMap, ok := additionalFieldsRaw.(map[string]interface{}); ok {
+				additionalFields = afMap
+			} else if afMap, ok := additionalFieldsRaw.(map[string]any); ok {
+				// Convert map[string]any to map[string]interface{}
+				additionalFields = make(map[string]interface{})
+				for k, v := range afMap {
+					additionalFields[k] = v
+				}
+			}
+		}
+
+		// Also check for any other fields that might have been passed directly
+		// (for backward compatibility and flexibility)
+		knownFields := map[string]bool{
+			"projectKey":        true,
+			"issueType":         true,
+			"summary":           true,
+			"description":       true,
+			"additionalFields":  true,
+			"idempotencyKey":    true,
+			"validate":          true,
+			"priority":          true,
+			"labels":            true,
+			"components":        true,
+			"assignee":          true,
+			"originalEstimate":  true,
+			"remainingEstimate": true,
+		}
+
+		// Merge any other fields that aren't in the known list into additionalFields
+		if additionalFields == nil {
+			additionalFields = make(map[string]interface{})
+		}
+		// Empty top-level strings are untouched form inputs and are skipped; explicit
+		// false/0/"" values should be sent inside additionalFields
+		for key, value := range body {
+			if !knownFields[key] && value != nil && value != "" {
+				additionalFields[key] = value
+			}
+		}
+
+		// Map the first-class form fields into Jira's field structures. Values that are
+		// already Jira structures (e.g. {"name": "High"}) are passed through unchanged.
+		common := client.CommonIssueFields{
+			Labels:     handler.StringSlice(body, "labels"),
+			Components: handler.StringSlice(body, "components"),
+		}
+		common.Priority, _ = body["priority"].(string)
+		common.Assignee, _ = body["assignee"].(string)
+		common.OriginalEstimate, common.RemainingEstimate = getEstimates(body)
+		for _, key := range []string{"priority", "assignee"} {
+			if value, ok := body[key].(map[string]interface{}); ok {
+				additionalFields[key] = value
+			}
+		}
+		maps.Copy(additionalFields, common.Fields())
+
+		// Validate required fields; the project falls back to the space's default project
+		if projectKey == "" {
+			projectKey = creds.DefaultProjectKey
+		}
+		if projectKey == "" {
+			return map[string]any{
+				"error":   "validation_error",
+				"message": "Project key is required (no default project is configured for this space)",
+			}
+		}
+		if issueType == "" {
+			return map[string]any{
+				"error":   "validation_error",
+				"message": "Issue type is required",
+			}
+		}
+		if summary == "" {
+			return map[string]any{
+				"error":   "validation_error",
+				"message": "Summary is required",
+			}
+		}
+		if err := client.ValidateLabels(common.Labels); err != nil {
+			return map[string]any{
+				"error":   "validation_error",
+				"message": fmt.Sprintf("Invalid labels: %v", err),
+			}
+		}
+		if err := validateEstimates(common); err != nil {
+			return map[string]any{
+				"error":   "validation_error",
+				"message": fmt.Sprintf("Invalid estimate: %v", err),
+			}
+		}
+
+		// A repeated idempotency key returns the result of the first request instead
+		// of creating a duplicate issue
+		if idempotencyKey != "" {
+			return createIdempotency.do(ctx, idempotencyScope(creds, idempotencyKey), func() map[string]any {
+				return createIssue(ctx, creds, projectKey, issueType, summary, description, additionalFields, validate)
+			})
+		}
+		return createIssue(ctx, creds, projectKey, issueType, summary, description, additionalFields, validate)
+	})
+}
+
+// createIssue creates one issue and builds the issues.create result. With validate set,
+// the project, issue type and required fields are checked against createmeta first.
+func createIssue(ctx context.Context, creds *credentials.JiraCredentials, projectKey, issueType, summary, description string, additionalFields map[string]interface{}, validate bool) map[string]any {
+	// Create Jira client, translate field names such as "Story Points" to IDs, and create issue
+	jiraClient := client.NewJiraClient(creds)
+	additionalFields, resolvedFields := jiraClient.TranslateFieldNames(ctx, additionalFields)
+	if validate {
+		problems, err := jiraClient.ValidateIssueCreate(ctx, projectKey, issueType, description != "", additionalFields)
+		if err != nil {
+			log.Printf("Failed to validate issue: %v", err)
+			return map[string]any{
+				"error":   client.ErrorCode(err),
+				"message": fmt.Sprintf("Failed to validate issue: %v", err),
+			}
+		}
+		if len(problems) > 0 {
+			return map[string]any{
+				"error":    "validation_error",
+				"message":  strings.Join(problems, "; "),
+				"problems": problems,
+			}
+		}
+	} else {
+		// Always check the issue type: Jira's own error for a type the project's scheme
+		// doesn't include is obscure. If the check itself fails, let Jira decide.
+		problem, err := jiraClient.CheckIssueType(ctx, projectKey, issueType)
+		if err != nil {
+			log.Printf("Could not check issue type '%s' for project %s, creating without the check: %v", issueType, projectKey, err)
+		} else if problem != "" {
+			return map[string]any{
+				"error":    "validation_error",
+				"message":  problem,
+				"problems": []string{problem},
+			}
+		}
+	}
+	issue, err := jiraClient.CreateIssue(ctx, projectKey, issueType, summary, description, additionalFields)
+	if err != nil {
+		log.Printf("Failed to create issue: %v", err)
+		return map[string]any{
+			"error":   client.ErrorCode(err),
+			"message": fmt.Sprintf("Failed to create issue: %v", err),
+		}
+	}
+
+	// Extract issue key from response
+	issueKey, _ := issue["key"].(string)
+	issueId, _ := issue["id"].(string)
+
+	log.Printf("Successfully created Jira issue: %s (ID: %s)", issueKey, issueId)
+
+	result := map[string]any{
+		"result":    "success",
+		"message":   "Issue created successfully",
+		"issueKey":  issueKey,
+		"issueId":   issueId,
+		"browseUrl": jiraClient.BrowseURL(issueKey),
+		"issue":     issue,
+	}
+	if len(resolvedFields) > 0 {
+		result["resolvedFields"] = resolvedFields
+	}
+	if _, ok := additionalFields["timetracking"]; ok {
+		if estimates := issueEstimates(ctx, jiraClient, issueKey); estimates != nil {
+			result["timetracking"] = estimates
+		}
+	}
+	return result
+}
+
+// getEstimates reads the originalEstimate and remainingEstimate form fields
+func getEstimates(body map[string]any) (original, remaining string) {
+	original, _ = body["originalEstimate"].(string)
+	remaining, _ = body["remainingEstimate"].(string)
+	return strings.TrimSpace(original), strings.TrimSpace(remaining)
+}
+
+// validateEstimates checks the format of the estimates that are set
+func validateEstimates(common client.CommonIssueFields) error {
+	for _, estimate := range []string{common.OriginalEstimate, common.RemainingEstimate} {
+		if estimate == "" {
+			continue
+		}
+		if err := client.ValidateDuration(estimate); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// issueEstimates reads back an issue's timetracking field, which holds the estimates
+// as Jira stored them (plus their length in seconds). The estimates were already
+// saved, so failing to read them only leaves them out of the result.
+func issueEstimates(ctx context.Context, jiraClient *client.JiraClient, issueKey string) map[string]interface{} {
+	issue, err := jiraClient.GetIssue(ctx, issueKey, []string{"timetracking"}, nil)
+	if err != nil {
+		log.Printf("Could not read back the estimates of %s: %v", issueKey, err)
+		return nil
+	}
+	fields, _ := issue["fields"].(map[string]interface{})
+	timetracking, _ := fields["timetracking"].(map[string]interface{})
+	return timetracking
+}
+
+// CreateMetaHandler handles the issues.createmeta action
+func CreateMetaHandler(msg *nats.Msg) {
+	handler.Run(msg, "issues.createmeta", func(ctx context.Context, creds *credentials.JiraCredentials, body map[string]any, progress handler.ProgressFunc) map[string]any {
+		// Extract form fields
+		projectKey, _ := body["projectKey"].(string)
+		issueType, _ := body["issueType"].(string)
+
+		// Validate required fields
+		if projectKey == "" {
+			return map[string]any{
+				"error":   "validation_error",
+				"message": "Project key is required",
+			}
+		}
+		if issueType == "" {
+			return map[string]any{
+				"error":   "validation_error",
+				"message": "Issue type is required",
+			}
+		}
+
+		jiraClient := client.NewJiraClient(creds)
+		if handler.BoolValue(body, "refresh") {
+			jiraClient.InvalidateCache()
+		}
+		meta, err := jiraClient.GetCreateMeta(ctx, projectKey, issueType)
+		if err != nil {
+			log.Printf("Failed to get createmeta: %v", err)
+			return map[string]any{
+				"error":   client.ErrorCode(err),
+				"message": fmt.Sprintf("Failed to get create fields: %v", err),
+			}
+		}
+
+		fields, _ := meta["fields"].([]map[string]interface{})
+		required, _ := meta["required"].([]string)
+		result := map[string]any{
+			"result":     "success",
+			"message":    fmt.Sprintf("Found %d fields (%d required) for creating %s issues in %s", len(fields), len(required), issueType, projectKey),
+			"projectKey": meta["projectKey"],
+			"issueType":  meta["issueType"],
+			"fields":     fields,
+			"required":   required,
+		}
+		return result
+	})
+}
+
+// ListFieldsHandler handles the fields.list action
+func ListFieldsHandler(msg *nats.Msg) {
+	handler.Run(msg, "fields.list", func(ctx context.Context, creds *credentials.JiraCredentials, body map[string]any, progress handler.ProgressFunc) map[string]any {
+		// Extract form fields
+		query, _ := body["query"].(string)
+		query = strings.ToLower(strings.TrimSpace(query))
+		customOnly := handler.BoolValue(body, "customOnly")
+
+		jiraClient := client.NewJiraClient(creds)
+		if handler.BoolValue(body, "refresh") {
+			jiraClient.InvalidateCache()
+		}
+		allFields, err := jiraClient.ListFields(ctx)
+		if err != nil {
+			log.Printf("Failed to list fields: %v", err)
+			return map[string]any{
+				"error":   client.ErrorCode(err),
+				"message": fmt.Sprintf("Failed to list fields: %v", err),
+			}
+		}
+
+		// The list is cached and shared, so summaries are built instead of editing it
+		fields := make([]map[string]any, 0, len(allFields))
+		for _, field := range allFields {
+			name, _ := field["name"].(string)
+			custom, _ := field["custom"].(bool)
+			if (customOnly && !custom) || !strings.Contains(strings.ToLower(name), query) {
+				continue
+			}
+			summary := map[string]any{
+				"id":     field["id"],
+				"name":   name,
+				"custom": custom,
+			}
+			if schema, ok := field["schema"].(map[string]interface{}); ok {
+				summary["type"] = schema["type"]
+				if items, ok := schema["items"]; ok {
+					summary["items"] = items
+				}
+				if customType, ok := schema["custom"]; ok {
+					summary["customType"] = customType
+				}
+			}
+			fields = append(fields, summary)
+		}
+		slices.SortFunc(fields, func(a, b map[string]any) int {
+			return strings.Compare(strings.ToLower(a["name"].(string)), strings.ToLower(b["name"].(string)))
+		})
+
+		result := map[string]any{
+			"result":  "success",
+			"message": fmt.Sprintf("Found %d fields", len(fields)),
+			"fields":  fields,
+			"count":   len(fields),
+		}
+		return result
+	})
+}
+
+// CreateSubtaskHandler handles the issues.subtask.create action
+func CreateSubtaskHandler(msg *nats.Msg) {
+	handler.Run(msg, "issues.subtask.create", func(ctx context.Context, creds *credentials.JiraCredentials, body map[string]any, progress handler.ProgressFunc) map[string]any {
+		// Extract form fields
+		parentKey, _ := body["parentKey"].(string)
+		summary, _ := body["summary"].(string)
+		description, _ := body["description"].(string)
+		issueType, _ := body["issueType"].(string)
+		additionalFields, _ := body["additionalFields"].(map[string]interface{})
+
+		// Validate required fields
+		if parentKey == "" {
+			return map[string]any{
+				"error":   "validation_error",
+				"message": "Parent issue key is required",
+			}
+		}
+		if summary == "" {
+			return map[string]any{
+				"error":   "validation_error",
+				"message": "Summary is required",
+			}
+		}
+
+		// The parent must exist, and its project and type decide where the subtask goes
+		jiraClient := client.NewJiraClient(creds)
+		parent, err := jiraClient.GetIssue(ctx, parentKey, []string{"project", "issuetype"}, nil)
+		if err != nil {
+			log.Printf("Failed to get parent issue: %v", err)
+			if errors.Is(err, client.ErrNotFound) {
+				return map[string]any{
+					"error":   "not_found",
+					"message": fmt.Sprintf("Parent issue %s not found", parentKey),
+				}
+			}
+			return map[string]any{
+				"error":   client.ErrorCode(err),
+				"message": fmt.Sprintf("Failed to get parent issue: %v", err),
+			}
+		}
+		var projectKey string
+		parentFields, _ := parent["fields"].(map[string]interface{})
+		if project, ok := parentFields["project"].(map[string]interface{}); ok {
+			projectKey, _ = project["key"].(string)
+		}
+		if parentType, ok := parentFields["issuetype"].(map[string]interface{}); ok {
+			if isSubtask, _ := parentType["subtask"].(bool); isSubtask {
+				return map[string]any{
+					"error":   "validation_error",
+					"message": fmt.Sprintf("Issue %s is itself a subtask and cannot have subtasks", parentKey),
+				}
+			}
+		}
+
+		// Default to the project's (first) subtask issue type
+		if issueType == "" {
+			issueTypes, err := jiraClient.ListIssueTypesForProject(ctx, projectKey)
+			if err != nil {
+				log.Printf("Failed to list issue types: %v", err)
+				return map[string]any{
+					"error":   client.ErrorCode(err),
+					"message": fmt.Sprintf("Failed to list issue types of project %s: %v", projectKey, err),
+				}
+			}
+			for _, t := range issueTypes {
+				if isSubtask, _ := t["subtask"].(bool); isSubtask {
+					issueType, _ = t["name"].(string)
+					break
+				}
+			}
+			if issueType == "" {
+				return map[string]any{
+					"error":   "validation_error",
+					"message": fmt.Sprintf("Project %s has no subtask issue type", projectKey),
+				}
+			}
+		}
+
+		// Translate field names such as "Story Points" to IDs and create the subtask
+		additionalFields, resolvedFields := jiraClient.TranslateFieldNames(ctx, additionalFields)
+		subtask, err := jiraClient.CreateSubtask(ctx, parentKey, projectKey, issueType, summary, description, additionalFields)
+		if err != nil {
+			log.Printf("Failed to create subtask: %v", err)
+			return map[string]any{
+				"error":   client.ErrorCode(err),
+				"message": fmt.Sprintf("Failed to create subtask: %v", err),
+			}
+		}
+
+		subtaskKey, _ := subtask["key"].(string)
+		subtaskId, _ := subtask["id"].(string)
+		log.Printf("Successfully created Jira subtask %s under %s", subtaskKey, parentKey)
+
+		result := map[string]any{
+			"result":    "success",
+			"message":   fmt.Sprintf("Subtask %s created under %s", subtaskKey, parentKey),
+			"issueKey":  subtaskKey,
+			"issueId":   subtaskId,
+			"parentKey": parentKey,
+			"issueType": issueType,
+			"browseUrl": jiraClient.BrowseURL(subtaskKey),
+		}
+		if len(resolvedFields) > 0 {
+			result["resolvedFields"] = resolvedFields
+		}
+		return result
+	})
+}
+
+// BulkCreateIssuesHandler handles the issues.bulk-create action
+func BulkCreateIssuesHandler(msg *nats.Msg) {
+	handler.Run(msg, "issues.bulk-create", func(ctx context.Context, creds *credentials.JiraCredentials, body map[string]any, progress handler.ProgressFunc) map[string]any {
+		rows, _ := body["issues"].([]interface{})
+		if len(rows) == 0 {
+			return map[string]any{
+				"error":   "validation_error",
+				"message": "At least one issue is required",
+			}
+		}
+
+		// Rows missing required fields are reported individually and not sent to Jira
+		results := make([]map[string]interface{}, len(rows))
+		warnings := []string{}
+		var valid []map[string]interface{}
+		var validIndexes []int
+		for i, raw := range rows {
+			row, ok := raw.(map[string]interface{})
+			if !ok {
+				results[i] = map[string]interface{}{"index": i, "error": "Issue must be an object"}
+				continue
+			}
+			projectKey, _ := row["projectKey"].(string)
+			issueType, _ := row["issueType"].(string)
+			summary, _ := row["summary"].(string)
+			if projectKey == "" || issueType == "" || summary == "" {
+				results[i] = map[string]interface{}{"index": i, "error": "projectKey, issueType and summary are required"}
+				continue
+			}
+			valid = append(valid, row)
+			validIndexes = append(validIndexes, i)
+		}
+
+		if len(valid) > 0 {
+			// Create Jira client and create the issues
+			jiraClient := client.NewJiraClient(creds)
+			bulkResult, err := jiraClient.BulkCreateIssues(ctx, valid, func(done, total int) {
+				progress(done*100/total, fmt.Sprintf("Created batch: %d of %d issues processed", done, total))
+			})
+			if err != nil {
+				log.Printf("Failed to bulk create issues: %v", err)
+				return map[string]any{
+					"error":   client.ErrorCode(err),
+					"message": fmt.Sprintf("Failed to bulk create issues: %v", err),
+				}
+			}
+			if bulkWarnings, ok := bulkResult["warnings"].([]string); ok {
+				warnings = bulkWarnings
+			}
+			// Map the results back to the positions of the submitted rows
+			created, _ := bulkResult["issues"].([]map[string]interface{})
+			for j, result := range created {
+				result["index"] = validIndexes[j]
+				if key, ok := result["key"].(string); ok {
+					result["browseUrl"] = jiraClient.BrowseURL(key)
+				}
+				results[validIndexes[j]] = result
+			}
+		}
+
+		createdCount, failedCount := 0, 0
+		var createdKeys []interface{}
+		for _, result := range results {
+			if _, failed := result["error"]; failed {
+				failedCount++
+			} else {
+				createdCount++
+				createdKeys = append(createdKeys, result["key"])
+			}
+		}
+
+		log.Printf("Bulk create: %d issues created, %d failed", createdCount, failedCount)
+
+		return handler.BatchOutcome(map[string]any{
+			"message":   fmt.Sprintf("Created %d of %d issues (%d failed)", createdCount, len(rows), failedCount),
+			"issues":    results,
+			"issueKeys": createdKeys,
+			"created":   createdCount,
+			"warnings":  warnings,
+		}, createdCount, failedCount)
+	})
+}
+
+// GetIssueHandler handles the issues.get action
+func GetIssueHandler(msg *nats.Msg) {
+	handler.Run(msg, "issues.get", func(ctx context.Context, creds *credentials.JiraCredentials, body map[string]any, progress handler.ProgressFunc) map[string]any {
+		// Extract form fields
+		issueKey, _ := body["issueKey"].(string)
+		fields := handler.StringSlice(body, "fields")
+		expand := handler.StringSlice(body, "expand")
+		rendered := handler.BoolValue(body, "rendered")
+
+		// Validate required fields
+		if issueKey == "" {
+			return map[string]any{
+				"error":   "validation_error",
+				"message": "Issue key or ID is required",
+			}
+		}
+
+		// Rendering is opt-in: Jira converts wiki markup or ADF to HTML only when asked
+		if rendered && !slices.Contains(expand, "renderedFields") {
+			expand = append(expand, "renderedFields")
+		}
+
+		// Create Jira client and fetch issue
+		jiraClient := client.NewJiraClient(creds)
+		issue, err := jiraClient.GetIssue(ctx, issueKey, fields, expand)
+		if err != nil {
+			log.Printf("Failed to get issue: %v", err)
+			if errors.Is(err, client.ErrNotFound) {
+				return map[string]any{
+					"error":   "not_found",
+					"message": fmt.Sprintf("Issue %s not found", issueKey),
+				}
+			}
+			return map[string]any{
+				"error":   client.ErrorCode(err),
+				"message": fmt.Sprintf("Failed to get issue: %v", err),
+			}
+		}
+
+		// Flatten commonly used fields for convenience
+		var summary, status, assignee string
+		if issueFields, ok := issue["fields"].(map[string]interface{}); ok {
+			summary, _ = issueFields["summary"].(string)
+			if statusMap, ok := issueFields["status"].(map[string]interface{}); ok {
+				status, _ = statusMap["name"].(string)
+			}
+			if assigneeMap, ok := issueFields["assignee"].(map[string]interface{}); ok {
+				assignee, _ = assigneeMap["displayName"].(string)
+			}
+		}
+
+		resolvedKey, _ := issue["key"].(string)
+		issueId, _ := issue["id"].(string)
+
+		log.Printf("Successfully retrieved Jira issue: %s (ID: %s)", resolvedKey, issueId)
+
+		result := map[string]any{
+			"result":    "success",
+			"message":   fmt.Sprintf("Issue %s retrieved successfully", resolvedKey),
+			"issueKey":  resolvedKey,
+			"issueId":   issueId,
+			"browseUrl": jiraClient.BrowseURL(resolvedKey),
+			"summary":   summary,
+			"status":    status,
+			"assignee":  assignee,
+			"issue":     issue,
+		}
+		if rendered {
+			// Null when the issue has no description (or it wasn't among the requested fields)
+			renderedFields, _ := issue["renderedFields"].(map[string]interface{})
+			result["renderedDescription"] = renderedFields["description"]
+		}
+		return result
+	})
+}
+
+// GetIssueChangelogHandler handles the issues.changelog action
+func GetIssueChangelogHandler(msg *nats.Msg) {
+	handler.Run(msg, "issues.changelog", func(ctx context.Context, creds *credentials.JiraCredentials, body map[string]any, progress handler.ProgressFunc) map[string]any {
+		// Extract form fields
+		issueKey, _ := body["issueKey"].(string)
+		maxResults := handler.IntValue(body, "maxResults", 100)
+		startAt := handler.IntValue(body, "startAt", 0)
+
+		// Validate required fields
+		if issueKey == "" {
+			return map[string]any{
+				"error":   "validation_error",
+				"message": "Issue key or ID is required",
+			}
+		}
+		if maxResults <= 0 {
+			maxResults = 100
+		}
+		if startAt < 0 {
+			startAt = 0
+		}
+
+		// Create Jira client and fetch the changelog
+		jiraClient := client.NewJiraClient(creds)
+		changelog, err := jiraClient.GetIssueChangelog(ctx, issueKey, startAt, maxResults)
+		if err != nil {
+			log.Printf("Failed to get changelog: %v", err)
+			if errors.Is(err, client.ErrNotFound) {
+				return map[string]any{
+					"error":   "not_found",
+					"message": fmt.Sprintf("Issue %s not found", issueKey),
+				}
+			}
+			return map[string]any{
+				"error":   client.ErrorCode(err),
+				"message": fmt.Sprintf("Failed to get changelog: %v", err),
+			}
+		}
+
+		// Flatten each history entry to its author, time and field changes
+		rawHistories, _ := changelog["values"].([]interface{})
+		histories := make([]map[string]any, 0, len(rawHistories))
+		for _, raw := range rawHistories {
+			history, ok := raw.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			var authorName, authorAccountId string
+			if author, ok := history["author"].(map[string]interface{}); ok {
+				authorName, _ = author["displayName"].(string)
+				authorAccountId, _ = author["accountId"].(string)
+			}
+			rawItems, _ := history["items"].([]interface{})
+			changes := make([]map[string]any, 0, len(rawItems))
+			for _, rawItem := range rawItems {
+				item, ok := rawItem.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				changes = append(changes, map[string]any{
+					"field": item["field"],
+					"from":  item["fromString"],
+					"to":    item["toString"],
+				})
+			}
+			histories = append(histories, map[string]any{
+				"id":              history["id"],
+				"author":          authorName,
+				"authorAccountId": authorAccountId,
+				"created":         history["created"],
+				"changes":         changes,
+			})
+		}
+
+		total := changelog["total"]
+		isLast, _ := changelog["isLast"].(bool)
+		log.Printf("Successfully retrieved %d history entries for Jira issue %s (total: %v)", len(histories), issueKey, total)
+
+		result := map[string]any{
+			"result":     "success",
+			"message":    fmt.Sprintf("Retrieved %d history entries for issue %s", len(histories), issueKey),
+			"issueKey":   issueKey,
+			"histories":  histories,
+			"total":      total,
+			"isLast":     isLast,
+			"startAt":    startAt,
+			"maxResults": maxResults,
+		}
+		return result
+	})
+}
+
+// MoveIssueHandler handles the issues.move action.
+// The v2 edit endpoint can change the issue type within a project, but it cannot move an
+// issue to another project or convert between standard and subtask types; those need
+// Jira's Move wizard and are reported as not supported.
+func MoveIssueHandler(msg *nats.Msg) {
+	handler.Run(msg, "issues.move", func(ctx context.Context, creds *credentials.JiraCredentials, body map[string]any, progress handler.ProgressFunc) map[string]any {
+		// Extract form fields
+		issueKey, _ := body["issueKey"].(string)
+		targetType, _ := body["issueType"].(string)
+		targetProject, _ := body["projectKey"].(string)
+
+		// Validate required fields
+		if issueKey == "" {
+			return map[string]any{
+				"error":   "validation_error",
+				"message": "Issue key or ID is required",
+			}
+		}
+		if targetType == "" {
+			return map[string]any{
+				"error":   "validation_error",
+				"message": "Target issue type is required",
+			}
+		}
+
+		// Look up the issue's current project and type
+		jiraClient := client.NewJiraClient(creds)
+		issue, err := jiraClient.GetIssue(ctx, issueKey, []string{"project", "issuetype"}, nil)
+		if err != nil {
+			log.Printf("Failed to get issue: %v", err)
+			if errors.Is(err, client.ErrNotFound) {
+				return map[string]any{
+					"error":   "not_found",
+					"message": fmt.Sprintf("Issue %s not found", issueKey),
+				}
+			}
+			return map[string]any{
+				"error":   client.ErrorCode(err),
+				"message": fmt.Sprintf("Failed to get issue: %v", err),
+			}
+		}
+		var projectKey, currentType string
+		var currentSubtask bool
+		issueFields, _ := issue["fields"].(map[string]interface{})
+		if project, ok := issueFields["project"].(map[string]interface{}); ok {
+			projectKey, _ = project["key"].(string)
+		}
+		if issueType, ok := issueFields["issuetype"].(map[string]interface{}); ok {
+			currentType, _ = issueType["name"].(string)
+			currentSubtask, _ = issueType["subtask"].(bool)
+		}
+
+		if targetProject != "" && !strings.EqualFold(targetProject, projectKey) {
+			return map[string]any{
+				"error":   "not_supported",
+				"message": fmt.Sprintf("Moving issue %s from project %s to %s is not supported by the Jira REST API. Use Move in the Jira UI instead", issueKey, projectKey, targetProject),
+			}
+		}
+
+		// The target type must exist in the project and be of the same kind (standard or subtask)
+		issueTypes, err := jiraClient.ListIssueTypesForProject(ctx, projectKey)
+		if err != nil {
+			log.Printf("Failed to list issue types: %v", err)
+			return map[string]any{
+				"error":   client.ErrorCode(err),
+				"message": fmt.Sprintf("Failed to list issue types of project %s: %v", projectKey, err),
+			}
+		}
+		var target map[string]interface{}
+		var validNames []string
+		for _, t := range issueTypes {
+			id, _ := t["id"].(string)
+			name, _ := t["name"].(string)
+			if subtask, _ := t["subtask"].(bool); subtask == currentSubtask {
+				validNames = append(validNames, name)
+			}
+			if id == targetType || strings.EqualFold(name, targetType) {
+				target = t
+			}
+		}
+		if target == nil {
+			return map[string]any{
+				"error":   "validation_error",
+				"message": fmt.Sprintf("Issue type '%s' does not exist in project %s. Valid issue types: %s", targetType, projectKey, strings.Join(validNames, ", ")),
+			}
+		}
+		targetID, _ := target["id"].(string)
+		targetName, _ := target["name"].(string)
+		if targetSubtask, _ := target["subtask"].(bool); targetSubtask != currentSubtask {
+			return map[string]any{
+				"error":   "not_supported",
+				"message": fmt.Sprintf("Converting issue %s between a standard issue type and a subtask type is not supported by the Jira REST API. Valid issue types: %s", issueKey, strings.Join(validNames, ", ")),
+			}
+		}
+		if strings.EqualFold(targetName, currentType) {
+			return map[string]any{
+				"result":    "success",
+				"message":   fmt.Sprintf("Issue %s already has issue type %s", issueKey, currentType),
+				"issueKey":  issueKey,
+				"issueType": currentType,
+			}
+		}
+
+		err = jiraClient.UpdateIssue(ctx, issueKey, map[string]interface{}{
+			"issuetype": map[string]interface{}{"id": targetID},
+		})
+		if err != nil {
+			log.Printf("Failed to change issue type: %v", err)
+			return map[string]any{
+				"error":   client.ErrorCode(err),
+				"message": fmt.Sprintf("Failed to change issue type of %s to %s (the types may use different workflows or screens; use Move in the Jira UI in that case): %v", issueKey, targetName, err),
+			}
+		}
+
+		log.Printf("Successfully changed issue type of %s from %s to %s", issueKey, currentType, targetName)
+
+		result := map[string]any{
+			"result":       "success",
+			"message":      fmt.Sprintf("Issue %s changed from %s to %s", issueKey, currentType, targetName),
+			"issueKey":     issueKey,
+			"previousType": currentType,
+			"issueType":    targetName,
+			"issueTypeId":  targetID,
+			"projectKey":   projectKey,
+		}
+		return result
+	})
+}
+
+// UpdateIssueHandler handles the issues.update action
+func UpdateIssueHandler(msg *nats.Msg) {
+	handler.Run(msg, "issues.update", func(ctx context.Context, creds *credentials.JiraCredentials, body map[string]any, progress handler.ProgressFunc) map[string]any {
+		// Extract core form fields
+		issueKey, _ := body["issueKey"].(string)
+		summary, _ := body["summary"].(string)
+		description, _ := body["description"].(string)
+
+		// Validate required fields
+		if issueKey == "" {
+			return map[string]any{
+				"error":   "validation_error",
+				"message": "Issue key or ID is required",
+			}
+		}
+
+		// Collect the fields to update, starting with additionalFields
+		fields := make(map[string]interface{})
+		if afMap, ok := body["additionalFields"].(map[string]interface{}); ok {
+			for k, v := range afMap {
+				fields[k] = v
+			}
+		}
+
+		// Also merge any other fields that might have been passed directly
+		knownFields := map[string]bool{
+			"issueKey":          true,
+			"summary":           true,
+			"description":       true,
+			"originalEstimate":  true,
+			"remainingEstimate": true,
+			"additionalFields":  true,
+		}
+		// Empty top-level strings are untouched form inputs and are skipped; explicit
+		// false/0/"" values should be sent inside additionalFields
+		for key, value := range body {
+			if !knownFields[key] && value != nil && value != "" {
+				fields[key] = value
+			}
+		}
+
+		if summary != "" {
+			fields["summary"] = summary
+		}
+		if description != "" {
+			fields["description"] = description
+		}
+		var estimates client.CommonIssueFields
+		estimates.OriginalEstimate, estimates.RemainingEstimate = getEstimates(body)
+		if err := validateEstimates(estimates); err != nil {
+			return map[string]any{
+				"error":   "validation_error",
+				"message": fmt.Sprintf("Invalid estimate: %v", err),
+			}
+		}
+		maps.Copy(fields, estimates.Fields())
+
+		if len(fields) == 0 {
+			return map[string]any{
+				"error":   "validation_error",
+				"message": "At least one field to update is required",
+			}
+		}
+
+		// Create Jira client, translate field names such as "Story Points" to IDs, and update issue
+		jiraClient := client.NewJiraClient(creds)
+		fields, resolvedFields := jiraClient.TranslateFieldNames(ctx, fields)
+		err := jiraClient.UpdateIssue(ctx, issueKey, fields)
+		if err != nil {
+			log.Printf("Failed to update issue: %v", err)
+			return map[string]any{
+				"error":   client.ErrorCode(err),
+				"message": fmt.Sprintf("Failed to update issue: %v", err),
+			}
+		}
+
+		log.Printf("Successfully updated Jira issue: %s", issueKey)
+
+		result := map[string]any{
+			"result":   "success",
+			"message":  fmt.Sprintf("Issue %s updated successfully", issueKey),
+			"issueKey": issueKey,
+		}
+		if len(resolvedFields) > 0 {
+			result["resolvedFields"] = resolvedFields
+		}
+		if _, ok := fields["timetracking"]; ok {
+			if estimates := issueEstimates(ctx, jiraClient, issueKey); estimates != nil {
+				result["timetracking"] = estimates
+			}
+		}
+		return result
+	})
+}
+
+// TransitionIssueHandler handles the issues.transition action
+func TransitionIssueHandler(msg *nats.Msg) {
+	handler.Run(msg, "issues.transition", func(ctx context.Context, creds *credentials.JiraCredentials, body map[string]any, progress handler.ProgressFunc) map[string]any {
+		// Extract form fields
+		issueKey, _ := body["issueKey"].(string)
+		transition, _ := body["transition"].(string)
+		resolution, _ := body["resolution"].(string)
+
+		// Validate required fields
+		if issueKey == "" {
+			return map[string]any{
+				"error":   "validation_error",
+				"message": "Issue key or ID is required",
+			}
+		}
+		if transition == "" {
+			return map[string]any{
+				"error":   "validation_error",
+				"message": "Transition name or ID is required",
+			}
+		}
+
+		// Set resolution during the transition if provided
+		var fields map[string]interface{}
+		if resolution != "" {
+			fields = map[string]interface{}{
+				"resolution": map[string]interface{}{
+					"name": resolution,
+				},
+			}
+		}
+
+		// An unavailable transition is reported as a validation_error listing the available ones
+		jiraClient := client.NewJiraClient(creds)
+		matched, err := jiraClient.TransitionIssueByName(ctx, issueKey, transition, fields)
+		if err != nil {
+			log.Printf("Failed to transition issue: %v", err)
+			return map[string]any{
+				"error":   client.ErrorCode(err),
+				"message": fmt.Sprintf("Failed to transition issue: %v", err),
+			}
+		}
+
+		transitionID, _ := matched["id"].(string)
+		transitionName, _ := matched["name"].(string)
+
+		// The target status of the transition is the issue's resulting status
+		status := ""
+		if to, ok := matched["to"].(map[string]interface{}); ok {
+			status, _ = to["name"].(string)
+		}
+
+		log.Printf("Successfully transitioned Jira issue %s via '%s' to status '%s'", issueKey, transitionName, status)
+
+		result := map[string]any{
+			"result":         "success",
+			"message":        fmt.Sprintf("Issue %s transitioned successfully", issueKey),
+			"issueKey":       issueKey,
+			"transitionId":   transitionID,
+			"transitionName": transitionName,
+			"status":         status,
+		}
+		return result
+	})
+}
+
+// BulkTransitionIssuesHandler handles the issues.bulk-transition action
+func BulkTransitionIssuesHandler(msg *nats.Msg) {
+	handler.Run(msg, "issues.bulk-transition", func(ctx context.Context, creds *credentials.JiraCredentials, body map[string]any, progress handler.ProgressFunc) map[string]any {
+		// Extract form fields
+		jql, _ := body["jql"].(string)
+		transition, _ := body["transition"].(string)
+		resolution, _ := body["resolution"].(string)
+		maxIssues := handler.IntValue(body, "maxIssues", defaultBulkTransitionIssues)
+
+		// Validate required fields
+		if strings.TrimSpace(jql) == "" {
+			return map[string]any{
+				"error":   "validation_error",
+				"message": "JQL query is required",
+			}
+		}
+		if transition == "" {
+			return map[string]any{
+				"error":   "validation_error",
+				"message": "Transition name or ID is required",
+			}
+		}
+		if maxIssues <= 0 || maxIssues > maxBulkTransitionIssues {
+			return map[string]any{
+				"error":   "validation_error",
+				"message": fmt.Sprintf("maxIssues must be between 1 and %d", maxBulkTransitionIssues),
+			}
+		}
+
+		// Collect the matching keys before transitioning anything: transitioned issues
+		// may stop matching the query, which would shift later pages
+		jiraClient := client.NewJiraClient(creds)
+		progress(0, "Searching for issues")
+		var keys []string
+		matched := 0
+		for len(keys) < maxIssues {
+			pageSize := min(bulkTransitionSearchPageSize, maxIssues-len(keys))
+			searchResult, err := jiraClient.SearchIssues(ctx, jql, len(keys), pageSize, []string{"status"}, nil)
+			if err != nil {
+				log.Printf("Failed to search issues: %v", err)
+				return map[string]any{
+					"error":   client.ErrorCode(err),
+					"message": fmt.Sprintf("Failed to search issues: %v", err),
+				}
+			}
+			issues, _ := searchResult["issues"].([]interface{})
+			if totalValue, ok := searchResult["total"].(float64); ok {
+				matched = int(totalValue)
+			}
+			for _, raw := range issues {
+				if issue, ok := raw.(map[string]interface{}); ok {
+					if key, _ := issue["key"].(string); key != "" {
+						keys = append(keys, key)
+					}
+				}
+			}
+			if len(issues) < pageSize || len(keys) >= matched {
+				break
+			}
+		}
+		matched = max(matched, len(keys))
+
+		if len(keys) == 0 {
+			return handler.BatchOutcome(map[string]any{
+				"message":      "No issues match the query",
+				"issues":       map[string]any{},
+				"matched":      0,
+				"transitioned": 0,
+			}, 0, 0)
+		}
+
+		// Set resolution during the transition if provided
+		var fields map[string]interface{}
+		if resolution != "" {
+			fields = map[string]interface{}{
+				"resolution": map[string]interface{}{
+					"name": resolution,
+				},
+			}
+		}
+
+		bulkResult, err := jiraClient.BulkTransitionIssues(ctx, keys, transition, fields, func(done, total int) {
+			progress(done*100/total, fmt.Sprintf("%d of %d issues processed", done, total))
+		})
+		if err != nil {
+			log.Printf("Failed to bulk transition issues: %v", err)
+			return map[string]any{
+				"error":   client.ErrorCode(err),
+				"message": fmt.Sprintf("Failed to bulk transition issues: %v", err),
+			}
+		}
+
+		transitionedCount, _ := bulkResult["transitioned"].(int)
+		failedCount, _ := bulkResult["failed"].(int)
+		log.Printf("Bulk transition: %d issues transitioned, %d failed", transitionedCount, failedCount)
+
+		result := map[string]any{
+			"message":      fmt.Sprintf("Transitioned %d of %d issues (%d failed)", transitionedCount, transitionedCount+failedCount, failedCount),
+			"issues":       bulkResult["issues"],
+			"matched":      matched,
+			"transitioned": transitionedCount,
+		}
+		if matched > len(keys) {
+			result["truncated"] = true
+			result["message"] = fmt.Sprintf("%s; %d more matching issues were left out by maxIssues", result["message"], matched-len(keys))
+		}
+		return handler.BatchOutcome(result, transitionedCount, failedCount)
+	})
+}
+
+// ListTransitionsHandler handles the issues.transitions.list action
+func ListTransitionsHandler(msg *nats.Msg) {
+	handler.Run(msg, "issues.transitions.list", func(ctx context.Context, creds *credentials.JiraCredentials, body map[string]any, progress handler.ProgressFunc) map[string]any {
+		// Extract form fields
+		issueKey, _ := body["issueKey"].(string)
+
+		// Validate required fields
+		if issueKey == "" {
+			return map[string]any{
+				"error":   "validation_error",
+				"message": "Issue key or ID is required",
+			}
+		}
+
+		// Jira returns an empty list (not an error) when the user may not transition the issue
+		jiraClient := client.NewJiraClient(creds)
+		transitions, err := jiraClient.ListTransitions(ctx, issueKey)
+		if err != nil {
+			log.Printf("Failed to list transitions: %v", err)
+			return map[string]any{
+				"error":   client.ErrorCode(err),
+				"message": fmt.Sprintf("Failed to list transitions: %v", err),
+			}
+		}
+
+		// Keep what a dropdown needs: the transition and the status it leads to
+		items := make([]map[string]any, 0, len(transitions))
+		for _, t := range transitions {
+			item := map[string]any{
+				"id":   t["id"],
+				"name": t["name"],
+			}
+			if to, ok := t["to"].(map[string]interface{}); ok {
+				item["toStatus"] = to["name"]
+				item["toStatusId"] = to["id"]
+				if category, ok := to["statusCategory"].(map[string]interface{}); ok {
+					item["toStatusCategory"] = category["key"]
+				}
+			}
+			items = append(items, item)
+		}
+
+		message := fmt.Sprintf("Issue %s has %d available transitions", issueKey, len(items))
+		if len(items) == 0 {
+			message = fmt.Sprintf("No transitions are available for issue %s (the workflow may have none, or you may lack permission to transition it)", issueKey)
+		}
+
+		result := map[string]any{
+			"result":      "success",
+			"message":     message,
+			"issueKey":    issueKey,
+			"transitions": items,
+			"count":       len(items),
+		}
+		return result
+	})
+}
+
+// AssignIssueHandler handles the issues.assign action
+func AssignIssueHandler(msg *nats.Msg) {
+	handler.Run(msg, "issues.assign", func(ctx context.Context, creds *credentials.JiraCredentials, body map[string]any, progress handler.ProgressFunc) map[string]any {
+		// Extract form fields (accountId may be null to unassign)
+		issueKey, _ := body["issueKey"].(string)
+		accountId, _ := body["accountId"].(string)
+		name, _ := body["name"].(string)
+
+		// Validate required fields
+		if issueKey == "" {
+			return map[string]any{
+				"error":   "validation_error",
+				"message": "Issue key or ID is required",
+			}
+		}
+
+		// Create Jira client and assign issue
+		jiraClient := client.NewJiraClient(creds)
+		var err error
+		if accountId == "" && name != "" {
+			err = jiraClient.AssignIssueByName(ctx, issueKey, name)
+		} else {
+			err = jiraClient.AssignIssue(ctx, issueKey, accountId)
+		}
+		if err != nil {
+			log.Printf("Failed to assign issue: %v", err)
+			return map[string]any{
+				"error":   client.ErrorCode(err),
+				"message": fmt.Sprintf("Failed to assign issue: %v", err),
+			}
+		}
+
+		assignee := accountId
+		if assignee == "" {
+			assignee = name
+		}
+
+		message := fmt.Sprintf("Issue %s assigned to %s", issueKey, assignee)
+		switch assignee {
+		case "":
+			message = fmt.Sprintf("Issue %s unassigned", issueKey)
+		case "-1":
+			message = fmt.Sprintf("Issue %s assigned to the default assignee", issueKey)
+		}
+
+		log.Printf("Successfully assigned Jira issue %s (assignee: '%s')", issueKey, assignee)
+
+		result := map[string]any{
+			"result":   "success",
+			"message":  message,
+			"issueKey": issueKey,
+			"assignee": assignee,
+		}
+		return result
+	})
+}
+
+// SearchIssuesHandler handles the issues.search action
+func SearchIssuesHandler(msg *nats.Msg) {
+	handler.Run(msg, "issues.search", func(ctx context.Context, creds *credentials.JiraCredentials, body map[string]any, progress handler.ProgressFunc) map[string]any {
+		// Extract form fields
+		jql, _ := body["jql"].(string)
+		maxResults := handler.IntValue(body, "maxResults", 50)
+		startAt := handler.IntValue(body, "startAt", 0)
+		fields := handler.StringSlice(body, "fields")
+		expand := handler.StringSlice(body, "expand")
+
+		// Validate required fields
+		if strings.TrimSpace(jql) == "" {
+			return map[string]any{
+				"error":   "validation_error",
+				"message": "JQL query is required",
+			}
+		}
+		if maxResults <= 0 {
+			maxResults = 50
+		}
+		if startAt < 0 {
+			startAt = 0
+		}
+
+		// Create Jira client and search issues
+		jiraClient := client.NewJiraClient(creds)
+		searchResult, err := jiraClient.SearchIssues(ctx, jql, startAt, maxResults, fields, expand)
+		if err != nil {
+			log.Printf("Failed to search issues: %v", err)
+			return map[string]any{
+				"error":   client.ErrorCode(err),
+				"message": fmt.Sprintf("Failed to search issues: %v", err),
+			}
+		}
+
+		issues, _ := searchResult["issues"].([]interface{})
+		if issues == nil {
+			issues = []interface{}{}
+		}
+		// Add a clickable link to every result
+		addBrowseURLs(jiraClient, issues)
+		total := searchResult["total"]
+
+		log.Printf("Successfully searched Jira issues: %d returned (total: %v)", len(issues), total)
+
+		result := map[string]any{
+			"result":     "success",
+			"message":    fmt.Sprintf("Found %d issues", len(issues)),
+			"issues":     issues,
+			"total":      total,
+			"startAt":    startAt,
+			"maxResults": maxResults,
+		}
+		return result
+	})
+}
+
+// addBrowseURLs adds a clickable link to every issue of a search result
+func addBrowseURLs(jiraClient *client.JiraClient, issues []interface{}) {
+	for _, raw := range issues {
+		if issue, ok := raw.(map[string]interface{}); ok {
+			key, _ := issue["key"].(string)
+			issue["browseUrl"] = jiraClient.BrowseURL(key)
+		}
+	}
+}
+
+// filterSummary reduces a Jira filter to its ID, name, owner and JQL
+func filterSummary(filter map[string]interface{}) map[string]any {
+	summary := map[string]any{
+		"id":   filter["id"],
+		"name": filter["name"],
+		"jql":  filter["jql"],
+	}
+	if description, ok := filter["description"].(string); ok && description != "" {
+		summary["description"] = description
+	}
+	if viewURL, ok := filter["viewUrl"].(string); ok && viewURL != "" {
+		summary["viewUrl"] = viewURL
+	}
+	// Cloud identifies the owner by accountId, Server/Data Center by name
+	if owner, ok := filter["owner"].(map[string]interface{}); ok {
+		ownerID := owner["accountId"]
+		if ownerID == nil {
+			ownerID = owner["name"]
+		}
+		summary["owner"] = map[string]any{
+			"id":          ownerID,
+			"displayName": owner["displayName"],
+		}
+	}
+	return summary
+}
+
+// ListFiltersHandler handles the filters.list action
+func ListFiltersHandler(msg *nats.Msg) {
+	handler.Run(msg, "filters.list", func(ctx context.Context, creds *credentials.JiraCredentials, body map[string]any, progress handler.ProgressFunc) map[string]any {
+		// Extract form fields
+		maxResults := handler.IntValue(body, "maxResults", 50)
+		startAt := handler.IntValue(body, "startAt", 0)
+		if maxResults <= 0 {
+			maxResults = 50
+		}
+		if startAt < 0 {
+			startAt = 0
+		}
+
+		// Create Jira client and list filters
+		jiraClient := client.NewJiraClient(creds)
+		page, err := jiraClient.ListFilters(ctx, startAt, maxResults)
+		if err != nil {
+			log.Printf("Failed to list filters: %v", err)
+			return map[string]any{
+				"error":   client.ErrorCode(err),
+				"message": fmt.Sprintf("Failed to list filters: %v", err),
+			}
+		}
+
+		values, _ := page["values"].([]interface{})
+		filters := make([]map[string]any, 0, len(values))
+		for _, raw := range values {
+			if filter, ok := raw.(map[string]interface{}); ok {
+				filters = append(filters, filterSummary(filter))
+			}
+		}
+
+		result := map[string]any{
+			"result":     "success",
+			"message":    fmt.Sprintf("Found %d filters", len(filters)),
+			"filters":    filters,
+			"total":      page["total"],
+			"isLast":     page["isLast"],
+			"startAt":    startAt,
+			"maxResults": maxResults,
+		}
+		return result
+	})
+}
+
+// RunFilterHandler handles the filters.run action
+func RunFilterHandler(msg *nats.Msg) {
+	handler.Run(msg, "filters.run", func(ctx context.Context, creds *credentials.JiraCredentials, body map[string]any, progress handler.ProgressFunc) map[string]any {
+		// Extract form fields; the ID may arrive as a number from automations
+		filterID, _ := body["filterId"].(string)
+		if id, ok := body["filterId"].(float64); ok {
+			filterID = fmt.Sprintf("%.0f", id)
+		}
+		filterID = strings.TrimSpace(filterID)
+		maxResults := handler.IntValue(body, "maxResults", 50)
+		startAt := handler.IntValue(body, "startAt", 0)
+		fields := handler.StringSlice(body, "fields")
+		expand := handler.StringSlice(body, "expand")
+
+		// Validate required fields
+		if filterID == "" {
+			return map[string]any{
+				"error":   "validation_error",
+				"message": "Filter ID is required",
+			}
+		}
+		if maxResults <= 0 {
+			maxResults = 50
+		}
+		if startAt < 0 {
+			startAt = 0
+		}
+
+		// Resolve the saved filter's JQL, then search with it
+		jiraClient := client.NewJiraClient(creds)
+		filter, err := jiraClient.GetFilter(ctx, filterID)
+		if err != nil {
+			log.Printf("Failed to get filter: %v", err)
+			return map[string]any{
+				"error":   client.ErrorCode(err),
+				"message": fmt.Sprintf("Failed to get filter: %v", err),
+			}
+		}
+		jql, _ := filter["jql"].(string)
+		if strings.TrimSpace(jql) == "" {
+			return map[string]any{
+				"error":   "validation_error",
+				"message": fmt.Sprintf("Filter %s has no JQL query", filterID),
+			}
+		}
+
+		searchResult, err := jiraClient.SearchIssues(ctx, jql, startAt, maxResults, fields, expand)
+		if err != nil {
+			log.Printf("Failed to run filter: %v", err)
+			return map[string]any{
+				"error":   client.ErrorCode(err),
+				"message": fmt.Sprintf("Failed to run filter %s: %v", filterID, err),
+			}
+		}
+
+		issues, _ := searchResult["issues"].([]interface{})
+		if issues == nil {
+			issues = []interface{}{}
+		}
+		addBrowseURLs(jiraClient, issues)
+		total := searchResult["total"]
+
+		log.Printf("Successfully ran Jira filter %s: %d returned (total: %v)", filterID, len(issues), total)
+
+		result := map[string]any{
+			"result":     "success",
+			"message":    fmt.Sprintf("Found %d issues with filter %v", len(issues), filter["name"]),
+			"filter":     filterSummary(filter),
+			"issues":     issues,
+			"total":      total,
+			"startAt":    startAt,
+			"maxResults": maxResults,
+		}
+		return result
+	})
+}
+
+// ValidateJQLHandler handles the issues.validate-jql action
+func ValidateJQLHandler(msg *nats.Msg) {
+	handler.Run(msg, "issues.validate-jql", func(ctx context.Context, creds *credentials.JiraCredentials, body map[string]any, progress handler.ProgressFunc) map[string]any {
+		// Extract form fields
+		jql, _ := body["jql"].(string)
+
+		// Validate required fields
+		if strings.TrimSpace(jql) == "" {
+			return map[string]any{
+				"error":   "validation_error",
+				"message": "JQL query is required",
+			}
+		}
+
+		// Create Jira client and validate the query
+		jiraClient := client.NewJiraClient(creds)
+		validation, err := jiraClient.ValidateJQL(ctx, jql)
+		if err != nil {
+			log.Printf("Failed to validate JQL: %v", err)
+			return map[string]any{
+				"error":   client.ErrorCode(err),
+				"message": fmt.Sprintf("Failed to validate JQL: %v", err),
+			}
+		}
+
+		// An invalid query is a successful validation, so it is not reported as an error
+		valid, _ := validation["valid"].(bool)
+		message := "JQL query is valid"
+		if !valid {
+			message = "JQL query is invalid"
+			if parseErrors, ok := validation["errors"].([]map[string]interface{}); ok && len(parseErrors) > 0 {
+				message = fmt.Sprintf("JQL query is invalid: %v", parseErrors[0]["message"])
+			}
+		}
+
+		result := map[string]any{
+			"result":  "success",
+			"message": message,
+			"jql":     jql,
+			"valid":   valid,
+			"errors":  validation["errors"],
+		}
+		return result
+	})
+}
+
+// DeleteIssueHandler handles the issues.delete action
+func DeleteIssueHandler(msg *nats.Msg) {
+	handler.Run(msg, "issues.delete", func(ctx context.Context, creds *credentials.JiraCredentials, body map[string]any, progress handler.ProgressFunc) map[string]any {
+		// Extract form fields
+		issueKey, _ := body["issueKey"].(string)
+		deleteSubtasks := handler.BoolValue(body, "deleteSubtasks")
+
+		// Validate required fields
+		if issueKey == "" {
+			return map[string]any{
+				"error":   "validation_error",
+				"message": "Issue key or ID is required",
+			}
+		}
+
+		// Create Jira client and delete issue
+		jiraClient := client.NewJiraClient(creds)
+		err := jiraClient.DeleteIssue(ctx, issueKey, deleteSubtasks)
+		if err != nil {
+			log.Printf("Failed to delete issue: %v", err)
+			return map[string]any{
+				"error":   client.ErrorCode(err),
+				"message": fmt.Sprintf("Failed to delete issue: %v", err),
+			}
+		}
+
+		log.Printf("Successfully deleted Jira issue: %s", issueKey)
+
+		result := map[string]any{
+			"result":   "success",
+			"message":  fmt.Sprintf("Issue %s deleted successfully", issueKey),
+			"issueKey": issueKey,
+		}
+		return result
+	})
+}
+
+// BulkDeleteIssuesHandler handles the issues.bulk-delete action
+func BulkDeleteIssuesHandler(msg *nats.Msg) {
+	handler.Run(msg, "issues.bulk-delete", func(ctx context.Context, creds *credentials.JiraCredentials, body map[string]any, progress handler.ProgressFunc) map[string]any {
+		// Extract form fields
+		issueKeys := handler.StringSlice(body, "issueKeys")
+		deleteSubtasks := handler.BoolValue(body, "deleteSubtasks")
+
+		// Validate required fields
+		if len(issueKeys) == 0 {
+			return map[string]any{
+				"error":   "validation_error",
+				"message": "At least one issue key is required",
+			}
+		}
+
+		// Create Jira client and delete the issues
+		jiraClient := client.NewJiraClient(creds)
+		bulkResult, err := jiraClient.BulkDeleteIssues(ctx, issueKeys, deleteSubtasks, func(done, total int) {
+			progress(done*100/total, fmt.Sprintf("%d of %d issues processed", done, total))
+		})
+		if err != nil {
+			log.Printf("Failed to bulk delete issues: %v", err)
+			return map[string]any{
+				"error":   client.ErrorCode(err),
+				"message": fmt.Sprintf("Failed to bulk delete issues: %v", err),
+			}
+		}
+
+		deletedCount, _ := bulkResult["deleted"].(int)
+		failedCount, _ := bulkResult["failed"].(int)
+		log.Printf("Bulk delete: %d issues deleted, %d failed", deletedCount, failedCount)
+
+		return handler.BatchOutcome(map[string]any{
+			"message": fmt.Sprintf("Deleted %d of %d issues (%d failed)", deletedCount, deletedCount+failedCount, failedCount),
+			"issues":  bulkResult["issues"],
+			"deleted": deletedCount,
+		}, deletedCount, failedCount)
+	})
+}
+
+// validateCommentVisibility checks a comment visibility object before it is sent, since
+// Jira answers a bad one with an unhelpful 400. type must be role or group (it is
+// normalized to lower case) and value must name the role or group; on Cloud a group may
+// be given by its ID in identifier instead. It returns a message describing the problem,
+// or "" if the object is valid.
+func validateCommentVisibility(visibility map[string]interface{}) string {
+	visibilityType, _ := visibility["type"].(string)
+	visibilityType = strings.ToLower(strings.TrimSpace(visibilityType))
+	if visibilityType != "role" && visibilityType != "group" {
+		return fmt.Sprintf("visibility.type must be \"role\" or \"group\", got %q", visibility["type"])
+	}
+	visibility["type"] = visibilityType
+
+	value, _ := visibility["value"].(string)
+	identifier, _ := visibility["identifier"].(string)
+	if strings.TrimSpace(value) == "" && (visibilityType != "group" || strings.TrimSpace(identifier) == "") {
+		return fmt.Sprintf("visibility.value is required: the name of the %s that may see the comment", visibilityType)
+	}
+	return ""
+}
+
+// AddCommentHandler handles the issues.comment action
+func AddCommentHandler(msg *nats.Msg) {
+	handler.Run(msg, "issues.comment", func(ctx context.Context, creds *credentials.JiraCredentials, body map[string]any, progress handler.ProgressFunc) map[string]any {
+		// Extract form fields
+		issueKey, _ := body["issueKey"].(string)
+		commentBody, _ := body["commentBody"].(string)
+		internal := handler.BoolValue(body, "internal")
+		var visibility map[string]interface{}
+
+		// Extract visibility if provided
+		if visibilityRaw, ok := body["visibility"]; ok {
+			if visMap, ok := visibilityRaw.(map[string]interface{}); ok {
+				visibility = visMap
+			} else if visMap, ok := visibilityRaw.(map[string]any); ok {
+				// Convert map[string]any to map[string]interface{}
+				visibility = make(map[string]interface{})
+				for k, v := range visMap {
+					visibility[k] = v
+				}
+			} else if visibilityRaw != nil {
+				return map[string]any{
+					"error":   "validation_error",
+					"message": "visibility must be an object with type and value",
+				}
+			}
+		}
+		// An empty object is an untouched form input and means no restriction
+		if len(visibility) == 0 {
+			visibility = nil
+		} else if message := validateCommentVisibility(visibility); message != "" {
+			return map[string]any{
+				"error":   "validation_error",
+				"message": message,
+			}
+		}
+
+		// Extract additionalFields if provided (as object)
+		var additionalFields map[string]interface{}
+		if additionalFieldsRaw, ok := body["additionalFields"]; ok {
+			if afMap, ok := additionalFieldsRaw.(map[string]interface{}); ok {
+				additionalFields = afMap
+			} else if afMap, ok := additionalFieldsRaw.(map[string]any); ok {
+				// Convert map[string]any to map[string]interface{}
+				additionalFields = make(map[string]interface{})
+				for k, v := range afMap {
+					additionalFields[k] = v
+				}
+			}
+		}
+
+		// Also check for any other fields that might have been passed directly
+		// (for backward compatibility and flexibility)
+		knownFields := map[string]bool{
+			"issueKey":         true,
+			"commentBody":      true,
+			"visibility":       true,
+			"internal":         true,
+			"additionalFields": true,
+		}
+
+		// Merge any other fields that aren't in the known list into additionalFields
+		if additionalFields == nil {
+			additionalFields = make(map[string]interface{})
+		}
+		// Empty top-level strings are untouched form inputs and are skipped; explicit
+		// false/0/"" values should be sent inside additionalFields
+		for key, value := range body {
+			if !knownFields[key] && value != nil && value != "" {
+				additionalFields[key] = value
+			}
+		}
+
+		// Validate required fields
+		if issueKey == "" {
+			return map[string]any{
+				"error":   "validation_error",
+				"message": "Issue key or ID is required",
+			}
+		}
+		if commentBody == "" {
+			return map[string]any{
+				"error":   "validation_error",
+				"message": "Comment body is required",
+			}
+		}
+
+		// Create Jira client and add comment
+		jiraClient := client.NewJiraClient(creds)
+
+		// Only Jira Service Management knows internal comments; elsewhere the property
+		// would be stored but the comment would still be visible to everyone
+		if internal {
+			serviceDesk, err := jiraClient.IsServiceDeskIssue(ctx, issueKey)
+			if err != nil {
+				log.Printf("Failed to check the project type of %s: %v", issueKey, err)
+				return map[string]any{
+					"error":   client.ErrorCode(err),
+					"message": fmt.Sprintf("Failed to check whether %s is a Jira Service Management issue: %v", issueKey, err),
+				}
+			}
+			if !serviceDesk {
+				return map[string]any{
+					"error":   "validation_error",
+					"message": fmt.Sprintf("internal comments are only supported on Jira Service Management projects, and %s is not in one", issueKey),
+				}
+			}
+		}
+
+		comment, err := jiraClient.AddComment(ctx, issueKey, commentBody, visibility, additionalFields, internal)
+		if err != nil {
+			log.Printf("Failed to add comment: %v", err)
+			return map[string]any{
+				"error":   client.ErrorCode(err),
+				"message": fmt.Sprintf("Failed to add comment: %v", err),
+			}
+		}
+
+		// Extract comment ID from response
+		commentId, _ := comment["id"].(string)
+		commentAuthor, _ := comment["author"].(map[string]interface{})
+
+		log.Printf("Successfully added comment to Jira issue %s (comment ID: %s)", issueKey, commentId)
+
+		result := map[string]any{
+			"result":        "success",
+			"message":       fmt.Sprintf("Comment added successfully to issue %s", issueKey),
+			"issueKey":      issueKey,
+			"commentId":     commentId,
+			"comment":       comment,
+			"commentAuthor": commentAuthor,
+			"internal":      internal,
+		}
+		return result
+	})
+}
+
+// BulkAddCommentHandler handles the issues.bulk-comment action
+func BulkAddCommentHandler(msg *nats.Msg) {
+	handler.Run(msg, "issues.bulk-comment", func(ctx context.Context, creds *credentials.JiraCredentials, body map[string]any, progress handler.ProgressFunc) map[string]any {
+		// Extract form fields
+		issueKeys := handler.StringSlice(body, "issueKeys")
+		commentBody, _ := body["commentBody"].(string)
+
+		// Validate required fields
+		if len(issueKeys) == 0 {
+			return map[string]any{
+				"error":   "validation_error",
+				"message": "At least one issue key is required",
+			}
+		}
+		if strings.TrimSpace(commentBody) == "" {
+			return map[string]any{
+				"error":   "validation_error",
+				"message": "Comment body is required",
+			}
+		}
+
+		// Create Jira client and comment on the issues
+		jiraClient := client.NewJiraClient(creds)
+		bulkResult, err := jiraClient.BulkAddComments(ctx, issueKeys, commentBody, func(done, total int) {
+			progress(done*100/total, fmt.Sprintf("%d of %d issues processed", done, total))
+		})
+		if err != nil {
+			log.Printf("Failed to bulk add comments: %v", err)
+			return map[string]any{
+				"error":   client.ErrorCode(err),
+				"message": fmt.Sprintf("Failed to bulk add comments: %v", err),
+			}
+		}
+
+		addedCount, _ := bulkResult["added"].(int)
+		failedCount, _ := bulkResult["failed"].(int)
+		log.Printf("Bulk comment: %d comments added, %d failed", addedCount, failedCount)
+
+		return handler.BatchOutcome(map[string]any{
+			"message": fmt.Sprintf("Commented on %d of %d issues (%d failed)", addedCount, addedCount+failedCount, failedCount),
+			"issues":  bulkResult["issues"],
+			"added":   addedCount,
+		}, addedCount, failedCount)
+	})
+}
+
+// ListCommentsHandler handles the issues.comments.list action
+func ListCommentsHandler(msg *nats.Msg) {
+	handler.Run(msg, "issues.comments.list", func(ctx context.Context, creds *credentials.JiraCredentials, body map[string]any, progress handler.ProgressFunc) map[string]any {
+		// Extract form fields
+		issueKey, _ := body["issueKey"].(string)
+		maxResults := handler.IntValue(body, "maxResults", 50)
+		startAt := handler.IntValue(body, "startAt", 0)
+
+		// Validate required fields
+		if issueKey == "" {
+			return map[string]any{
+				"error":   "validation_error",
+				"message": "Issue key or ID is required",
+			}
+		}
+		if maxResults <= 0 {
+			maxResults = 50
+		}
+		if startAt < 0 {
+			startAt = 0
+		}
+
+		// Create Jira client and list comments
+		jiraClient := client.NewJiraClient(creds)
+		commentsResult, err := jiraClient.ListComments(ctx, issueKey, startAt, maxResults)
+		if err != nil {
+			log.Printf("Failed to list comments: %v", err)
+			return map[string]any{
+				"error":   client.ErrorCode(err),
+				"message": fmt.Sprintf("Failed to list comments: %v", err),
+			}
+		}
+
+		// An issue without comments returns an empty array, which is not an error
+		rawComments, _ := commentsResult["comments"].([]interface{})
+		comments := make([]map[string]any, 0, len(rawComments))
+		for _, raw := range rawComments {
+			comment, ok := raw.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			authorName := ""
+			if author, ok := comment["author"].(map[string]interface{}); ok {
+				authorName, _ = author["displayName"].(string)
+			}
+			comments = append(comments, map[string]any{
+				"id":      comment["id"],
+				"author":  authorName,
+				"created": comment["created"],
+				"updated": comment["updated"],
+				"body":    comment["body"],
+			})
+		}
+
+		total := commentsResult["total"]
+		log.Printf("Successfully retrieved %d comments for Jira issue %s (total: %v)", len(comments), issueKey, total)
+
+		result := map[string]any{
+			"result":     "success",
+			"message":    fmt.Sprintf("Retrieved %d comments for issue %s", len(comments), issueKey),
+			"issueKey":   issueKey,
+			"comments":   comments,
+			"total":      total,
+			"startAt":    startAt,
+			"maxResults": maxResults,
+		}
+		return result
+	})
+}
+
+// UpdateCommentHandler handles the issues.comment.update action
+func UpdateCommentHandler(msg *nats.Msg) {
+	handler.Run(msg, "issues.comment.update", func(ctx context.Context, creds *credentials.JiraCredentials, body map[string]any, progress handler.ProgressFunc) map[string]any {
+		// Extract form fields
+		issueKey, _ := body["issueKey"].(string)
+		commentId, _ := body["commentId"].(string)
+		commentBody, _ := body["commentBody"].(string)
+
+		// Validate required fields
+		if issueKey == "" {
+			return map[string]any{
+				"error":   "validation_error",
+				"message": "Issue key or ID is required",
+			}
+		}
+		if commentId == "" {
+			return map[string]any{
+				"error":   "validation_error",
+				"message": "Comment ID is required",
+			}
+		}
+		if commentBody == "" {
+			return map[string]any{
+				"error":   "validation_error",
+				"message": "Comment body is required",
+			}
+		}
+
+		// Create Jira client and update comment
+		jiraClient := client.NewJiraClient(creds)
+		comment, err := jiraClient.UpdateComment(ctx, issueKey, commentId, commentBody)
+		if err != nil {
+			log.Printf("Failed to update comment: %v", err)
+			return map[string]any{
+				"error":   client.ErrorCode(err),
+				"message": fmt.Sprintf("Failed to update comment: %v", err),
+			}
+		}
+
+		log.Printf("Successfully updated comment %s on Jira issue %s", commentId, issueKey)
+
+		result := map[string]any{
+			"result":    "success",
+			"message":   fmt.Sprintf("Comment %s updated successfully on issue %s", commentId, issueKey),
+			"issueKey":  issueKey,
+			"commentId": commentId,
+			"comment":   comment,
+		}
+		return result
+	})
+}
+
+// DeleteCommentHandler handles the issues.comment.delete action
+func DeleteCommentHandler(msg *nats.Msg) {
+	handler.Run(msg, "issues.comment.delete", func(ctx context.Context, creds *credentials.JiraCredentials, body map[string]any, progress handler.ProgressFunc) map[string]any {
+		// Extract form fields
+		issueKey, _ := body["issueKey"].(string)
+		commentId, _ := body["commentId"].(string)
+
+		// Validate required fields
+		if issueKey == "" {
+			return map[string]any{
+				"error":   "validation_error",
+				"message": "Issue key or ID is required",
+			}
+		}
+		if commentId == "" {
+			return map[string]any{
+				"error":   "validation_error",
+				"message": "Comment ID is required",
+			}
+		}
+
+		// Create Jira client and delete comment
+		jiraClient := client.NewJiraClient(creds)
+		err := jiraClient.DeleteComment(ctx, issueKey, commentId)
+		if err != nil {
+			log.Printf("Failed to delete comment: %v", err)
+			return map[string]any{
+				"error":   client.ErrorCode(err),
+				"message": fmt.Sprintf("Failed to delete comment: %v", err),
+			}
+		}
+
+		log.Printf("Successfully deleted comment %s from Jira issue %s", commentId, issueKey)
+
+		result := map[string]any{
+			"result":    "success",
+			"message":   fmt.Sprintf("Comment %s deleted successfully from issue %s", commentId, issueKey),
+			"issueKey":  issueKey,
+			"commentId": commentId,
+		}
+		return result
+	})
+}
+
+// AddAttachmentHandler handles the issues.attachment.add action
+func AddAttachmentHandler(msg *nats.Msg) {
+	handler.Run(msg, "issues.attachment.add", func(ctx context.Context, creds *credentials.JiraCredentials, body map[string]any, progress handler.ProgressFunc) map[string]any {
+		// Extract form fields
+		issueKey, _ := body["issueKey"].(string)
+		filename, _ := body["filename"].(string)
+		encodedContent, _ := body["content"].(string)
+
+		// Validate required fields
+		if issueKey == "" {
+			return map[string]any{
+				"error":   "validation_error",
+				"message": "Issue key or ID is required",
+			}
+		}
+		if filename == "" {
+			return map[string]any{
+				"error":   "validation_error",
+				"message": "File name is required",
+			}
+		}
+		if encodedContent == "" {
+			return map[string]any{
+				"error":   "validation_error",
+				"message": "File content is required",
+			}
+		}
+
+		content, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encodedContent))
+		if err != nil {
+			return map[string]any{
+				"error":   "validation_error",
+				"message": fmt.Sprintf("Content must be base64-encoded: %v", err),
+			}
+		}
+
+		// Create Jira client and upload attachment
+		jiraClient := client.NewJiraClient(creds)
+		attachments, err := jiraClient.AddAttachment(ctx, issueKey, filename, content)
+		if err != nil {
+			log.Printf("Failed to add attachment: %v", err)
+			return map[string]any{
+				"error":   client.ErrorCode(err),
+				"message": fmt.Sprintf("Failed to add attachment: %v", err),
+			}
+		}
+
+		// Extract attachment IDs and URLs from response
+		attachmentIds := make([]string, 0, len(attachments))
+		attachmentUrls := make([]string, 0, len(attachments))
+		for _, attachment := range attachments {
+			if id, ok := attachment["id"].(string); ok {
+				attachmentIds = append(attachmentIds, id)
+			}
+			if contentUrl, ok := attachment["content"].(string); ok {
+				attachmentUrls = append(attachmentUrls, contentUrl)
+			}
+		}
+
+		log.Printf("Successfully added attachment %s to Jira issue %s (IDs: %v)", filename, issueKey, attachmentIds)
+
+		result := map[string]any{
+			"result":         "success",
+			"message":        fmt.Sprintf("Attachment %s added successfully to issue %s", filename, issueKey),
+			"issueKey":       issueKey,
+			"attachmentIds":  attachmentIds,
+			"attachmentUrls": attachmentUrls,
+			"attachments":    attachments,
+		}
+		return result
+	})
+}
+
+// ListAttachmentsHandler handles the issues.attachments.list action
+func ListAttachmentsHandler(msg *nats.Msg) {
+	handler.Run(msg, "issues.attachments.list", func(ctx context.Context, creds *credentials.JiraCredentials, body map[string]any, progress handler.ProgressFunc) map[string]any {
+		// Extract form fields
+		issueKey, _ := body["issueKey"].(string)
+
+		// Validate required fields
+		if issueKey == "" {
+			return map[string]any{
+				"error":   "validation_error",
+				"message": "Issue key or ID is required",
+			}
+		}
+
+		jiraClient := client.NewJiraClient(creds)
+		attachments, err := jiraClient.GetIssueAttachments(ctx, issueKey)
+		if err != nil {
+			log.Printf("Failed to list attachments: %v", err)
+			return map[string]any{
+				"error":   client.ErrorCode(err),
+				"message": fmt.Sprintf("Failed to list attachments: %v", err),
+			}
+		}
+
+		items := make([]map[string]any, 0, len(attachments))
+		for _, attachment := range attachments {
+			item := map[string]any{
+				"id":       attachment["id"],
+				"filename": attachment["filename"],
+				"size":     attachment["size"],
+				"mimeType": attachment["mimeType"],
+				"content":  attachment["content"],
+				"created":  attachment["created"],
+			}
+			if author, ok := attachment["author"].(map[string]interface{}); ok {
+				item["author"] = author["displayName"]
+			}
+			items = append(items, item)
+		}
+
+		result := map[string]any{
+			"result":      "success",
+			"message":     fmt.Sprintf("Issue %s has %d attachments", issueKey, len(items)),
+			"issueKey":    issueKey,
+			"attachments": items,
+			"count":       len(items),
+		}
+		return result
+	})
+}
+
+// DownloadAttachmentHandler handles the issues.attachment.download action
+func DownloadAttachmentHandler(msg *nats.Msg) {
+	handler.Run(msg, "issues.attachment.download", func(ctx context.Context, creds *credentials.JiraCredentials, body map[string]any, progress handler.ProgressFunc) map[string]any {
+		// Extract form fields
+		attachmentId, _ := body["attachmentId"].(string)
+
+		// Validate required fields
+		if attachmentId == "" {
+			return map[string]any{
+				"error":   "validation_error",
+				"message": "Attachment ID is required",
+			}
+		}
+
+		jiraClient := client.NewJiraClient(creds)
+		content, filename, err := jiraClient.DownloadAttachment(ctx, attachmentId)
+		if err != nil {
+			log.Printf("Failed to download attachment: %v", err)
+			return map[string]any{
+				"error":   client.ErrorCode(err),
+				"message": fmt.Sprintf("Failed to download attachment: %v", err),
+			}
+		}
+
+		// Encoded the same way issues.attachment.add expects its content
+		result := map[string]any{
+			"result":       "success",
+			"message":      fmt.Sprintf("Attachment %s downloaded (%d bytes)", filename, len(content)),
+			"attachmentId": attachmentId,
+			"filename":     filename,
+			"size":         len(content),
+			"content":      base64.StdEncoding.EncodeToString(content),
+		}
+		return result
+	})
+}
+
+// NotifyIssueHandler handles the issues.notify action
+func NotifyIssueHandler(msg *nats.Msg) {
+	handler.Run(msg, "issues.notify", func(ctx context.Context, creds *credentials.JiraCredentials, body map[string]any, progress handler.ProgressFunc) map[string]any {
+		// Extract form fields
+		issueKey, _ := body["issueKey"].(string)
+		subject, _ := body["subject"].(string)
+		textBody, _ := body["textBody"].(string)
+		users := handler.StringSlice(body, "users")
+		groups := handler.StringSlice(body, "groups")
+
+		// Validate required fields
+		if issueKey == "" {
+			return map[string]any{
+				"error":   "validation_error",
+				"message": "Issue key or ID is required",
+			}
+		}
+		if strings.TrimSpace(textBody) == "" {
+			return map[string]any{
+				"error":   "validation_error",
+				"message": "Message is required",
+			}
+		}
+
+		// Build the recipients in Jira's notify structure
+		to := map[string]interface{}{
+			"reporter": handler.BoolValue(body, "notifyReporter"),
+			"assignee": handler.BoolValue(body, "notifyAssignee"),
+			"watchers": handler.BoolValue(body, "notifyWatchers"),
+		}
+		if len(users) > 0 {
+			recipients := make([]map[string]interface{}, 0, len(users))
+			for _, accountId := range users {
+				recipients = append(recipients, map[string]interface{}{"accountId": accountId})
+			}
+			to["users"] = recipients
+		}
+		if len(groups) > 0 {
+			recipients := make([]map[string]interface{}, 0, len(groups))
+			for _, name := range groups {
+				recipients = append(recipients, map[string]interface{}{"name": name})
+			}
+			to["groups"] = recipients
+		}
+		if !to["reporter"].(bool) && !to["assignee"].(bool) && !to["watchers"].(bool) && len(users) == 0 && len(groups) == 0 {
+			return map[string]any{
+				"error":   "validation_error",
+				"message": "At least one recipient is required: notify the reporter, assignee or watchers, or list users or groups",
+			}
+		}
+
+		jiraClient := client.NewJiraClient(creds)
+		err := jiraClient.NotifyIssue(ctx, issueKey, subject, textBody, to)
+		if err != nil {
+			log.Printf("Failed to notify about issue: %v", err)
+			return map[string]any{
+				"error":   client.ErrorCode(err),
+				"message": fmt.Sprintf("Failed to send notification: %v", err),
+			}
+		}
+
+		result := map[string]any{
+			"result":   "success",
+			"message":  fmt.Sprintf("Notification about issue %s queued for delivery", issueKey),
+			"issueKey": issueKey,
+		}
+		return result
+	})
+}
+
+// AddWorklogHandler handles the issues.worklog.add action
+func AddWorklogHandler(msg *nats.Msg) {
+	handler.Run(msg, "issues.worklog.add", func(ctx context.Context, creds *credentials.JiraCredentials, body map[string]any, progress handler.ProgressFunc) map[string]any {
+		// Extract form fields
+		issueKey, _ := body["issueKey"].(string)
+		timeSpent, _ := body["timeSpent"].(string)
+		startedRaw, _ := body["started"].(string)
+		comment, _ := body["comment"].(string)
+
+		// Validate required fields
+		if issueKey == "" {
+			return map[string]any{
+				"error":   "validation_error",
+				"message": "Issue key or ID is required",
+			}
+		}
+		if strings.TrimSpace(timeSpent) == "" {
+			return map[string]any{
+				"error":   "validation_error",
+				"message": "Time spent is required (e.g., 3h 30m)",
+			}
+		}
+
+		// Parse the optional start time (RFC 3339 or Jira's own format)
+		var started time.Time
+		if startedRaw != "" {
+			var err error
+			started, err = time.Parse(time.RFC3339, startedRaw)
+			if err != nil {
+				started, err = time.Parse(client.JiraTimeFormat, startedRaw)
+			}
+			if err != nil {
+				return map[string]any{
+					"error":   "validation_error",
+					"message": fmt.Sprintf("Invalid started time '%s': use a format like 2024-12-31T09:00:00Z", startedRaw),
+				}
+			}
+		}
+
+		// Create Jira client and add worklog
+		jiraClient := client.NewJiraClient(creds)
+		worklog, err := jiraClient.AddWorklog(ctx, issueKey, timeSpent, comment, started)
+		if err != nil {
+			log.Printf("Failed to add worklog: %v", err)
+			return map[string]any{
+				"error":   client.ErrorCode(err),
+				"message": fmt.Sprintf("Failed to add worklog: %v", err),
+			}
+		}
+
+		worklogId, _ := worklog["id"].(string)
+
+		// The worklog response doesn't include the issue's estimate, so fetch it separately
+		remainingEstimate := ""
+		issue, err := jiraClient.GetIssue(ctx, issueKey, []string{"timetracking"}, nil)
+		if err != nil {
+			log.Printf("Failed to fetch remaining estimate for issue %s: %v", issueKey, err)
+		} else if issueFields, ok := issue["fields"].(map[string]interface{}); ok {
+			if timetracking, ok := issueFields["timetracking"].(map[string]interface{}); ok {
+				remainingEstimate, _ = timetracking["remainingEstimate"].(string)
+			}
+		}
+
+		log.Printf("Successfully added worklog %s to Jira issue %s", worklogId, issueKey)
+
+		result := map[string]any{
+			"result":            "success",
+			"message":           fmt.Sprintf("Logged %s on issue %s", timeSpent, issueKey),
+			"issueKey":          issueKey,
+			"worklogId":         worklogId,
+			"remainingEstimate": remainingEstimate,
+			"worklog":           worklog,
+		}
+		return result
+	})
+}
+
+// LinkIssuesHandler handles the issues.link action
+func LinkIssuesHandler(msg *nats.Msg) {
+	handler.Run(msg, "issues.link", func(ctx context.Context, creds *credentials.JiraCredentials, body map[string]any, progress handler.ProgressFunc) map[string]any {
+		// Extract form fields
+		inwardIssueKey, _ := body["inwardIssueKey"].(string)
+		outwardIssueKey, _ := body["outwardIssueKey"].(string)
+		linkType, _ := body["linkType"].(string)
+		comment, _ := body["comment"].(string)
+
+		// Validate required fields
+		if inwardIssueKey == "" || outwardIssueKey == "" {
+			return map[string]any{
 				"error":   "validation_error",
-				"message": "Issue key or ID is required",
+				"message": "Both inward and outward issue keys are required",
+			}
+		}
+		if linkType == "" {
+			return map[string]any{
+				"error":   "validation_error",
+				"message": "Link type is required (use issues.link.types to list valid names)",
 			}
 		}
 
-		// Create Jira client and delete issue
+		// Create Jira client and link issues
 		jiraClient := client.NewJiraClient(creds)
-		err := jiraClient.DeleteIssue(issueKey, deleteSubtasks)
+		err := jiraClient.LinkIssues(ctx, inwardIssueKey, outwardIssueKey, linkType, comment)
 		if err != nil {
-			log.Printf("Failed to delete issue: %v", err)
+			log.Printf("Failed to link issues: %v", err)
 			return map[string]any{
-				"error":   "jira_api_error",
-				"message": fmt.Sprintf("Failed to delete issue: %v", err),
+				"error":   client.ErrorCode(err),
+				"message": fmt.Sprintf("Failed to link issues: %v", err),
 			}
 		}
 
-		log.Printf("Successfully deleted Jira issue: %s", issueKey)
+		log.Printf("Successfully linked Jira issues %s and %s (%s)", inwardIssueKey, outwardIssueKey, linkType)
 
 		result := map[string]any{
-			"result":   "success",
-			"message":  fmt.Sprintf("Issue %s deleted successfully", issueKey),
-			"issueKey": issueKey,
+			"result":          "success",
+			"message":         fmt.Sprintf("Linked %s and %s (%s)", inwardIssueKey, outwardIssueKey, linkType),
+			"inwardIssueKey":  inwardIssueKey,
+			"outwardIssueKey": outwardIssueKey,
+			"linkType":        linkType,
 		}
 		return result
 	})
 }
 
-// AddCommentHandler handles the issues.comment action
-func AddCommentHandler(msg *nats.Msg) {
-	handleActionWithCredentialsCheckSync(msg, "issues.comment", func(creds *credentials.JiraCredentials, body map[string]any) map[string]any {
+// ListLinkTypesHandler handles the issues.link.types action
+func ListLinkTypesHandler(msg *nats.Msg) {
+	handler.Run(msg, "issues.link.types", func(ctx context.Context, creds *credentials.JiraCredentials, body map[string]any, progress handler.ProgressFunc) map[string]any {
+		// Create Jira client and fetch link types
+		jiraClient := client.NewJiraClient(creds)
+		linkTypes, err := jiraClient.ListLinkTypes(ctx)
+		if err != nil {
+			log.Printf("Failed to list link types: %v", err)
+			return map[string]any{
+				"error":   client.ErrorCode(err),
+				"message": fmt.Sprintf("Failed to fetch link types: %v", err),
+			}
+		}
+
+		result := map[string]any{
+			"result":    "success",
+			"message":   fmt.Sprintf("Successfully retrieved %d link types", len(linkTypes)),
+			"linkTypes": linkTypes,
+			"count":     len(linkTypes),
+		}
+		return result
+	})
+}
+
+// AddWatcherHandler handles the issues.watchers.add action
+func AddWatcherHandler(msg *nats.Msg) {
+	handler.Run(msg, "issues.watchers.add", func(ctx context.Context, creds *credentials.JiraCredentials, body map[string]any, progress handler.ProgressFunc) map[string]any {
 		// Extract form fields
 		issueKey, _ := body["issueKey"].(string)
-		commentBody, _ := body["commentBody"].(string)
-		var visibility map[string]interface{}
+		accountId, _ := body["accountId"].(string)
 
-		// Extract visibility if provided
-		if visibilityRaw, ok := body["visibility"]; ok {
-			if visMap, ok := visibilityRaw.(map[string]interface{}); ok {
-				visibility = visMap
-			} else if visMap, ok := visibilityRaw.(map[string]any); ok {
-				// Convert map[string]any to map[string]interface{}
-				visibility = make(map[string]interface{})
-				for k, v := range visMap {
-					visibility[k] = v
-				}
+		// Validate required fields
+		if issueKey == "" {
+			return map[string]any{
+				"error":   "validation_error",
+				"message": "Issue key or ID is required",
+			}
+		}
+		if accountId == "" {
+			return map[string]any{
+				"error":   "validation_error",
+				"message": "Account ID is required",
 			}
 		}
 
-		// Extract additionalFields if provided (as object)
-		var additionalFields map[string]interface{}
-		if additionalFieldsRaw, ok := body["additionalFields"]; ok {
-			if afMap, ok := additionalFieldsRaw.(map[string]interface{}); ok {
-				additionalFields = afMap
-			} else if afMap, ok := additionalFieldsRaw.(map[string]any); ok {
-				// Convert map[string]any to map[string]interface{}
-				additionalFields = make(map[string]interface{})
-				for k, v := range afMap {
-					additionalFields[k] = v
-				}
+		// Create Jira client and add watcher
+		jiraClient := client.NewJiraClient(creds)
+		err := jiraClient.AddWatcher(ctx, issueKey, accountId)
+		if err != nil {
+			log.Printf("Failed to add watcher: %v", err)
+			return map[string]any{
+				"error":   client.ErrorCode(err),
+				"message": fmt.Sprintf("Failed to add watcher: %v", err),
 			}
 		}
 
-		// Also check for any other fields that might have been passed directly
-		// (for backward compatibility and flexibility)
-		knownFields := map[string]bool{
-			"issueKey":         true,
-			"commentBody":      true,
-			"visibility":       true,
-			"additionalFields": true,
+		result := map[string]any{
+			"result":    "success",
+			"message":   fmt.Sprintf("User %s is now watching issue %s", accountId, issueKey),
+			"issueKey":  issueKey,
+			"accountId": accountId,
 		}
+		return result
+	})
+}
 
-		// Merge any other fields that aren't in the known list into additionalFields
-		if additionalFields == nil {
-			additionalFields = make(map[string]interface{})
+// RemoveWatcherHandler handles the issues.watchers.remove action
+func RemoveWatcherHandler(msg *nats.Msg) {
+	handler.Run(msg, "issues.watchers.remove", func(ctx context.Context, creds *credentials.JiraCredentials, body map[string]any, progress handler.ProgressFunc) map[string]any {
+		// Extract form fields
+		issueKey, _ := body["issueKey"].(string)
+		accountId, _ := body["accountId"].(string)
+
+		// Validate required fields
+		if issueKey == "" {
+			return map[string]any{
+				"error":   "validation_error",
+				"message": "Issue key or ID is required",
+			}
 		}
-		for key, value := range body {
-			if !knownFields[key] && value != nil && value != "" {
-				additionalFields[key] = value
+		if accountId == "" {
+			return map[string]any{
+				"error":   "validation_error",
+				"message": "Account ID is required",
+			}
+		}
+
+		// Create Jira client and remove watcher
+		jiraClient := client.NewJiraClient(creds)
+		err := jiraClient.RemoveWatcher(ctx, issueKey, accountId)
+		if err != nil {
+			log.Printf("Failed to remove watcher: %v", err)
+			return map[string]any{
+				"error":   client.ErrorCode(err),
+				"message": fmt.Sprintf("Failed to remove watcher: %v", err),
+			}
+		}
+
+		result := map[string]any{
+			"result":    "success",
+			"message":   fmt.Sprintf("User %s is no longer watching issue %s", accountId, issueKey),
+			"issueKey":  issueKey,
+			"accountId": accountId,
+		}
+		return result
+	})
+}
+
+// ListWatchersHandler handles the issues.watchers.list action
+func ListWatchersHandler(msg *nats.Msg) {
+	handler.Run(msg, "issues.watchers.list", func(ctx context.Context, creds *credentials.JiraCredentials, body map[string]any, progress handler.ProgressFunc) map[string]any {
+		// Extract form fields
+		issueKey, _ := body["issueKey"].(string)
+
+		// Validate required fields
+		if issueKey == "" {
+			return map[string]any{
+				"error":   "validation_error",
+				"message": "Issue key or ID is required",
+			}
+		}
+
+		// Create Jira client and list watchers
+		jiraClient := client.NewJiraClient(creds)
+		watchersResult, err := jiraClient.ListWatchers(ctx, issueKey)
+		if err != nil {
+			log.Printf("Failed to list watchers: %v", err)
+			return map[string]any{
+				"error":   client.ErrorCode(err),
+				"message": fmt.Sprintf("Failed to list watchers: %v", err),
 			}
 		}
 
+		watchers, _ := watchersResult["watchers"].([]interface{})
+		if watchers == nil {
+			watchers = []interface{}{}
+		}
+
+		result := map[string]any{
+			"result":     "success",
+			"message":    fmt.Sprintf("Issue %s has %d watchers", issueKey, len(watchers)),
+			"issueKey":   issueKey,
+			"watchers":   watchers,
+			"watchCount": watchersResult["watchCount"],
+			"isWatching": watchersResult["isWatching"],
+		}
+		return result
+	})
+}
+
+// AmIWatchingHandler handles the issues.watchers.am-i-watching action
+func AmIWatchingHandler(msg *nats.Msg) {
+	handler.Run(msg, "issues.watchers.am-i-watching", func(ctx context.Context, creds *credentials.JiraCredentials, body map[string]any, progress handler.ProgressFunc) map[string]any {
+		// Extract form fields
+		issueKey, _ := body["issueKey"].(string)
+
 		// Validate required fields
 		if issueKey == "" {
 			return map[string]any{
@@ -391,38 +4433,251 @@ func AddCommentHandler(msg *nats.Msg) {
 				"message": "Issue key or ID is required",
 			}
 		}
-		if commentBody == "" {
+
+		jiraClient := client.NewJiraClient(creds)
+		currentUser, err := jiraClient.GetCurrentUser(ctx)
+		if err != nil {
+			log.Printf("Failed to get current user: %v", err)
+			return map[string]any{
+				"error":   client.ErrorCode(err),
+				"message": fmt.Sprintf("Failed to get current user: %v", err),
+			}
+		}
+		watchersResult, err := jiraClient.ListWatchers(ctx, issueKey)
+		if err != nil {
+			log.Printf("Failed to list watchers: %v", err)
+			return map[string]any{
+				"error":   client.ErrorCode(err),
+				"message": fmt.Sprintf("Failed to list watchers: %v", err),
+			}
+		}
+
+		watching := false
+		watchers, _ := watchersResult["watchers"].([]interface{})
+		for _, raw := range watchers {
+			if watcher, ok := raw.(map[string]interface{}); ok && sameUser(watcher, currentUser) {
+				watching = true
+				break
+			}
+		}
+		// Without permission to view watchers the list is empty, but Jira still
+		// reports whether the caller watches the issue
+		if isWatching, ok := watchersResult["isWatching"].(bool); ok && !watching {
+			watching = isWatching
+		}
+
+		message := fmt.Sprintf("You are not watching issue %s", issueKey)
+		if watching {
+			message = fmt.Sprintf("You are watching issue %s", issueKey)
+		}
+		result := map[string]any{
+			"result":     "success",
+			"message":    message,
+			"issueKey":   issueKey,
+			"watching":   watching,
+			"watchCount": watchersResult["watchCount"],
+		}
+		return result
+	})
+}
+
+// sameUser reports whether two Jira user objects are the same user, comparing the
+// accountId on Cloud and the user key or name on Server/Data Center
+func sameUser(a, b map[string]interface{}) bool {
+	for _, field := range []string{"accountId", "key", "name"} {
+		if id, _ := a[field].(string); id != "" {
+			if other, _ := b[field].(string); other != "" {
+				return id == other
+			}
+		}
+	}
+	return false
+}
+
+// AddVoteHandler handles the issues.vote action
+func AddVoteHandler(msg *nats.Msg) {
+	handler.Run(msg, "issues.vote", func(ctx context.Context, creds *credentials.JiraCredentials, body map[string]any, progress handler.ProgressFunc) map[string]any {
+		return changeVote(ctx, creds, body, true)
+	})
+}
+
+// RemoveVoteHandler handles the issues.unvote action
+func RemoveVoteHandler(msg *nats.Msg) {
+	handler.Run(msg, "issues.unvote", func(ctx context.Context, creds *credentials.JiraCredentials, body map[string]any, progress handler.ProgressFunc) map[string]any {
+		return changeVote(ctx, creds, body, false)
+	})
+}
+
+// changeVote adds or removes the connected user's vote and returns the new vote count
+func changeVote(ctx context.Context, creds *credentials.JiraCredentials, body map[string]any, vote bool) map[string]any {
+	// Extract form fields
+	issueKey, _ := body["issueKey"].(string)
+
+	// Validate required fields
+	if issueKey == "" {
+		return map[string]any{
+			"error":   "validation_error",
+			"message": "Issue key or ID is required",
+		}
+	}
+
+	jiraClient := client.NewJiraClient(creds)
+	var err error
+	if vote {
+		err = jiraClient.AddVote(ctx, issueKey)
+	} else {
+		err = jiraClient.RemoveVote(ctx, issueKey)
+	}
+	if err != nil {
+		log.Printf("Failed to change vote: %v", err)
+		return map[string]any{
+			"error":   client.ErrorCode(err),
+			"message": fmt.Sprintf("Failed to change vote: %v", err),
+		}
+	}
+
+	message := fmt.Sprintf("Voted for issue %s", issueKey)
+	if !vote {
+		message = fmt.Sprintf("Vote removed from issue %s", issueKey)
+	}
+	result := map[string]any{
+		"result":   "success",
+		"message":  message,
+		"issueKey": issueKey,
+		"hasVoted": vote,
+	}
+	// The count is informational; failing to read it doesn't fail the vote
+	if votes, err := jiraClient.GetVotes(ctx, issueKey); err == nil {
+		result["votes"] = votes["votes"]
+	}
+	return result
+}
+
+// GetVotesHandler handles the issues.votes action
+func GetVotesHandler(msg *nats.Msg) {
+	handler.Run(msg, "issues.votes", func(ctx context.Context, creds *credentials.JiraCredentials, body map[string]any, progress handler.ProgressFunc) map[string]any {
+		// Extract form fields
+		issueKey, _ := body["issueKey"].(string)
+
+		// Validate required fields
+		if issueKey == "" {
 			return map[string]any{
 				"error":   "validation_error",
-				"message": "Comment body is required",
+				"message": "Issue key or ID is required",
 			}
 		}
 
-		// Create Jira client and add comment
 		jiraClient := client.NewJiraClient(creds)
-		comment, err := jiraClient.AddComment(issueKey, commentBody, visibility, additionalFields)
+		votes, err := jiraClient.GetVotes(ctx, issueKey)
 		if err != nil {
-			log.Printf("Failed to add comment: %v", err)
+			log.Printf("Failed to get votes: %v", err)
 			return map[string]any{
-				"error":   "jira_api_error",
-				"message": fmt.Sprintf("Failed to add comment: %v", err),
+				"error":   client.ErrorCode(err),
+				"message": fmt.Sprintf("Failed to get votes: %v", err),
 			}
 		}
 
-		// Extract comment ID from response
-		commentId, _ := comment["id"].(string)
-		commentAuthor, _ := comment["author"].(map[string]interface{})
+		voters, _ := votes["voters"].([]interface{})
+		if voters == nil {
+			voters = []interface{}{}
+		}
 
-		log.Printf("Successfully added comment to Jira issue %s (comment ID: %s)", issueKey, commentId)
+		result := map[string]any{
+			"result":   "success",
+			"message":  fmt.Sprintf("Issue %s has %v votes", issueKey, votes["votes"]),
+			"issueKey": issueKey,
+			"votes":    votes["votes"],
+			"hasVoted": votes["hasVoted"],
+			"voters":   voters,
+		}
+		return result
+	})
+}
+
+// AddLabelsHandler handles the issues.labels.add action
+func AddLabelsHandler(msg *nats.Msg) {
+	handler.Run(msg, "issues.labels.add", modifyLabels)
+}
+
+// RemoveLabelsHandler handles the issues.labels.remove action
+func RemoveLabelsHandler(msg *nats.Msg) {
+	handler.Run(msg, "issues.labels.remove", modifyLabels)
+}
+
+// SuggestLabelsHandler handles the issues.labels.suggest action
+func SuggestLabelsHandler(msg *nats.Msg) {
+	handler.Run(msg, "issues.labels.suggest", func(ctx context.Context, creds *credentials.JiraCredentials, body map[string]any, progress handler.ProgressFunc) map[string]any {
+		// Extract form fields; an empty query returns Jira's most relevant labels
+		query, _ := body["query"].(string)
+		query = strings.TrimSpace(query)
+		maxResults := handler.IntValue(body, "maxResults", 20)
+
+		jiraClient := client.NewJiraClient(creds)
+		labels, err := jiraClient.SuggestLabels(ctx, query, maxResults)
+		if err != nil {
+			log.Printf("Failed to suggest labels: %v", err)
+			return map[string]any{
+				"error":   client.ErrorCode(err),
+				"message": fmt.Sprintf("Failed to suggest labels: %v", err),
+			}
+		}
 
 		result := map[string]any{
-			"result":        "success",
-			"message":       fmt.Sprintf("Comment added successfully to issue %s", issueKey),
-			"issueKey":      issueKey,
-			"commentId":     commentId,
-			"comment":       comment,
-			"commentAuthor": commentAuthor,
+			"result":  "success",
+			"message": fmt.Sprintf("Found %d labels matching '%s'", len(labels), query),
+			"query":   query,
+			"labels":  labels,
+			"count":   len(labels),
 		}
 		return result
 	})
 }
+
+// modifyLabels applies the add and remove label arrays from the request body.
+// Both label actions accept both arrays so a single call can add and remove labels.
+func modifyLabels(ctx context.Context, creds *credentials.JiraCredentials, body map[string]any, progress handler.ProgressFunc) map[string]any {
+	// Extract form fields
+	issueKey, _ := body["issueKey"].(string)
+	add := handler.StringSlice(body, "add")
+	remove := handler.StringSlice(body, "remove")
+
+	// Validate required fields
+	if issueKey == "" {
+		return map[string]any{
+			"error":   "validation_error",
+			"message": "Issue key or ID is required",
+		}
+	}
+	if len(add) == 0 && len(remove) == 0 {
+		return map[string]any{
+			"error":   "validation_error",
+			"message": "At least one label to add or remove is required",
+		}
+	}
+	if err := client.ValidateLabels(add); err != nil {
+		return map[string]any{
+			"error":   "validation_error",
+			"message": fmt.Sprintf("Invalid labels: %v", err),
+		}
+	}
+
+	// Create Jira client and modify labels
+	jiraClient := client.NewJiraClient(creds)
+	err := jiraClient.ModifyLabels(ctx, issueKey, add, remove)
+	if err != nil {
+		log.Printf("Failed to modify labels: %v", err)
+		return map[string]any{
+			"error":   client.ErrorCode(err),
+			"message": fmt.Sprintf("Failed to modify labels: %v", err),
+		}
+	}
+
+	result := map[string]any{
+		"result":   "success",
+		"message":  fmt.Sprintf("Labels updated successfully on issue %s", issueKey),
+		"issueKey": issueKey,
+		"added":    add,
+		"removed":  remove,
+	}
+	return result
+}
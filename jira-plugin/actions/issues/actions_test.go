@@ -0,0 +1,78 @@
+package issues
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateCommentVisibility(t *testing.T) {
+	tests := []struct {
+		name       string
+		visibility map[string]interface{}
+		wantErr    string
+		wantType   string
+	}{
+		{
+			name:       "role",
+			visibility: map[string]interface{}{"type": "role", "value": "Developers"},
+			wantType:   "role",
+		},
+		{
+			name:       "group",
+			visibility: map[string]interface{}{"type": "group", "value": "jira-software-users"},
+			wantType:   "group",
+		},
+		{
+			name:       "type is lowercased",
+			visibility: map[string]interface{}{"type": " Role ", "value": "Administrators"},
+			wantType:   "role",
+		},
+		{
+			name:       "group by identifier",
+			visibility: map[string]interface{}{"type": "group", "identifier": "276f955c-63d7-42c8-9520-92d01dca0625"},
+			wantType:   "group",
+		},
+		{
+			name:       "role by identifier needs a value",
+			visibility: map[string]interface{}{"type": "role", "identifier": "10002"},
+			wantErr:    "visibility.value is required: the name of the role",
+		},
+		{
+			name:       "missing value",
+			visibility: map[string]interface{}{"type": "group", "value": "  "},
+			wantErr:    "visibility.value is required: the name of the group",
+		},
+		{
+			name:       "unknown type",
+			visibility: map[string]interface{}{"type": "user", "value": "ada"},
+			wantErr:    `visibility.type must be "role" or "group", got "user"`,
+		},
+		{
+			name:       "missing type",
+			visibility: map[string]interface{}{"value": "Developers"},
+			wantErr:    `visibility.type must be "role" or "group"`,
+		},
+		{
+			name:       "non-string type",
+			visibility: map[string]interface{}{"type": 1, "value": "Developers"},
+			wantErr:    `visibility.type must be "role" or "group"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := validateCommentVisibility(tt.visibility)
+			if tt.wantErr != "" {
+				if !strings.Contains(got, tt.wantErr) {
+					t.Errorf("validateCommentVisibility() = %q, want %q", got, tt.wantErr)
+				}
+				return
+			}
+			if got != "" {
+				t.Fatalf("validateCommentVisibility() = %q, want no error", got)
+			}
+			if tt.visibility["type"] != tt.wantType {
+				t.Errorf("type = %v, want %q", tt.visibility["type"], tt.wantType)
+			}
+		})
+	}
+}
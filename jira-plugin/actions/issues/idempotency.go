@@ -0,0 +1,117 @@
+package issues
+
+import (
+	"context"
+	"log"
+	"maps"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sorenhq/jira-plugin/credentials"
+)
+
+// idempotencyWindowEnv overrides how long an issues.create idempotency key is remembered
+// (a Go duration such as "10m" or "1h")
+const idempotencyWindowEnv = "SOREN_JIRA_IDEMPOTENCY_WINDOW"
+
+// defaultIdempotencyWindow is how long an idempotency key is remembered by default
+const defaultIdempotencyWindow = 10 * time.Minute
+
+// idempotencyEntry tracks one idempotency key: done is closed once the first request
+// finishes, and result is only kept if that request succeeded
+type idempotencyEntry struct {
+	done    chan struct{}
+	result  map[string]any
+	expires time.Time
+}
+
+// idempotencyStore remembers the results of recent requests by idempotency key, in memory
+type idempotencyStore struct {
+	mu      sync.Mutex
+	entries map[string]*idempotencyEntry
+}
+
+// createIdempotency holds the idempotency keys of issues.create
+var createIdempotency = &idempotencyStore{entries: make(map[string]*idempotencyEntry)}
+
+// idempotencyScope keys an idempotency key by the space and credentials profile it was
+// sent with (and the Jira instance), so keys chosen by different spaces can't collide
+// even when they connect to Jira with the same account
+func idempotencyScope(creds *credentials.JiraCredentials, key string) string {
+	return creds.StorageKey() + "|" + strings.TrimSuffix(creds.InstanceURL, "/") + "|" + key
+}
+
+// idempotencyWindow returns the configured window, falling back to the default
+func idempotencyWindow() time.Duration {
+	raw := strings.TrimSpace(os.Getenv(idempotencyWindowEnv))
+	if raw == "" {
+		return defaultIdempotencyWindow
+	}
+	window, err := time.ParseDuration(raw)
+	if err != nil || window <= 0 {
+		log.Printf("Invalid %s %q, using %v", idempotencyWindowEnv, raw, defaultIdempotencyWindow)
+		return defaultIdempotencyWindow
+	}
+	return window
+}
+
+// do runs fn for the first request with a key and returns its result. Later requests
+// with the same key within the window get a copy of that result, marked with
+// "idempotentReplay", instead of running fn again. Requests arriving while the first
+// one is still running wait for it. Failed results are not remembered, so a failed
+// request can be retried with the same key.
+func (s *idempotencyStore) do(ctx context.Context, key string, fn func() map[string]any) map[string]any {
+	for {
+		s.mu.Lock()
+		now := time.Now()
+		for k, entry := range s.entries {
+			if entry.result != nil && now.After(entry.expires) {
+				delete(s.entries, k)
+			}
+		}
+
+		entry, exists := s.entries[key]
+		if !exists {
+			entry = &idempotencyEntry{done: make(chan struct{})}
+			s.entries[key] = entry
+			s.mu.Unlock()
+			return s.run(key, entry, fn)
+		}
+		if entry.result != nil {
+			replay := maps.Clone(entry.result)
+			s.mu.Unlock()
+			log.Printf("Idempotency key already used, returning the previous result")
+			replay["idempotentReplay"] = true
+			return replay
+		}
+		s.mu.Unlock()
+
+		// The first request is still running; wait for it and check again
+		select {
+		case <-entry.done:
+		case <-ctx.Done():
+			return map[string]any{
+				"error":   "timeout",
+				"message": "Timed out waiting for an earlier request with the same idempotency key",
+			}
+		}
+	}
+}
+
+// run executes fn for a newly claimed key and records its result
+func (s *idempotencyStore) run(key string, entry *idempotencyEntry, fn func() map[string]any) map[string]any {
+	result := fn()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, failed := result["error"]; failed {
+		delete(s.entries, key)
+	} else {
+		entry.result = result
+		entry.expires = time.Now().Add(idempotencyWindow())
+	}
+	close(entry.done)
+	return result
+}
@@ -0,0 +1,65 @@
+package issues
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sorenhq/jira-plugin/credentials"
+)
+
+func TestIdempotencyScopeIsPerSpace(t *testing.T) {
+	t.Chdir(t.TempDir())
+	t.Setenv("SOREN_CREDENTIALS_KEY", "")
+	storage := credentials.NewCredentialsStorage()
+
+	// Two spaces onboarded with the same Jira account
+	shared := credentials.JiraCredentials{
+		InstanceURL: "https://example.atlassian.net",
+		Email:       "ada@example.com",
+		APIToken:    "token",
+	}
+	load := func(spaceID, profile string) *credentials.JiraCredentials {
+		t.Helper()
+		if err := storage.SaveCredentialsProfile(spaceID, profile, shared); err != nil {
+			t.Fatalf("SaveCredentialsProfile: %v", err)
+		}
+		creds, err := storage.GetCredentialsProfile(spaceID, profile)
+		if err != nil {
+			t.Fatalf("GetCredentialsProfile: %v", err)
+		}
+		return creds
+	}
+	spaceA := load("space-a", "")
+	spaceB := load("space-b", "")
+	spaceAStaging := load("space-a", "staging")
+
+	if idempotencyScope(spaceA, "key-1") != idempotencyScope(load("space-a", ""), "key-1") {
+		t.Error("the same space and key map to different scopes")
+	}
+	if idempotencyScope(spaceA, "key-1") == idempotencyScope(spaceB, "key-1") {
+		t.Error("spaces sharing a Jira account share idempotency keys")
+	}
+	if idempotencyScope(spaceA, "key-1") == idempotencyScope(spaceAStaging, "key-1") {
+		t.Error("profiles of a space share idempotency keys")
+	}
+
+	// A key reused in another space runs the request instead of replaying
+	store := &idempotencyStore{entries: make(map[string]*idempotencyEntry)}
+	runs := 0
+	create := func(creds *credentials.JiraCredentials) map[string]any {
+		return store.do(context.Background(), idempotencyScope(creds, "key-1"), func() map[string]any {
+			runs++
+			return map[string]any{"result": "success", "key": "COM-1"}
+		})
+	}
+	create(spaceA)
+	if replay := create(spaceA); replay["idempotentReplay"] != true {
+		t.Errorf("second request in space A = %v, want a replay", replay)
+	}
+	if result := create(spaceB); result["idempotentReplay"] == true {
+		t.Errorf("request in space B = %v, want a new run", result)
+	}
+	if runs != 2 {
+		t.Errorf("runs = %d, want 2", runs)
+	}
+}
@@ -1,16 +1,23 @@
 package projects
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log"
+	"strings"
 
 	"github.com/nats-io/nats.go"
 	sdkv2Models "github.com/sorenhq/go-plugin-sdk/gosdk/models"
 
+	"github.com/sorenhq/jira-plugin/actions/handler"
 	"github.com/sorenhq/jira-plugin/client"
 	"github.com/sorenhq/jira-plugin/credentials"
 )
 
+// maxProjectDetailWorkers caps the concurrency a projects.list request may ask for
+const maxProjectDetailWorkers = 20
+
 // GetActions returns all project-related actions
 func GetActions() []sdkv2Models.Action {
 	return []sdkv2Models.Action{
@@ -19,24 +26,318 @@ func GetActions() []sdkv2Models.Action {
 			Title:       "List Projects",
 			Description: "Get a list of all projects in your Jira instance",
 			Form: sdkv2Models.ActionFormBuilder{
-				Jsonui:     map[string]any{},
-				Jsonschema: map[string]any{"type": "object", "properties": map[string]any{}},
+				Jsonui: map[string]any{
+					"type": "VerticalLayout",
+					"elements": []map[string]any{
+						{
+							"type":  "Control",
+							"scope": "#/properties/refresh",
+						},
+						{
+							"type":  "Control",
+							"scope": "#/properties/includeDetails",
+						},
+						{
+							"type":  "Control",
+							"scope": "#/properties/concurrency",
+						},
+					},
+				},
+				Jsonschema: map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"refresh": map[string]any{
+							"type":        "boolean",
+							"title":       "Refresh",
+							"description": "Bypass the metadata cache and fetch fresh data from Jira",
+							"default":     false,
+						},
+						"includeDetails": map[string]any{
+							"type":        "boolean",
+							"title":       "Include Details",
+							"description": "Fetch each project's full details (lead, issue types, components)",
+							"default":     false,
+						},
+						"concurrency": map[string]any{
+							"type":        "integer",
+							"title":       "Concurrency",
+							"description": fmt.Sprintf("Number of projects fetched in parallel when including details (1-%d)", maxProjectDetailWorkers),
+							"default":     client.DefaultProjectDetailWorkers,
+						},
+					},
+				},
 			},
 			RequestHandler: ListProjectsHandler,
 		},
+		{
+			Method:      "projects.get",
+			Title:       "Get Project",
+			Description: "Get a single Jira project by key, including its lead, issue types and components",
+			Form: sdkv2Models.ActionFormBuilder{
+				Jsonui: map[string]any{
+					"type": "VerticalLayout",
+					"elements": []map[string]any{
+						{
+							"type":  "Control",
+							"scope": "#/properties/projectKey",
+						},
+					},
+				},
+				Jsonschema: map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"projectKey": map[string]any{
+							"type":        "string",
+							"title":       "Project Key",
+							"description": "The project key (e.g., PROJ)",
+						},
+					},
+					"required": []string{"projectKey"},
+				},
+			},
+			RequestHandler: GetProjectHandler,
+		},
+		{
+			Method:      "projects.issuetypes",
+			Title:       "List Project Issue Types",
+			Description: "List the issue types that can be created in a project",
+			Form: sdkv2Models.ActionFormBuilder{
+				Jsonui: map[string]any{
+					"type": "VerticalLayout",
+					"elements": []map[string]any{
+						{
+							"type":  "Control",
+							"scope": "#/properties/projectKey",
+						},
+						{
+							"type":  "Control",
+							"scope": "#/properties/refresh",
+						},
+					},
+				},
+				Jsonschema: map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"projectKey": map[string]any{
+							"type":        "string",
+							"title":       "Project Key",
+							"description": "The project key (e.g., PROJ)",
+						},
+						"refresh": map[string]any{
+							"type":        "boolean",
+							"title":       "Refresh",
+							"description": "Bypass the metadata cache and fetch fresh data from Jira",
+							"default":     false,
+						},
+					},
+					"required": []string{"projectKey"},
+				},
+			},
+			RequestHandler: ListIssueTypesHandler,
+		},
+		{
+			Method:      "projects.components.list",
+			Title:       "List Components",
+			Description: "List the components of a project",
+			Form: sdkv2Models.ActionFormBuilder{
+				Jsonui: map[string]any{
+					"type": "VerticalLayout",
+					"elements": []map[string]any{
+						{
+							"type":  "Control",
+							"scope": "#/properties/projectKey",
+						},
+					},
+				},
+				Jsonschema: map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"projectKey": map[string]any{
+							"type":        "string",
+							"title":       "Project Key",
+							"description": "The project key (e.g., PROJ)",
+						},
+					},
+					"required": []string{"projectKey"},
+				},
+			},
+			RequestHandler: ListComponentsHandler,
+		},
+		{
+			Method:      "projects.components.create",
+			Title:       "Create Component",
+			Description: "Create a component in a project",
+			Form: sdkv2Models.ActionFormBuilder{
+				Jsonui: map[string]any{
+					"type": "VerticalLayout",
+					"elements": []map[string]any{
+						{
+							"type":  "Control",
+							"scope": "#/properties/projectKey",
+						},
+						{
+							"type":  "Control",
+							"scope": "#/properties/name",
+						},
+						{
+							"type":  "Control",
+							"scope": "#/properties/description",
+						},
+						{
+							"type":  "Control",
+							"scope": "#/properties/leadAccountId",
+						},
+					},
+				},
+				Jsonschema: map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"projectKey": map[string]any{
+							"type":        "string",
+							"title":       "Project Key",
+							"description": "The key of the project to create the component in (e.g., PROJ)",
+						},
+						"name": map[string]any{
+							"type":        "string",
+							"title":       "Name",
+							"description": "The component name",
+						},
+						"description": map[string]any{
+							"type":        "string",
+							"title":       "Description",
+							"description": "Optional component description",
+							"format":      "textarea",
+						},
+						"leadAccountId": map[string]any{
+							"type":        "string",
+							"title":       "Lead Account ID",
+							"description": "Optional account ID of the component lead",
+						},
+					},
+					"required": []string{"projectKey", "name"},
+				},
+			},
+			RequestHandler: CreateComponentHandler,
+		},
+		{
+			Method:      "projects.components.delete",
+			Title:       "Delete Component",
+			Description: "Delete a project component",
+			Form: sdkv2Models.ActionFormBuilder{
+				Jsonui: map[string]any{
+					"type": "VerticalLayout",
+					"elements": []map[string]any{
+						{
+							"type":  "Control",
+							"scope": "#/properties/componentId",
+						},
+					},
+				},
+				Jsonschema: map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"componentId": map[string]any{
+							"type":        "string",
+							"title":       "Component ID",
+							"description": "The ID of the component",
+						},
+					},
+					"required": []string{"componentId"},
+				},
+			},
+			RequestHandler: DeleteComponentHandler,
+		},
+		{
+			Method:      "projects.roles",
+			Title:       "List Project Roles",
+			Description: "List the roles of a Jira project (e.g., Administrators, Developers) with their IDs",
+			Form: sdkv2Models.ActionFormBuilder{
+				Jsonui: map[string]any{
+					"type": "VerticalLayout",
+					"elements": []map[string]any{
+						{
+							"type":  "Control",
+							"scope": "#/properties/projectKey",
+						},
+					},
+				},
+				Jsonschema: map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"projectKey": map[string]any{
+							"type":        "string",
+							"title":       "Project Key",
+							"description": "The project key (e.g., PROJ)",
+						},
+					},
+					"required": []string{"projectKey"},
+				},
+			},
+			RequestHandler: ListProjectRolesHandler,
+		},
+
+		{
+			Method:      "projects.role-actors",
+			Title:       "List Role Actors",
+			Description: "List the users and groups in a project role",
+			Form: sdkv2Models.ActionFormBuilder{
+				Jsonui: map[string]any{
+					"type": "VerticalLayout",
+					"elements": []map[string]any{
+						{
+							"type":  "Control",
+							"scope": "#/properties/projectKey",
+						},
+						{
+							"type":  "Control",
+							"scope": "#/properties/roleId",
+						},
+						{
+							"type":  "Control",
+							"scope": "#/properties/roleName",
+						},
+					},
+				},
+				Jsonschema: map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"projectKey": map[string]any{
+							"type":        "string",
+							"title":       "Project Key",
+							"description": "The project key (e.g., PROJ)",
+						},
+						"roleId": map[string]any{
+							"type":        "string",
+							"title":       "Role ID",
+							"description": "ID of the role (from projects.roles)",
+						},
+						"roleName": map[string]any{
+							"type":        "string",
+							"title":       "Role Name",
+							"description": "Name of the role (e.g., Administrators), used when no role ID is given",
+						},
+					},
+					"required": []string{"projectKey"},
+				},
+			},
+			RequestHandler: ListRoleActorsHandler,
+		},
 	}
 }
 
 // ListProjectsHandler handles the projects.list action
 func ListProjectsHandler(msg *nats.Msg) {
-	handleActionWithCredentialsCheckSync(msg, "projects.list", func(creds *credentials.JiraCredentials, body map[string]any) map[string]any {
-		// Create Jira client and fetch projects
+	handler.Run(msg, "projects.list", func(ctx context.Context, creds *credentials.JiraCredentials, body map[string]any, progress handler.ProgressFunc) map[string]any {
+		// Create Jira client and fetch projects (cached unless a refresh is requested)
 		jiraClient := client.NewJiraClient(creds)
-		projects, err := jiraClient.ListProjects()
+		if handler.BoolValue(body, "refresh") {
+			jiraClient.InvalidateCache()
+		}
+		projects, err := jiraClient.ListProjects(ctx)
 		if err != nil {
 			log.Printf("Failed to list projects: %v", err)
 			return map[string]any{
-				"error":   "jira_api_error",
+				"error":   client.ErrorCode(err),
 				"message": fmt.Sprintf("Failed to fetch projects: %v", err),
 			}
 		}
@@ -46,6 +347,23 @@ func ListProjectsHandler(msg *nats.Msg) {
 			log.Printf("First project: %+v", projects[0])
 		}
 
+		// Enrich every project with its details; failures are reported per project
+		if handler.BoolValue(body, "includeDetails") {
+			concurrency := handler.IntValue(body, "concurrency", client.DefaultProjectDetailWorkers)
+			concurrency = min(max(concurrency, 1), maxProjectDetailWorkers)
+			projects = jiraClient.GetProjectDetails(ctx, projects, concurrency, func(done, total int) {
+				progress(done*100/total, fmt.Sprintf("Fetched details for %d of %d projects", done, total))
+			})
+
+			failed := 0
+			for _, project := range projects {
+				if _, ok := project["error"]; ok {
+					failed++
+				}
+			}
+			log.Printf("Fetched details for %d projects (%d failed)", len(projects)-failed, failed)
+		}
+
 		result := map[string]any{
 			"result":   "success",
 			"message":  fmt.Sprintf("Successfully retrieved %d projects", len(projects)),
@@ -55,3 +373,376 @@ func ListProjectsHandler(msg *nats.Msg) {
 		return result
 	})
 }
+
+// GetProjectHandler handles the projects.get action
+func GetProjectHandler(msg *nats.Msg) {
+	handler.Run(msg, "projects.get", func(ctx context.Context, creds *credentials.JiraCredentials, body map[string]any, progress handler.ProgressFunc) map[string]any {
+		// Extract form fields
+		projectKey, _ := body["projectKey"].(string)
+
+		// Validate required fields
+		if projectKey == "" {
+			return map[string]any{
+				"error":   "validation_error",
+				"message": "Project key is required",
+			}
+		}
+
+		// Create Jira client and fetch project
+		jiraClient := client.NewJiraClient(creds)
+		project, err := jiraClient.GetProject(ctx, projectKey)
+		if err != nil {
+			log.Printf("Failed to get project: %v", err)
+			if errors.Is(err, client.ErrNotFound) {
+				return map[string]any{
+					"error":   "not_found",
+					"message": fmt.Sprintf("Project %s not found", projectKey),
+				}
+			}
+			return map[string]any{
+				"error":   client.ErrorCode(err),
+				"message": fmt.Sprintf("Failed to get project: %v", err),
+			}
+		}
+
+		resolvedKey, _ := project["key"].(string)
+		name, _ := project["name"].(string)
+		description, _ := project["description"].(string)
+		var lead string
+		if leadMap, ok := project["lead"].(map[string]interface{}); ok {
+			lead, _ = leadMap["displayName"].(string)
+		}
+
+		log.Printf("Successfully retrieved Jira project: %s (%s)", resolvedKey, name)
+
+		result := map[string]any{
+			"result":      "success",
+			"message":     fmt.Sprintf("Project %s retrieved successfully", resolvedKey),
+			"projectKey":  resolvedKey,
+			"name":        name,
+			"description": description,
+			"lead":        lead,
+			"issueTypes":  project["issueTypes"],
+			"components":  project["components"],
+			"project":     project,
+		}
+		return result
+	})
+}
+
+// ListIssueTypesHandler handles the projects.issuetypes action
+func ListIssueTypesHandler(msg *nats.Msg) {
+	handler.Run(msg, "projects.issuetypes", func(ctx context.Context, creds *credentials.JiraCredentials, body map[string]any, progress handler.ProgressFunc) map[string]any {
+		// Extract form fields
+		projectKey, _ := body["projectKey"].(string)
+
+		// Validate required fields
+		if projectKey == "" {
+			return map[string]any{
+				"error":   "validation_error",
+				"message": "Project key is required",
+			}
+		}
+
+		// Create Jira client and fetch issue types (cached unless a refresh is requested)
+		jiraClient := client.NewJiraClient(creds)
+		if handler.BoolValue(body, "refresh") {
+			jiraClient.InvalidateCache()
+		}
+		issueTypes, err := jiraClient.ListIssueTypesForProject(ctx, projectKey)
+		if err != nil {
+			log.Printf("Failed to list issue types: %v", err)
+			if errors.Is(err, client.ErrNotFound) {
+				return map[string]any{
+					"error":   "not_found",
+					"message": fmt.Sprintf("Project %s not found", projectKey),
+				}
+			}
+			return map[string]any{
+				"error":   client.ErrorCode(err),
+				"message": fmt.Sprintf("Failed to list issue types: %v", err),
+			}
+		}
+
+		result := map[string]any{
+			"result":     "success",
+			"message":    fmt.Sprintf("Successfully retrieved %d issue types for project %s", len(issueTypes), projectKey),
+			"projectKey": projectKey,
+			"issueTypes": issueTypes,
+			"count":      len(issueTypes),
+		}
+		return result
+	})
+}
+
+// ListComponentsHandler handles the projects.components.list action
+func ListComponentsHandler(msg *nats.Msg) {
+	handler.Run(msg, "projects.components.list", func(ctx context.Context, creds *credentials.JiraCredentials, body map[string]any, progress handler.ProgressFunc) map[string]any {
+		// Extract form fields
+		projectKey, _ := body["projectKey"].(string)
+
+		// Validate required fields
+		if projectKey == "" {
+			return map[string]any{
+				"error":   "validation_error",
+				"message": "Project key is required",
+			}
+		}
+
+		// Create Jira client and fetch components
+		jiraClient := client.NewJiraClient(creds)
+		components, err := jiraClient.ListComponents(ctx, projectKey)
+		if err != nil {
+			log.Printf("Failed to list components: %v", err)
+			if errors.Is(err, client.ErrNotFound) {
+				return map[string]any{
+					"error":   "not_found",
+					"message": fmt.Sprintf("Project %s not found", projectKey),
+				}
+			}
+			return map[string]any{
+				"error":   client.ErrorCode(err),
+				"message": fmt.Sprintf("Failed to list components: %v", err),
+			}
+		}
+
+		result := map[string]any{
+			"result":     "success",
+			"message":    fmt.Sprintf("Successfully retrieved %d components for project %s", len(components), projectKey),
+			"projectKey": projectKey,
+			"components": components,
+			"count":      len(components),
+		}
+		return result
+	})
+}
+
+// CreateComponentHandler handles the projects.components.create action
+func CreateComponentHandler(msg *nats.Msg) {
+	handler.Run(msg, "projects.components.create", func(ctx context.Context, creds *credentials.JiraCredentials, body map[string]any, progress handler.ProgressFunc) map[string]any {
+		// Extract form fields
+		projectKey, _ := body["projectKey"].(string)
+		name, _ := body["name"].(string)
+		description, _ := body["description"].(string)
+		leadAccountId, _ := body["leadAccountId"].(string)
+
+		// Validate required fields
+		if projectKey == "" || name == "" {
+			return map[string]any{
+				"error":   "validation_error",
+				"message": "Project key and component name are required",
+			}
+		}
+
+		// Create Jira client and create component
+		jiraClient := client.NewJiraClient(creds)
+		component, err := jiraClient.CreateComponent(ctx, projectKey, name, description, leadAccountId)
+		if err != nil {
+			log.Printf("Failed to create component: %v", err)
+			return map[string]any{
+				"error":   client.ErrorCode(err),
+				"message": fmt.Sprintf("Failed to create component: %v", err),
+			}
+		}
+
+		componentId, _ := component["id"].(string)
+
+		result := map[string]any{
+			"result":      "success",
+			"message":     fmt.Sprintf("Component %s created in project %s", name, projectKey),
+			"componentId": componentId,
+			"component":   component,
+		}
+		return result
+	})
+}
+
+// DeleteComponentHandler handles the projects.components.delete action
+func DeleteComponentHandler(msg *nats.Msg) {
+	handler.Run(msg, "projects.components.delete", func(ctx context.Context, creds *credentials.JiraCredentials, body map[string]any, progress handler.ProgressFunc) map[string]any {
+		// Extract form fields
+		componentId, _ := body["componentId"].(string)
+
+		// Validate required fields
+		if componentId == "" {
+			return map[string]any{
+				"error":   "validation_error",
+				"message": "Component ID is required",
+			}
+		}
+
+		// Create Jira client and delete component
+		jiraClient := client.NewJiraClient(creds)
+		err := jiraClient.DeleteComponent(ctx, componentId)
+		if err != nil {
+			log.Printf("Failed to delete component: %v", err)
+			return map[string]any{
+				"error":   client.ErrorCode(err),
+				"message": fmt.Sprintf("Failed to delete component: %v", err),
+			}
+		}
+
+		result := map[string]any{
+			"result":      "success",
+			"message":     fmt.Sprintf("Component %s deleted successfully", componentId),
+			"componentId": componentId,
+		}
+		return result
+	})
+}
+
+// ListProjectRolesHandler handles the projects.roles action
+func ListProjectRolesHandler(msg *nats.Msg) {
+	handler.Run(msg, "projects.roles", func(ctx context.Context, creds *credentials.JiraCredentials, body map[string]any, progress handler.ProgressFunc) map[string]any {
+		// Extract form fields
+		projectKey, _ := body["projectKey"].(string)
+
+		// Validate required fields
+		if projectKey == "" {
+			return map[string]any{
+				"error":   "validation_error",
+				"message": "Project key is required",
+			}
+		}
+
+		// Create Jira client and fetch the roles
+		jiraClient := client.NewJiraClient(creds)
+		roles, err := jiraClient.ListProjectRoles(ctx, projectKey)
+		if err != nil {
+			log.Printf("Failed to list project roles: %v", err)
+			if errors.Is(err, client.ErrNotFound) {
+				return map[string]any{
+					"error":   "not_found",
+					"message": fmt.Sprintf("Project %s not found", projectKey),
+				}
+			}
+			return map[string]any{
+				"error":   client.ErrorCode(err),
+				"message": fmt.Sprintf("Failed to list project roles: %v", err),
+			}
+		}
+
+		result := map[string]any{
+			"result":     "success",
+			"message":    fmt.Sprintf("Successfully retrieved %d roles for project %s", len(roles), projectKey),
+			"projectKey": projectKey,
+			"roles":      roles,
+			"count":      len(roles),
+		}
+		return result
+	})
+}
+
+// ListRoleActorsHandler handles the projects.role-actors action
+func ListRoleActorsHandler(msg *nats.Msg) {
+	handler.Run(msg, "projects.role-actors", func(ctx context.Context, creds *credentials.JiraCredentials, body map[string]any, progress handler.ProgressFunc) map[string]any {
+		// Extract form fields
+		projectKey, _ := body["projectKey"].(string)
+		roleID, _ := body["roleId"].(string)
+		roleName, _ := body["roleName"].(string)
+		roleID = strings.TrimSpace(roleID)
+		roleName = strings.TrimSpace(roleName)
+
+		// Validate required fields
+		if projectKey == "" {
+			return map[string]any{
+				"error":   "validation_error",
+				"message": "Project key is required",
+			}
+		}
+		if roleID == "" && roleName == "" {
+			return map[string]any{
+				"error":   "validation_error",
+				"message": "Either roleId or roleName is required",
+			}
+		}
+
+		jiraClient := client.NewJiraClient(creds)
+
+		// Resolve a role name (e.g. Administrators) to its ID
+		if roleID == "" {
+			roles, err := jiraClient.ListProjectRoles(ctx, projectKey)
+			if err != nil {
+				log.Printf("Failed to list project roles: %v", err)
+				return map[string]any{
+					"error":   client.ErrorCode(err),
+					"message": fmt.Sprintf("Failed to list project roles: %v", err),
+				}
+			}
+			for _, role := range roles {
+				if name, _ := role["name"].(string); strings.EqualFold(name, roleName) {
+					roleID, _ = role["id"].(string)
+					break
+				}
+			}
+			if roleID == "" {
+				return map[string]any{
+					"error":   "not_found",
+					"message": fmt.Sprintf("Project %s has no role named %q", projectKey, roleName),
+				}
+			}
+		}
+
+		role, err := jiraClient.GetProjectRole(ctx, projectKey, roleID)
+		if err != nil {
+			log.Printf("Failed to get project role: %v", err)
+			return map[string]any{
+				"error":   client.ErrorCode(err),
+				"message": fmt.Sprintf("Failed to get project role: %v", err),
+			}
+		}
+
+		rawActors, _ := role["actors"].([]interface{})
+		actors := make([]map[string]any, 0, len(rawActors))
+		for _, raw := range rawActors {
+			if actor, ok := raw.(map[string]interface{}); ok {
+				actors = append(actors, roleActorSummary(actor))
+			}
+		}
+
+		result := map[string]any{
+			"result":     "success",
+			"message":    fmt.Sprintf("Role %v of project %s has %d actors", role["name"], projectKey, len(actors)),
+			"projectKey": projectKey,
+			"role": map[string]any{
+				"id":          role["id"],
+				"name":        role["name"],
+				"description": role["description"],
+			},
+			"actors": actors,
+			"count":  len(actors),
+		}
+		return result
+	})
+}
+
+// roleActorSummary flattens a role actor to its type (user or group), ID and display name.
+// Cloud nests the IDs in actorUser/actorGroup; Server/Data Center puts the username or
+// group name in the actor's name.
+func roleActorSummary(actor map[string]interface{}) map[string]any {
+	summary := map[string]any{
+		"displayName": actor["displayName"],
+	}
+	actorType, _ := actor["type"].(string)
+	if strings.Contains(actorType, "group") {
+		summary["type"] = "group"
+		summary["name"] = actor["name"]
+		summary["id"] = actor["name"]
+		if group, ok := actor["actorGroup"].(map[string]interface{}); ok {
+			if group["name"] != nil {
+				summary["name"] = group["name"]
+			}
+			if group["groupId"] != nil {
+				summary["id"] = group["groupId"]
+			}
+		}
+		return summary
+	}
+
+	summary["type"] = "user"
+	summary["id"] = actor["name"]
+	if user, ok := actor["actorUser"].(map[string]interface{}); ok && user["accountId"] != nil {
+		summary["id"] = user["accountId"]
+	}
+	return summary
+}
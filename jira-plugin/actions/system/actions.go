@@ -0,0 +1,140 @@
+package system
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	sdkv2Models "github.com/sorenhq/go-plugin-sdk/gosdk/models"
+
+	"github.com/sorenhq/jira-plugin/actions/handler"
+	"github.com/sorenhq/jira-plugin/client"
+	"github.com/sorenhq/jira-plugin/credentials"
+)
+
+// GetActions returns all system (monitoring) actions
+func GetActions() []sdkv2Models.Action {
+	return []sdkv2Models.Action{
+		{
+			Method:      "system.ping",
+			Title:       "Ping",
+			Description: "Check that the Jira integration for this space is working (read-only)",
+			Form: sdkv2Models.ActionFormBuilder{
+				Jsonui:     map[string]any{},
+				Jsonschema: map[string]any{"type": "object", "properties": map[string]any{}},
+			},
+			RequestHandler: PingHandler,
+		},
+		{
+			Method:      "system.info",
+			Title:       "Server Info",
+			Description: "Get the Jira version, deployment type (Cloud or Server/Data Center), build number and server time",
+			Form: sdkv2Models.ActionFormBuilder{
+				Jsonui: map[string]any{
+					"type": "VerticalLayout",
+					"elements": []map[string]any{
+						{
+							"type":  "Control",
+							"scope": "#/properties/refresh",
+						},
+					},
+				},
+				Jsonschema: map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"refresh": map[string]any{
+							"type":        "boolean",
+							"title":       "Refresh",
+							"description": "Bypass the metadata cache and fetch fresh data from Jira",
+							"default":     false,
+						},
+					},
+				},
+			},
+			RequestHandler: InfoHandler,
+		},
+	}
+}
+
+// PingHandler handles the system.ping action
+func PingHandler(msg *nats.Msg) {
+	handler.Run(msg, "system.ping", func(ctx context.Context, creds *credentials.JiraCredentials, body map[string]any, progress handler.ProgressFunc) map[string]any {
+		// Fetch the authenticated user and time the round trip
+		jiraClient := client.NewJiraClient(creds)
+		start := time.Now()
+		user, err := jiraClient.TestConnection(ctx)
+		latencyMs := time.Since(start).Milliseconds()
+		if err != nil {
+			log.Printf("Ping failed after %dms: %v", latencyMs, err)
+			return map[string]any{
+				"status":      "error",
+				"error":       client.ErrorCode(err),
+				"message":     fmt.Sprintf("Failed to reach Jira: %v", err),
+				"instanceUrl": creds.InstanceURL,
+				"latencyMs":   latencyMs,
+			}
+		}
+
+		displayName, _ := user["displayName"].(string)
+		log.Printf("Ping succeeded in %dms (connected as %s)", latencyMs, displayName)
+
+		// Record the successful check; a failure to store it doesn't fail the ping
+		if err := credentials.GetCredentialsStorage().MarkValidated(creds, time.Now().UTC()); err != nil {
+			log.Printf("Failed to record credential validation: %v", err)
+		}
+
+		result := map[string]any{
+			"result":      "success",
+			"status":      "ok",
+			"message":     fmt.Sprintf("Connected to Jira as %s in %dms", displayName, latencyMs),
+			"user":        displayName,
+			"accountId":   user["accountId"],
+			"instanceUrl": creds.InstanceURL,
+			"latencyMs":   latencyMs,
+		}
+		return result
+	})
+}
+
+// InfoHandler handles the system.info action
+func InfoHandler(msg *nats.Msg) {
+	handler.Run(msg, "system.info", func(ctx context.Context, creds *credentials.JiraCredentials, body map[string]any, progress handler.ProgressFunc) map[string]any {
+		// Create Jira client and fetch the server info (cached unless a refresh is requested)
+		jiraClient := client.NewJiraClient(creds)
+		if handler.BoolValue(body, "refresh") {
+			jiraClient.InvalidateCache()
+		}
+		info, err := jiraClient.GetServerInfo(ctx)
+		if err != nil {
+			log.Printf("Failed to get server info: %v", err)
+			return map[string]any{
+				"error":   client.ErrorCode(err),
+				"message": fmt.Sprintf("Failed to get server info: %v", err),
+			}
+		}
+
+		// Only Cloud reports "Cloud"; Server and Data Center report "Server" or "DataCenter"
+		deploymentType, _ := info["deploymentType"].(string)
+		cloud := strings.EqualFold(deploymentType, "Cloud")
+		version, _ := info["version"].(string)
+
+		result := map[string]any{
+			"result":         "success",
+			"message":        fmt.Sprintf("Jira %s (%s)", version, deploymentType),
+			"version":        version,
+			"versionNumbers": info["versionNumbers"],
+			"deploymentType": deploymentType,
+			"cloud":          cloud,
+			"buildNumber":    info["buildNumber"],
+			"buildDate":      info["buildDate"],
+			"serverTime":     info["serverTime"],
+			"serverTitle":    info["serverTitle"],
+			"baseUrl":        info["baseUrl"],
+			"apiVersion":     jiraClient.APIVersion,
+		}
+		return result
+	})
+}
@@ -0,0 +1,432 @@
+package users
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/nats-io/nats.go"
+	sdkv2Models "github.com/sorenhq/go-plugin-sdk/gosdk/models"
+
+	"github.com/sorenhq/jira-plugin/actions/handler"
+	"github.com/sorenhq/jira-plugin/client"
+	"github.com/sorenhq/jira-plugin/credentials"
+)
+
+// maxUserSearchResults caps the number of users a single users.search returns
+const maxUserSearchResults = 100
+
+// maxGroupSearchResults caps the number of groups a single groups.list returns
+const maxGroupSearchResults = 100
+
+// GetActions returns all user-related actions
+func GetActions() []sdkv2Models.Action {
+	return []sdkv2Models.Action{
+		{
+			Method:      "users.search",
+			Title:       "Search Users",
+			Description: "Find users by name or email to get their account ID",
+			Form: sdkv2Models.ActionFormBuilder{
+				Jsonui: map[string]any{
+					"type": "VerticalLayout",
+					"elements": []map[string]any{
+						{
+							"type":  "Control",
+							"scope": "#/properties/query",
+						},
+						{
+							"type":  "Control",
+							"scope": "#/properties/maxResults",
+						},
+					},
+				},
+				Jsonschema: map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"query": map[string]any{
+							"type":        "string",
+							"title":       "Query",
+							"description": "Name, username or email address to search for",
+						},
+						"maxResults": map[string]any{
+							"type":        "integer",
+							"title":       "Max Results",
+							"description": "Maximum number of users to return (at most 100)",
+							"default":     50,
+						},
+					},
+					"required": []string{"query"},
+				},
+			},
+			RequestHandler: SearchUsersHandler,
+		},
+		{
+			Method:      "users.current",
+			Title:       "Current User",
+			Description: "Get the Jira user the space is connected as",
+			Form: sdkv2Models.ActionFormBuilder{
+				Jsonui:     map[string]any{},
+				Jsonschema: map[string]any{"type": "object", "properties": map[string]any{}},
+			},
+			RequestHandler: GetCurrentUserHandler,
+		},
+		{
+			Method:      "users.assignable",
+			Title:       "Find Assignable Users",
+			Description: "Find users who can be assigned issues in a project",
+			Form: sdkv2Models.ActionFormBuilder{
+				Jsonui: map[string]any{
+					"type": "VerticalLayout",
+					"elements": []map[string]any{
+						{
+							"type":  "Control",
+							"scope": "#/properties/projectKey",
+						},
+						{
+							"type":  "Control",
+							"scope": "#/properties/query",
+						},
+						{
+							"type":  "Control",
+							"scope": "#/properties/maxResults",
+						},
+					},
+				},
+				Jsonschema: map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"projectKey": map[string]any{
+							"type":        "string",
+							"title":       "Project Key",
+							"description": "The project key (e.g., PROJ)",
+						},
+						"query": map[string]any{
+							"type":        "string",
+							"title":       "Query",
+							"description": "Optional name, username or email address to filter by",
+						},
+						"maxResults": map[string]any{
+							"type":        "integer",
+							"title":       "Max Results",
+							"description": "Maximum number of users to return (at most 100)",
+							"default":     50,
+						},
+					},
+					"required": []string{"projectKey"},
+				},
+			},
+			RequestHandler: FindAssignableUsersHandler,
+		},
+		{
+			Method:      "groups.list",
+			Title:       "List Groups",
+			Description: "Find Jira groups by name, e.g. for comment visibility or assigning to group members",
+			Form: sdkv2Models.ActionFormBuilder{
+				Jsonui: map[string]any{
+					"type": "VerticalLayout",
+					"elements": []map[string]any{
+						{
+							"type":  "Control",
+							"scope": "#/properties/query",
+						},
+						{
+							"type":  "Control",
+							"scope": "#/properties/maxResults",
+						},
+					},
+				},
+				Jsonschema: map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"query": map[string]any{
+							"type":        "string",
+							"title":       "Query (Optional)",
+							"description": "Part of the group name. Leave empty to list groups",
+						},
+						"maxResults": map[string]any{
+							"type":        "integer",
+							"title":       "Max Results",
+							"description": "Maximum number of groups to return (at most 100)",
+							"default":     50,
+						},
+					},
+				},
+			},
+			RequestHandler: ListGroupsHandler,
+		},
+
+		{
+			Method:      "groups.members",
+			Title:       "List Group Members",
+			Description: "List the members of a Jira group (up to 1000)",
+			Form: sdkv2Models.ActionFormBuilder{
+				Jsonui: map[string]any{
+					"type": "VerticalLayout",
+					"elements": []map[string]any{
+						{
+							"type":  "Control",
+							"scope": "#/properties/groupName",
+						},
+						{
+							"type":  "Control",
+							"scope": "#/properties/includeInactive",
+						},
+					},
+				},
+				Jsonschema: map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"groupName": map[string]any{
+							"type":        "string",
+							"title":       "Group Name",
+							"description": "The group name (e.g., jira-developers)",
+						},
+						"includeInactive": map[string]any{
+							"type":        "boolean",
+							"title":       "Include Inactive Users",
+							"description": "Also list deactivated users",
+							"default":     false,
+						},
+					},
+					"required": []string{"groupName"},
+				},
+			},
+			RequestHandler: ListGroupMembersHandler,
+		},
+	}
+}
+
+// summarizeUser picks the identifying fields of a Jira user
+func summarizeUser(user map[string]interface{}) map[string]any {
+	return map[string]any{
+		"accountId":    user["accountId"],
+		"name":         user["name"],
+		"displayName":  user["displayName"],
+		"emailAddress": user["emailAddress"],
+		"active":       user["active"],
+	}
+}
+
+// SearchUsersHandler handles the users.search action
+func SearchUsersHandler(msg *nats.Msg) {
+	handler.Run(msg, "users.search", func(ctx context.Context, creds *credentials.JiraCredentials, body map[string]any, progress handler.ProgressFunc) map[string]any {
+		// Extract form fields
+		query, _ := body["query"].(string)
+		query = strings.TrimSpace(query)
+		maxResults := handler.IntValue(body, "maxResults", 50)
+
+		// Validate required fields
+		if query == "" {
+			return map[string]any{
+				"error":   "validation_error",
+				"message": "Search query is required",
+			}
+		}
+		if maxResults <= 0 {
+			maxResults = 50
+		}
+		if maxResults > maxUserSearchResults {
+			maxResults = maxUserSearchResults
+		}
+
+		// Create Jira client and search users
+		jiraClient := client.NewJiraClient(creds)
+		users, err := jiraClient.SearchUsers(ctx, query, maxResults)
+		if err != nil {
+			log.Printf("Failed to search users: %v", err)
+			return map[string]any{
+				"error":   client.ErrorCode(err),
+				"message": fmt.Sprintf("Failed to search users: %v", err),
+			}
+		}
+
+		results := make([]map[string]any, 0, len(users))
+		for _, user := range users {
+			results = append(results, summarizeUser(user))
+		}
+
+		result := map[string]any{
+			"result":  "success",
+			"message": fmt.Sprintf("Found %d users matching %q", len(results), query),
+			"users":   results,
+			"count":   len(results),
+		}
+		return result
+	})
+}
+
+// GetCurrentUserHandler handles the users.current action
+func GetCurrentUserHandler(msg *nats.Msg) {
+	handler.Run(msg, "users.current", func(ctx context.Context, creds *credentials.JiraCredentials, body map[string]any, progress handler.ProgressFunc) map[string]any {
+		// Create Jira client and fetch the authenticated user
+		jiraClient := client.NewJiraClient(creds)
+		user, err := jiraClient.GetCurrentUser(ctx)
+		if err != nil {
+			log.Printf("Failed to get current user: %v", err)
+			return map[string]any{
+				"error":   client.ErrorCode(err),
+				"message": fmt.Sprintf("Failed to get current user: %v", err),
+			}
+		}
+
+		result := summarizeUser(user)
+		result["result"] = "success"
+		result["message"] = fmt.Sprintf("Connected to Jira as %v", user["displayName"])
+		return result
+	})
+}
+
+// FindAssignableUsersHandler handles the users.assignable action
+func FindAssignableUsersHandler(msg *nats.Msg) {
+	handler.Run(msg, "users.assignable", func(ctx context.Context, creds *credentials.JiraCredentials, body map[string]any, progress handler.ProgressFunc) map[string]any {
+		// Extract form fields
+		projectKey, _ := body["projectKey"].(string)
+		query, _ := body["query"].(string)
+		query = strings.TrimSpace(query)
+		maxResults := handler.IntValue(body, "maxResults", 50)
+
+		// Validate required fields
+		if projectKey == "" {
+			return map[string]any{
+				"error":   "validation_error",
+				"message": "Project key is required",
+			}
+		}
+		if maxResults <= 0 {
+			maxResults = 50
+		}
+		if maxResults > maxUserSearchResults {
+			maxResults = maxUserSearchResults
+		}
+
+		// Create Jira client and search assignable users
+		jiraClient := client.NewJiraClient(creds)
+		users, err := jiraClient.FindAssignableUsers(ctx, projectKey, query, maxResults)
+		if err != nil {
+			log.Printf("Failed to find assignable users: %v", err)
+			if errors.Is(err, client.ErrNotFound) {
+				return map[string]any{
+					"error":   "not_found",
+					"message": fmt.Sprintf("Project %s not found", projectKey),
+				}
+			}
+			return map[string]any{
+				"error":   client.ErrorCode(err),
+				"message": fmt.Sprintf("Failed to find assignable users: %v", err),
+			}
+		}
+
+		results := make([]map[string]any, 0, len(users))
+		for _, user := range users {
+			results = append(results, summarizeUser(user))
+		}
+
+		result := map[string]any{
+			"result":     "success",
+			"message":    fmt.Sprintf("Found %d assignable users in project %s", len(results), projectKey),
+			"projectKey": projectKey,
+			"users":      results,
+			"count":      len(results),
+		}
+		return result
+	})
+}
+
+// ListGroupsHandler handles the groups.list action
+func ListGroupsHandler(msg *nats.Msg) {
+	handler.Run(msg, "groups.list", func(ctx context.Context, creds *credentials.JiraCredentials, body map[string]any, progress handler.ProgressFunc) map[string]any {
+		// Extract form fields
+		query, _ := body["query"].(string)
+		query = strings.TrimSpace(query)
+		maxResults := handler.IntValue(body, "maxResults", 50)
+		if maxResults <= 0 {
+			maxResults = 50
+		}
+		if maxResults > maxGroupSearchResults {
+			maxResults = maxGroupSearchResults
+		}
+
+		// Create Jira client and search groups
+		jiraClient := client.NewJiraClient(creds)
+		groups, err := jiraClient.SearchGroups(ctx, query, maxResults)
+		if err != nil {
+			log.Printf("Failed to list groups: %v", err)
+			return map[string]any{
+				"error":   client.ErrorCode(err),
+				"message": fmt.Sprintf("Failed to list groups: %v", err),
+			}
+		}
+
+		results := make([]map[string]any, 0, len(groups))
+		for _, group := range groups {
+			results = append(results, map[string]any{
+				"name":    group["name"],
+				"groupId": group["groupId"],
+			})
+		}
+
+		result := map[string]any{
+			"result":  "success",
+			"message": fmt.Sprintf("Found %d groups", len(results)),
+			"groups":  results,
+			"count":   len(results),
+		}
+		return result
+	})
+}
+
+// ListGroupMembersHandler handles the groups.members action
+func ListGroupMembersHandler(msg *nats.Msg) {
+	handler.Run(msg, "groups.members", func(ctx context.Context, creds *credentials.JiraCredentials, body map[string]any, progress handler.ProgressFunc) map[string]any {
+		// Extract form fields
+		groupName, _ := body["groupName"].(string)
+		groupName = strings.TrimSpace(groupName)
+		includeInactive := handler.BoolValue(body, "includeInactive")
+
+		// Validate required fields
+		if groupName == "" {
+			return map[string]any{
+				"error":   "validation_error",
+				"message": "Group name is required",
+			}
+		}
+
+		// Create Jira client and collect every page of members
+		jiraClient := client.NewJiraClient(creds)
+		members, truncated, err := jiraClient.GetGroupMembers(ctx, groupName, includeInactive)
+		if err != nil {
+			log.Printf("Failed to list group members: %v", err)
+			if errors.Is(err, client.ErrNotFound) {
+				return map[string]any{
+					"error":   "not_found",
+					"message": fmt.Sprintf("Group %s not found", groupName),
+				}
+			}
+			return map[string]any{
+				"error":   client.ErrorCode(err),
+				"message": fmt.Sprintf("Failed to list group members: %v", err),
+			}
+		}
+
+		results := make([]map[string]any, 0, len(members))
+		for _, member := range members {
+			results = append(results, summarizeUser(member))
+		}
+
+		message := fmt.Sprintf("Group %s has %d members", groupName, len(results))
+		if truncated {
+			message = fmt.Sprintf("Showing the first %d members of group %s", len(results), groupName)
+		}
+		result := map[string]any{
+			"result":    "success",
+			"message":   message,
+			"groupName": groupName,
+			"members":   results,
+			"count":     len(results),
+			"truncated": truncated,
+		}
+		return result
+	})
+}
@@ -0,0 +1,311 @@
+package versions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	sdkv2Models "github.com/sorenhq/go-plugin-sdk/gosdk/models"
+
+	"github.com/sorenhq/jira-plugin/actions/handler"
+	"github.com/sorenhq/jira-plugin/client"
+	"github.com/sorenhq/jira-plugin/credentials"
+)
+
+// GetActions returns all version-related actions
+func GetActions() []sdkv2Models.Action {
+	return []sdkv2Models.Action{
+		{
+			Method:      "versions.list",
+			Title:       "List Versions",
+			Description: "List the versions (fix versions) of a project",
+			Form: sdkv2Models.ActionFormBuilder{
+				Jsonui: map[string]any{
+					"type": "VerticalLayout",
+					"elements": []map[string]any{
+						{
+							"type":  "Control",
+							"scope": "#/properties/projectKey",
+						},
+					},
+				},
+				Jsonschema: map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"projectKey": map[string]any{
+							"type":        "string",
+							"title":       "Project Key",
+							"description": "The project key (e.g., PROJ)",
+						},
+					},
+					"required": []string{"projectKey"},
+				},
+			},
+			RequestHandler: ListVersionsHandler,
+		},
+		{
+			Method:      "versions.create",
+			Title:       "Create Version",
+			Description: "Create a version in a project",
+			Form: sdkv2Models.ActionFormBuilder{
+				Jsonui: map[string]any{
+					"type": "VerticalLayout",
+					"elements": []map[string]any{
+						{
+							"type":  "Control",
+							"scope": "#/properties/projectKey",
+						},
+						{
+							"type":  "Control",
+							"scope": "#/properties/name",
+						},
+						{
+							"type":  "Control",
+							"scope": "#/properties/description",
+						},
+						{
+							"type":  "Control",
+							"scope": "#/properties/releaseDate",
+						},
+					},
+				},
+				Jsonschema: map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"projectKey": map[string]any{
+							"type":        "string",
+							"title":       "Project Key",
+							"description": "The key of the project to create the version in (e.g., PROJ)",
+						},
+						"name": map[string]any{
+							"type":        "string",
+							"title":       "Name",
+							"description": "The version name (e.g., 1.2.0)",
+						},
+						"description": map[string]any{
+							"type":        "string",
+							"title":       "Description",
+							"description": "Optional version description",
+							"format":      "textarea",
+						},
+						"releaseDate": map[string]any{
+							"type":        "string",
+							"title":       "Release Date",
+							"description": "Optional planned release date (YYYY-MM-DD)",
+							"format":      "date",
+						},
+					},
+					"required": []string{"projectKey", "name"},
+				},
+			},
+			RequestHandler: CreateVersionHandler,
+		},
+		{
+			Method:      "versions.release",
+			Title:       "Release Version",
+			Description: "Mark a version as released (or unreleased)",
+			Form: sdkv2Models.ActionFormBuilder{
+				Jsonui: map[string]any{
+					"type": "VerticalLayout",
+					"elements": []map[string]any{
+						{
+							"type":  "Control",
+							"scope": "#/properties/versionId",
+						},
+						{
+							"type":  "Control",
+							"scope": "#/properties/released",
+						},
+						{
+							"type":  "Control",
+							"scope": "#/properties/releaseDate",
+						},
+					},
+				},
+				Jsonschema: map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"versionId": map[string]any{
+							"type":        "string",
+							"title":       "Version ID",
+							"description": "The ID of the version",
+						},
+						"released": map[string]any{
+							"type":        "boolean",
+							"title":       "Released",
+							"description": "Whether the version is released; uncheck to mark it unreleased",
+							"default":     true,
+						},
+						"releaseDate": map[string]any{
+							"type":        "string",
+							"title":       "Release Date",
+							"description": "Optional release date (YYYY-MM-DD)",
+							"format":      "date",
+						},
+					},
+					"required": []string{"versionId"},
+				},
+			},
+			RequestHandler: ReleaseVersionHandler,
+		},
+	}
+}
+
+// validDate reports whether s is empty or a YYYY-MM-DD date
+func validDate(s string) bool {
+	if s == "" {
+		return true
+	}
+	_, err := time.Parse("2006-01-02", s)
+	return err == nil
+}
+
+// ListVersionsHandler handles the versions.list action
+func ListVersionsHandler(msg *nats.Msg) {
+	handler.Run(msg, "versions.list", func(ctx context.Context, creds *credentials.JiraCredentials, body map[string]any, progress handler.ProgressFunc) map[string]any {
+		// Extract form fields
+		projectKey, _ := body["projectKey"].(string)
+
+		// Validate required fields
+		if projectKey == "" {
+			return map[string]any{
+				"error":   "validation_error",
+				"message": "Project key is required",
+			}
+		}
+
+		// Create Jira client and fetch versions
+		jiraClient := client.NewJiraClient(creds)
+		versions, err := jiraClient.ListVersions(ctx, projectKey)
+		if err != nil {
+			log.Printf("Failed to list versions: %v", err)
+			if errors.Is(err, client.ErrNotFound) {
+				return map[string]any{
+					"error":   "not_found",
+					"message": fmt.Sprintf("Project %s not found", projectKey),
+				}
+			}
+			return map[string]any{
+				"error":   client.ErrorCode(err),
+				"message": fmt.Sprintf("Failed to list versions: %v", err),
+			}
+		}
+
+		result := map[string]any{
+			"result":     "success",
+			"message":    fmt.Sprintf("Successfully retrieved %d versions for project %s", len(versions), projectKey),
+			"projectKey": projectKey,
+			"versions":   versions,
+			"count":      len(versions),
+		}
+		return result
+	})
+}
+
+// CreateVersionHandler handles the versions.create action
+func CreateVersionHandler(msg *nats.Msg) {
+	handler.Run(msg, "versions.create", func(ctx context.Context, creds *credentials.JiraCredentials, body map[string]any, progress handler.ProgressFunc) map[string]any {
+		// Extract form fields
+		projectKey, _ := body["projectKey"].(string)
+		name, _ := body["name"].(string)
+		description, _ := body["description"].(string)
+		releaseDate, _ := body["releaseDate"].(string)
+
+		// Validate required fields
+		if projectKey == "" || name == "" {
+			return map[string]any{
+				"error":   "validation_error",
+				"message": "Project key and version name are required",
+			}
+		}
+		if !validDate(releaseDate) {
+			return map[string]any{
+				"error":   "validation_error",
+				"message": "Release date must be in YYYY-MM-DD format",
+			}
+		}
+
+		// Create Jira client and create version
+		jiraClient := client.NewJiraClient(creds)
+		version, err := jiraClient.CreateVersion(ctx, projectKey, name, description, releaseDate)
+		if err != nil {
+			log.Printf("Failed to create version: %v", err)
+			return map[string]any{
+				"error":   client.ErrorCode(err),
+				"message": fmt.Sprintf("Failed to create version: %v", err),
+			}
+		}
+
+		versionId, _ := version["id"].(string)
+
+		result := map[string]any{
+			"result":    "success",
+			"message":   fmt.Sprintf("Version %s created in project %s", name, projectKey),
+			"versionId": versionId,
+			"version":   version,
+		}
+		return result
+	})
+}
+
+// ReleaseVersionHandler handles the versions.release action
+func ReleaseVersionHandler(msg *nats.Msg) {
+	handler.Run(msg, "versions.release", func(ctx context.Context, creds *credentials.JiraCredentials, body map[string]any, progress handler.ProgressFunc) map[string]any {
+		// Extract form fields
+		versionId, _ := body["versionId"].(string)
+		releaseDate, _ := body["releaseDate"].(string)
+		// Releasing is the default when the checkbox isn't sent
+		released := true
+		if _, ok := body["released"]; ok {
+			released = handler.BoolValue(body, "released")
+		}
+
+		// Validate required fields
+		if versionId == "" {
+			return map[string]any{
+				"error":   "validation_error",
+				"message": "Version ID is required",
+			}
+		}
+		if !validDate(releaseDate) {
+			return map[string]any{
+				"error":   "validation_error",
+				"message": "Release date must be in YYYY-MM-DD format",
+			}
+		}
+
+		// Create Jira client and update the version
+		jiraClient := client.NewJiraClient(creds)
+		version, err := jiraClient.ReleaseVersion(ctx, versionId, released, releaseDate)
+		if err != nil {
+			log.Printf("Failed to release version: %v", err)
+			if errors.Is(err, client.ErrNotFound) {
+				return map[string]any{
+					"error":   "not_found",
+					"message": fmt.Sprintf("Version %s not found", versionId),
+				}
+			}
+			return map[string]any{
+				"error":   client.ErrorCode(err),
+				"message": fmt.Sprintf("Failed to release version: %v", err),
+			}
+		}
+
+		message := fmt.Sprintf("Version %s released", versionId)
+		if !released {
+			message = fmt.Sprintf("Version %s marked as unreleased", versionId)
+		}
+
+		result := map[string]any{
+			"result":    "success",
+			"message":   message,
+			"versionId": versionId,
+			"released":  released,
+			"version":   version,
+		}
+		return result
+	})
+}
@@ -0,0 +1,108 @@
+package client
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultCacheTTL is how long project, field and issue type metadata is reused
+const DefaultCacheTTL = 5 * time.Minute
+
+// metadataCache is a small in-memory TTL cache for Jira metadata that rarely changes.
+// It is shared by all clients (which are created per action), so entries are keyed
+// by instance, user and credentials profile; see JiraClient.cacheKey.
+type metadataCache struct {
+	mu      sync.RWMutex
+	entries map[string]cacheEntry
+}
+
+type cacheEntry struct {
+	value     interface{}
+	expiresAt time.Time
+}
+
+// sharedMetadataCache is the cache used by every JiraClient
+var sharedMetadataCache = &metadataCache{entries: make(map[string]cacheEntry)}
+
+// get returns the cached value for key if it exists and has not expired
+func (c *metadataCache) get(key string) (interface{}, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	entry, ok := c.entries[key]
+	if !ok || time.Now().After(entry.expiresAt) {
+		return nil, false
+	}
+	return entry.value, true
+}
+
+// set stores value under key for ttl
+func (c *metadataCache) set(key string, value interface{}, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	// Drop expired entries while we hold the lock so the map doesn't grow forever
+	now := time.Now()
+	for k, entry := range c.entries {
+		if now.After(entry.expiresAt) {
+			delete(c.entries, k)
+		}
+	}
+	c.entries[key] = cacheEntry{value: value, expiresAt: now.Add(ttl)}
+}
+
+// deletePrefix removes every entry whose key starts with prefix
+func (c *metadataCache) deletePrefix(prefix string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for k := range c.entries {
+		if strings.HasPrefix(k, prefix) {
+			delete(c.entries, k)
+		}
+	}
+}
+
+// WithCacheTTL overrides how long metadata (projects, fields, issue types) is cached.
+// A zero or negative TTL disables caching for the client.
+func WithCacheTTL(ttl time.Duration) ClientOption {
+	return func(jc *JiraClient) {
+		jc.CacheTTL = ttl
+	}
+}
+
+// cacheKey scopes a cache entry to the client's instance, user, credentials profile and
+// API version, since what a user can see depends on their permissions. The profile
+// matters for OAuth, whose profiles may share a cloud ID without a stored email.
+func (jc *JiraClient) cacheKey(name string) string {
+	return jc.cachePrefix() + name
+}
+
+func (jc *JiraClient) cachePrefix() string {
+	return jc.baseURL() + "|" + jc.Email + "|" + jc.credentialsKey + "|" + jc.APIVersion + "|"
+}
+
+// InvalidateCache drops all cached metadata for the client's instance and user,
+// so the next lookup fetches fresh data from Jira
+func (jc *JiraClient) InvalidateCache() {
+	sharedMetadataCache.deletePrefix(jc.cachePrefix())
+}
+
+// cachedLookup returns the cached value for name, or calls fetch and caches its result
+func cachedLookup[T any](jc *JiraClient, name string, fetch func() (T, error)) (T, error) {
+	if jc.CacheTTL <= 0 {
+		return fetch()
+	}
+
+	key := jc.cacheKey(name)
+	if cached, ok := sharedMetadataCache.get(key); ok {
+		if value, ok := cached.(T); ok {
+			return value, nil
+		}
+	}
+
+	value, err := fetch()
+	if err != nil {
+		return value, err
+	}
+	sharedMetadataCache.set(key, value, jc.CacheTTL)
+	return value, nil
+}
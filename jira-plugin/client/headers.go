@@ -0,0 +1,87 @@
+package client
+
+import (
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/bytedance/sonic"
+)
+
+// extraHeadersEnv holds a JSON object of headers added to every Jira request, e.g.
+// {"X-Api-Gateway-Key": "..."} for instances behind an auth gateway
+const extraHeadersEnv = "SOREN_JIRA_EXTRA_HEADERS"
+
+// extraHeadersOverrideEnv lets the extra headers from the environment replace the
+// protected headers the client sets itself
+const extraHeadersOverrideEnv = "SOREN_JIRA_EXTRA_HEADERS_OVERRIDE"
+
+// protectedHeaders are set by the client on every request. Extra headers only replace
+// them when OverrideHeaders is set.
+var protectedHeaders = map[string]bool{
+	"Authorization": true,
+	"Content-Type":  true,
+	"Accept":        true,
+}
+
+var (
+	// envHeadersOnce parses the environment on first use, after env.plugin is loaded
+	envHeadersOnce     sync.Once
+	envHeaders         map[string]string
+	envHeadersOverride bool
+)
+
+// extraHeadersFromEnv returns the headers configured in SOREN_JIRA_EXTRA_HEADERS and
+// whether they may override protected headers. Invalid JSON is logged and ignored.
+func extraHeadersFromEnv() (map[string]string, bool) {
+	envHeadersOnce.Do(func() {
+		raw := strings.TrimSpace(os.Getenv(extraHeadersEnv))
+		if raw == "" {
+			return
+		}
+		var headers map[string]string
+		if err := sonic.Unmarshal([]byte(raw), &headers); err != nil {
+			log.Printf("Error: ignoring %s: must be a JSON object of header names to string values: %v", extraHeadersEnv, err)
+			return
+		}
+		envHeaders = headers
+		envHeadersOverride, _ = strconv.ParseBool(os.Getenv(extraHeadersOverrideEnv))
+
+		// Only the names are logged: the values are often gateway secrets
+		names := make([]string, 0, len(headers))
+		for name := range headers {
+			names = append(names, http.CanonicalHeaderKey(name))
+		}
+		log.Printf("Adding %d extra headers to Jira requests: %s", len(names), strings.Join(names, ", "))
+	})
+	return envHeaders, envHeadersOverride
+}
+
+// WithExtraHeaders adds headers to every request of the client, on top of (and
+// replacing same-named) headers from SOREN_JIRA_EXTRA_HEADERS. Authorization,
+// Content-Type and Accept are only replaced if override is true.
+func WithExtraHeaders(headers map[string]string, override bool) ClientOption {
+	return func(jc *JiraClient) {
+		if jc.ExtraHeaders == nil {
+			jc.ExtraHeaders = make(map[string]string, len(headers))
+		}
+		for name, value := range headers {
+			jc.ExtraHeaders[name] = value
+		}
+		jc.OverrideHeaders = override
+	}
+}
+
+// applyExtraHeaders sets the client's extra headers on a request, skipping protected
+// headers unless OverrideHeaders is set
+func (jc *JiraClient) applyExtraHeaders(req *http.Request) {
+	for name, value := range jc.ExtraHeaders {
+		if protectedHeaders[http.CanonicalHeaderKey(name)] && !jc.OverrideHeaders {
+			continue
+		}
+		req.Header.Set(name, value)
+	}
+}
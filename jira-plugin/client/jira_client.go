@@ -2,128 +2,3946 @@ package client
 
 import (
 	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
 	"fmt"
 	"io"
 	"log"
+	"maps"
+	"math/rand/v2"
+	"mime/multipart"
 	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
+	"unicode"
 
 	"github.com/bytedance/sonic"
 
 	"github.com/sorenhq/jira-plugin/credentials"
 )
 
+// ErrNotFound is returned when the requested Jira resource does not exist
+var ErrNotFound = errors.New("not found")
+
+// ErrUnauthorized is returned when Jira rejects the credentials (HTTP 401)
+var ErrUnauthorized = errors.New("unauthorized")
+
+// ErrForbidden is returned when the credentials lack permission for the request (HTTP 403)
+var ErrForbidden = errors.New("forbidden")
+
+// ErrTransitionUnavailable is returned when no workflow transition available for an
+// issue leads to the requested status
+var ErrTransitionUnavailable = errors.New("transition not available")
+
+// ErrVotingDisabled is returned by the vote methods when voting is turned off on the instance
+var ErrVotingDisabled = errors.New("voting is disabled on this Jira instance")
+
+// JiraAPIError is returned when Jira responds with an unexpected non-2xx status.
+// It matches ErrNotFound, ErrUnauthorized and ErrForbidden with errors.Is based on StatusCode.
+type JiraAPIError struct {
+	// StatusCode is the HTTP status code of the response
+	StatusCode int
+	// ErrorMessages are the general error messages from the response body
+	ErrorMessages []string
+	// Errors maps field names to field-specific error messages
+	Errors map[string]string
+
+	fieldErrorsLabel string
+	rawBody          string
+}
+
+func (e *JiraAPIError) Error() string {
+	// Build a user-friendly error message
+	var errorParts []string
+
+	// Add error messages
+	errorParts = append(errorParts, e.ErrorMessages...)
+
+	// Add field-specific errors
+	if len(e.Errors) > 0 {
+		label := e.fieldErrorsLabel
+		if label == "" {
+			label = "Errors"
+		}
+		fieldErrors := []string{}
+		for field, msg := range e.Errors {
+			fieldErrors = append(fieldErrors, fmt.Sprintf("%s: %s", field, msg))
+		}
+		errorParts = append(errorParts, fmt.Sprintf("%s: %s", label, strings.Join(fieldErrors, "; ")))
+	}
+
+	if len(errorParts) > 0 {
+		return fmt.Sprintf("Jira API error (status %d): %s", e.StatusCode, strings.Join(errorParts, ". "))
+	}
+
+	// Fallback to raw error message
+	return fmt.Sprintf("Jira API error (status %d): %s", e.StatusCode, e.rawBody)
+}
+
+// Is lets errors.Is match a JiraAPIError against the sentinel errors by status code
+func (e *JiraAPIError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrForbidden:
+		return e.StatusCode == http.StatusForbidden
+	}
+	return false
+}
+
+// RateLimitError is returned when Jira keeps responding with 429 Too Many Requests
+// after all rate limit retries are exhausted
+type RateLimitError struct {
+	// RetryAfter is the delay Jira asked for in its last response, if any
+	RetryAfter time.Duration
+	// Retries is the number of retries made before giving up
+	Retries int
+}
+
+func (e *RateLimitError) Error() string {
+	if e.RetryAfter > 0 {
+		return fmt.Sprintf("Jira API rate limit exceeded (status 429) after %d retries, retry after %v", e.Retries, e.RetryAfter)
+	}
+	return fmt.Sprintf("Jira API rate limit exceeded (status 429) after %d retries", e.Retries)
+}
+
+// RetryBudgetError is returned when a request keeps failing transiently and another
+// retry would exceed the client's MaxRetryElapsedTime
+type RetryBudgetError struct {
+	// Elapsed is the time spent on the request, including backoff, before giving up
+	Elapsed time.Duration
+	// Retries is the number of retries made before giving up
+	Retries int
+	// LastStatus is the status code of the last response, or 0 if it failed without one
+	LastStatus int
+	// Err is the error of the last attempt, if it failed without a response
+	Err error
+}
+
+func (e *RetryBudgetError) Error() string {
+	last := fmt.Sprintf("status %d", e.LastStatus)
+	if e.Err != nil {
+		last = e.Err.Error()
+	}
+	return fmt.Sprintf("Jira API request still failing after %d retries in %v, retry budget exhausted (last attempt: %s)", e.Retries, e.Elapsed.Round(time.Millisecond), last)
+}
+
+// Unwrap returns the error of the last attempt
+func (e *RetryBudgetError) Unwrap() error {
+	return e.Err
+}
+
+// ErrorCode maps an error returned by the client to the error code reported by action handlers
+func ErrorCode(err error) string {
+	var rateLimitErr *RateLimitError
+	var retryBudgetErr *RetryBudgetError
+	switch {
+	case errors.As(err, &retryBudgetErr):
+		return "retries_exhausted"
+	case errors.As(err, &rateLimitErr):
+		return "rate_limited"
+	case errors.Is(err, ErrNotFound):
+		return "not_found"
+	case errors.Is(err, ErrUnauthorized):
+		return "unauthorized"
+	case errors.Is(err, ErrForbidden):
+		return "forbidden"
+	case errors.Is(err, context.DeadlineExceeded):
+		return "timeout"
+	case errors.Is(err, ErrTransitionUnavailable):
+		return "validation_error"
+	case errors.Is(err, ErrVotingDisabled):
+		return "not_supported"
+	}
+	var apiErr *JiraAPIError
+	if errors.As(err, &apiErr) {
+		switch {
+		case apiErr.StatusCode == http.StatusTooManyRequests:
+			return "rate_limited"
+		case apiErr.StatusCode == http.StatusBadRequest:
+			return "invalid_request"
+		case apiErr.StatusCode == http.StatusConflict:
+			return "conflict"
+		case apiErr.StatusCode >= 500:
+			return "jira_unavailable"
+		}
+	}
+	return "jira_api_error"
+}
+
+// Authentication modes supported by the client
+const (
+	// AuthModeBearer sends the API token as a Bearer token (Jira Server/Data Center PATs)
+	AuthModeBearer = "bearer"
+	// AuthModeBasic sends the email and API token with HTTP basic auth (Jira Cloud API tokens)
+	AuthModeBasic = "basic"
+	// AuthModeOAuth sends an OAuth 2.0 access token as a Bearer token through Atlassian's
+	// API gateway (Jira Cloud). Unlike AuthModeBearer it implies Cloud API semantics.
+	AuthModeOAuth = "oauth"
+	// authModeAnonymous sends no credentials (used to detect the deployment type)
+	authModeAnonymous = "anonymous"
+)
+
+// PluginVersion is the plugin version, reported in the plugin intro and the User-Agent header
+const PluginVersion = "1.0.0"
+
+// UserAgent identifies the plugin in Jira's logs instead of the Go default, which some
+// Atlassian WAF rules flag
+const UserAgent = "soren-jira-plugin/" + PluginVersion
+
+// DefaultAPIVersion is the Jira REST API version used unless configured otherwise
+const DefaultAPIVersion = "2"
+
+// JiraTimeFormat is the timestamp layout Jira expects for date-time fields such as worklog "started"
+const JiraTimeFormat = "2006-01-02T15:04:05.000-0700"
+
+// DefaultMaxProjects caps how many projects ListProjects collects across pages
+const DefaultMaxProjects = 5000
+
+// DefaultMaxRetries is the default number of retries for transient failures
+const DefaultMaxRetries = 3
+
+// DefaultRetryBaseDelay is the default initial backoff delay between retries
+const DefaultRetryBaseDelay = 200 * time.Millisecond
+
+// DefaultRetryJitter is the default random extra backoff, as a fraction of the delay
+const DefaultRetryJitter = 0.5
+
+// DefaultMaxRetryElapsedTime is the default bound on the total time one request may
+// spend on attempts and backoff before retrying stops
+const DefaultMaxRetryElapsedTime = time.Minute
+
+// DefaultMaxRateLimitRetries is the default number of retries after a 429 response
+const DefaultMaxRateLimitRetries = 3
+
+// projectsPageSize is the number of projects requested per page
+const projectsPageSize = 50
+
+// DefaultProjectDetailWorkers is the number of concurrent GetProject calls made by GetProjectDetails
+const DefaultProjectDetailWorkers = 8
+
+// agileAPIPrefix is the base path of the Jira Software Agile REST API
+const agileAPIPrefix = "/rest/agile/1.0"
+
+// sprintsPageSize is the number of sprints requested per page
+const sprintsPageSize = 50
+
+// bulkCreateBatchSize is the maximum number of issues Jira accepts per bulk create call
+const bulkCreateBatchSize = 50
+
+// ServiceDeskProjectType is the projectTypeKey of Jira Service Management projects
+const ServiceDeskProjectType = "service_desk"
+
+// internalCommentProperty is the comment property Jira Service Management reads to
+// decide whether a comment is internal (agent-only) or public
+const internalCommentProperty = "sd.public.comment"
+
+// sprintIssuesBatchSize is the maximum number of issues Jira accepts per move-to-sprint call
+const sprintIssuesBatchSize = 50
+
+// bulkIssueWorkers bounds the number of issues a bulk operation (delete, comment,
+// transition) processes concurrently
+const bulkIssueWorkers = 5
+
+// DefaultTimeout is the default overall timeout for a single HTTP request
+const DefaultTimeout = 30 * time.Second
+
+// MaxTimeout is the longest per-instance request timeout credentials may configure
+const MaxTimeout = 5 * time.Minute
+
+// DefaultTestConnectionTimeout bounds TestConnection, which backs health checks
+// such as system.ping and should fail fast rather than wait for the request timeout
+const DefaultTestConnectionTimeout = 10 * time.Second
+
+// ClientOption configures optional JiraClient settings
+type ClientOption func(*JiraClient)
+
+// WithTimeout overrides the default 30s HTTP request timeout
+func WithTimeout(timeout time.Duration) ClientOption {
+	return func(jc *JiraClient) {
+		jc.HTTPClient.Timeout = timeout
+	}
+}
+
+// WithTestConnectionTimeout overrides the default 10s bound on TestConnection; 0 removes
+// it, leaving only the HTTP request timeout (e.g. when validating new credentials)
+func WithTestConnectionTimeout(timeout time.Duration) ClientOption {
+	return func(jc *JiraClient) {
+		jc.TestConnectionTimeout = timeout
+	}
+}
+
 // JiraClient handles Jira API calls
 type JiraClient struct {
-	BaseURL    string
+	// BaseURL is the Jira site address. It may include a context path for instances
+	// served below a prefix (e.g. https://host/jira); endpoints are appended to it.
+	BaseURL string
+	// SiteURL is the address of the Jira web UI when API requests don't go to the site
+	// itself (OAuth clients use Atlassian's API gateway); empty means BaseURL
+	SiteURL    string
 	Email      string
 	APIToken   string
 	HTTPClient *http.Client
+
+	// AuthMode selects how requests are authenticated (AuthModeBearer, AuthModeBasic or AuthModeOAuth)
+	AuthMode string
+
+	// APIVersion is the Jira REST API version ("2" or "3"). Version 3 requires
+	// rich text fields such as descriptions and comments in Atlassian Document Format.
+	APIVersion string
+
+	// TestConnectionTimeout bounds TestConnection (including retries); it only
+	// shortens the HTTP client's own timeout, never lengthens it
+	TestConnectionTimeout time.Duration
+
+	// MaxProjects is a safety cap on the number of projects ListProjects returns
+	MaxProjects int
+
+	// MaxRetries is the number of times a request is retried on network errors or 5xx responses
+	MaxRetries int
+	// RetryBaseDelay is the initial backoff delay, doubled on every retry
+	RetryBaseDelay time.Duration
+	// RetryJitter adds a random extra delay of up to this fraction of each backoff
+	// delay (0 disables jitter), so clients retrying together spread out
+	RetryJitter float64
+	// MaxRetryElapsedTime bounds the total time a request may take across attempts
+	// and backoff: a retry that would exceed it is not made and a *RetryBudgetError
+	// is returned instead (0 means no bound besides MaxRetries and the context)
+	MaxRetryElapsedTime time.Duration
+	// MaxRateLimitRetries is the number of times a request is retried after a 429 response
+	MaxRateLimitRetries int
+
+	// CacheTTL is how long project, field and issue type metadata is cached (0 disables caching)
+	CacheTTL time.Duration
+
+	// ProxyURL is the explicit proxy requests are sent through (set with WithProxy);
+	// empty means the proxy environment variables are used
+	ProxyURL string
+	// TLSConfig overrides the TLS settings of the shared transport (set with WithTLSConfig)
+	TLSConfig *tls.Config
+	// ExtraHeaders are added to every request, e.g. for auth gateways (set with
+	// WithExtraHeaders or SOREN_JIRA_EXTRA_HEADERS)
+	ExtraHeaders map[string]string
+	// OverrideHeaders lets ExtraHeaders replace the Authorization, Content-Type and
+	// Accept headers the client sets itself; otherwise those extra headers are ignored
+	OverrideHeaders bool
+	// MetricsRecorder receives per-request metrics (set with WithMetricsRecorder);
+	// nil falls back to the recorder installed with SetMetricsRecorder, if any
+	MetricsRecorder MetricsRecorder
+
+	// oauth refreshes the access token of OAuth clients whose credentials include a
+	// refresh token; nil otherwise
+	oauth *oauthSession
+	// credentialsKey is the StorageKey of the credentials the client was created from;
+	// it keeps cached metadata of profiles without an email (OAuth) apart
+	credentialsKey string
 }
 
-// NewJiraClient creates a new Jira API client
-func NewJiraClient(creds *credentials.JiraCredentials) *JiraClient {
-	return &JiraClient{
+// NewJiraClient creates a new Jira API client.
+// All clients with the same proxy and TLS settings share one pooled http.Transport;
+// each gets its own http.Client so options like WithTimeout don't affect other clients.
+func NewJiraClient(creds *credentials.JiraCredentials, opts ...ClientOption) *JiraClient {
+	jc := &JiraClient{
 		BaseURL:  creds.InstanceURL,
 		Email:    creds.Email,
 		APIToken: creds.APIToken,
 		HTTPClient: &http.Client{
-			Timeout: 30 * time.Second,
+			Timeout: DefaultTimeout,
 		},
+		AuthMode:              authModeFor(creds),
+		APIVersion:            creds.APIVersion,
+		TestConnectionTimeout: DefaultTestConnectionTimeout,
+		MaxProjects:           DefaultMaxProjects,
+		MaxRetries:            DefaultMaxRetries,
+		RetryBaseDelay:        DefaultRetryBaseDelay,
+		RetryJitter:           DefaultRetryJitter,
+		MaxRetryElapsedTime:   DefaultMaxRetryElapsedTime,
+		MaxRateLimitRetries:   DefaultMaxRateLimitRetries,
+		CacheTTL:              DefaultCacheTTL,
+		credentialsKey:        creds.StorageKey(),
+	}
+	// OAuth access tokens are only accepted through Atlassian's API gateway
+	if creds.IsOAuth() {
+		jc.SiteURL = creds.InstanceURL
+		jc.BaseURL = oauthBaseURL(creds.CloudID)
+		jc.APIToken = creds.AccessToken
+		if creds.RefreshToken != "" {
+			session := *creds
+			jc.oauth = &oauthSession{creds: &session}
+		}
+	}
+	// Slow on-prem instances can be given a longer timeout in their credentials
+	if creds.TimeoutSeconds > 0 {
+		jc.HTTPClient.Timeout = min(time.Duration(creds.TimeoutSeconds)*time.Second, MaxTimeout)
+	}
+	// A proxy and headers forced through the environment apply first so explicit options can override them
+	if proxyURL := os.Getenv(proxyEnv); proxyURL != "" {
+		WithProxy(proxyURL)(jc)
+	}
+	if headers, override := extraHeadersFromEnv(); len(headers) > 0 {
+		WithExtraHeaders(headers, override)(jc)
+	}
+	for _, opt := range opts {
+		opt(jc)
+	}
+	jc.HTTPClient.Transport = transportFor(jc.ProxyURL, jc.TLSConfig)
+	return jc
+}
+
+// oauthSession returns the session that refreshes the client's OAuth access token, or
+// nil if the token can't be refreshed or isn't sent (anonymous requests)
+func (jc *JiraClient) oauthSession() *oauthSession {
+	if jc.AuthMode != AuthModeOAuth {
+		return nil
+	}
+	return jc.oauth
+}
+
+// authModeFor uses the auth mode detected at onboarding, or derives it from the URL
+func authModeFor(creds *credentials.JiraCredentials) string {
+	if creds.IsOAuth() {
+		return AuthModeOAuth
+	}
+	if creds.AuthMode == AuthModeBasic || creds.AuthMode == AuthModeBearer {
+		return creds.AuthMode
+	}
+	return defaultAuthMode(creds.InstanceURL)
+}
+
+// defaultAuthMode picks basic auth for Jira Cloud (*.atlassian.net) and Bearer auth otherwise
+func defaultAuthMode(instanceURL string) string {
+	if !strings.Contains(instanceURL, "://") {
+		instanceURL = "https://" + instanceURL
+	}
+	parsed, err := url.Parse(instanceURL)
+	if err == nil && strings.HasSuffix(strings.ToLower(parsed.Hostname()), ".atlassian.net") {
+		return AuthModeBasic
+	}
+	return AuthModeBearer
+}
+
+// makeRequest makes an authenticated JSON HTTP request to Jira API
+func (jc *JiraClient) makeRequest(ctx context.Context, method, endpoint string, body io.Reader) (*http.Response, error) {
+	return jc.makeRequestWithHeaders(ctx, method, endpoint, body, nil)
+}
+
+// makeRequestWithHeaders makes an authenticated HTTP request to Jira API.
+// headers are applied after the defaults, so they can override e.g. Content-Type.
+// Network errors and 5xx responses are retried with exponential backoff as long
+// as the request body can be replayed; 4xx responses are returned immediately.
+func (jc *JiraClient) makeRequestWithHeaders(ctx context.Context, method, endpoint string, body io.Reader, headers map[string]string) (*http.Response, error) {
+	// Join base URL and endpoint with exactly one slash, keeping any context path
+	url := jc.baseURL() + "/" + strings.TrimLeft(endpoint, "/")
+
+	// Buffer in-memory bodies so they can be rewound between attempts.
+	// Other readers are sent once without retries.
+	var bodyBytes []byte
+	replayable := true
+	if body != nil {
+		switch body.(type) {
+		case *bytes.Reader, *bytes.Buffer, *strings.Reader:
+			b, err := io.ReadAll(body)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read request body: %w", err)
+			}
+			bodyBytes = b
+		default:
+			replayable = false
+		}
+	}
+
+	maxRetries := jc.MaxRetries
+	maxRateLimitRetries := jc.MaxRateLimitRetries
+	if !replayable {
+		maxRetries = 0
+		maxRateLimitRetries = 0
+	}
+
+	retries, rateLimitRetries := 0, 0
+	// tokenRefreshed records the one refresh attempt allowed per request
+	tokenRefreshed := false
+
+	// Refresh an OAuth access token about to expire before using it, so long-running
+	// automations don't fail at expiry. If that fails the current token is still tried,
+	// as it may not have expired yet; Jira's 401 is returned otherwise.
+	oauth := jc.oauthSession()
+	if oauth != nil {
+		if err := oauth.refreshIfExpired(); err != nil {
+			log.Printf("Could not refresh the expiring OAuth access token before %s %s: %v", method, url, err)
+			tokenRefreshed = true
+		}
+	}
+	requestStart := time.Now()
+	// exceedsBudget reports whether waiting delay before the next attempt would
+	// exceed the retry budget
+	exceedsBudget := func(delay time.Duration) bool {
+		return jc.MaxRetryElapsedTime > 0 && time.Since(requestStart)+delay > jc.MaxRetryElapsedTime
+	}
+	for {
+		reqBody := body
+		if body != nil && replayable {
+			reqBody = bytes.NewReader(bodyBytes)
+		}
+
+		log.Printf("Making Jira API request: %s %s", method, url)
+
+		req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
+
+		// Jira Cloud API tokens use basic auth with the account email;
+		// Jira Server/Data Center PATs (Personal Access Tokens) and OAuth access tokens use Bearer auth
+		token := jc.APIToken
+		if oauth != nil {
+			token = oauth.accessToken()
+		}
+		switch jc.AuthMode {
+		case AuthModeBasic:
+			req.SetBasicAuth(jc.Email, token)
+		case authModeAnonymous:
+		default:
+			req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Accept", "application/json")
+		req.Header.Set("User-Agent", UserAgent)
+		jc.applyExtraHeaders(req)
+		for key, value := range headers {
+			req.Header.Set(key, value)
+		}
+
+		start := time.Now()
+		resp, err := jc.HTTPClient.Do(req)
+		metrics := RequestMetrics{
+			Method:   method,
+			Endpoint: endpoint,
+			Duration: time.Since(start),
+			Attempt:  retries + rateLimitRetries,
+			Err:      err,
+		}
+		if resp != nil {
+			metrics.StatusCode = resp.StatusCode
+		}
+		jc.recordRequest(ctx, metrics)
+
+		// The access token may have been revoked or expired early: refresh it and retry
+		// once. If the refresh fails, Jira's 401 is returned as is.
+		if err == nil && resp.StatusCode == http.StatusUnauthorized && oauth != nil && replayable && !tokenRefreshed {
+			tokenRefreshed = true
+			if refreshErr := oauth.refreshAfterRejection(token); refreshErr != nil {
+				log.Printf("Could not refresh the OAuth access token after a 401 from %s %s: %v", method, url, refreshErr)
+				return resp, nil
+			}
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+			log.Printf("Retrying %s %s with a refreshed OAuth access token", method, url)
+			continue
+		}
+
+		// Rate limited: wait for Retry-After (or back off) and try again
+		if err == nil && resp.StatusCode == http.StatusTooManyRequests {
+			delay := parseRetryAfter(resp.Header.Get("Retry-After"))
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+
+			if rateLimitRetries >= maxRateLimitRetries {
+				return nil, &RateLimitError{RetryAfter: delay, Retries: rateLimitRetries}
+			}
+			if delay <= 0 {
+				delay = jc.backoffDelay(rateLimitRetries)
+			}
+			if exceedsBudget(delay) {
+				log.Printf("Not retrying rate limited %s %s: waiting %v would exceed the %v retry budget", method, url, delay, jc.MaxRetryElapsedTime)
+				return nil, &RetryBudgetError{Elapsed: time.Since(requestStart), Retries: retries + rateLimitRetries, LastStatus: http.StatusTooManyRequests}
+			}
+
+			rateLimitRetries++
+			log.Printf("Jira API rate limit hit, retrying %s %s in %v (retry %d/%d)", method, url, delay, rateLimitRetries, maxRateLimitRetries)
+			if err := sleepContext(ctx, delay); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		retryable := (err != nil && ctx.Err() == nil) || (err == nil && resp.StatusCode >= http.StatusInternalServerError)
+		if !retryable || retries >= maxRetries {
+			if err != nil {
+				return nil, fmt.Errorf("failed to make request: %w", err)
+			}
+			return resp, nil
+		}
+
+		// Drain and close the failed response so the connection can be reused
+		lastStatus := 0
+		if err != nil {
+			log.Printf("Jira API request failed: %v", err)
+		} else {
+			log.Printf("Jira API returned transient status %d", resp.StatusCode)
+			lastStatus = resp.StatusCode
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		delay := jc.backoffDelay(retries)
+		if exceedsBudget(delay) {
+			log.Printf("Not retrying %s %s: waiting %v would exceed the %v retry budget", method, url, delay, jc.MaxRetryElapsedTime)
+			return nil, &RetryBudgetError{Elapsed: time.Since(requestStart), Retries: retries + rateLimitRetries, LastStatus: lastStatus, Err: err}
+		}
+		retries++
+		log.Printf("Retrying Jira API request %s %s in %v (retry %d/%d)", method, url, delay, retries, maxRetries)
+		if err := sleepContext(ctx, delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// sleepContext waits for the given delay or until the context is done
+func sleepContext(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// parseRetryAfter parses a Retry-After header given either in seconds or as an HTTP-date.
+// It returns 0 if the header is absent or invalid.
+func parseRetryAfter(value string) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		if delay := time.Until(date); delay > 0 {
+			return delay
+		}
+	}
+	return 0
+}
+
+// backoffDelay returns the exponential backoff delay for the given attempt
+// (base, 2x base, 4x base, ...) plus up to 50% random jitter
+func (jc *JiraClient) backoffDelay(attempt int) time.Duration {
+	base := jc.RetryBaseDelay
+	if base <= 0 {
+		base = DefaultRetryBaseDelay
+	}
+	delay := base << attempt
+	jitter := time.Duration(float64(delay) * min(max(jc.RetryJitter, 0), 1))
+	return delay + rand.N(jitter+1)
+}
+
+// TestConnection verifies the credentials by fetching the authenticated user.
+// It gives up after TestConnectionTimeout (10s by default).
+func (jc *JiraClient) TestConnection(ctx context.Context) (map[string]interface{}, error) {
+	if jc.TestConnectionTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, jc.TestConnectionTimeout)
+		defer cancel()
+	}
+	user, err := jc.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("Successfully connected to Jira as %v", user["displayName"])
+	return user, nil
+}
+
+// GetServerInfo fetches the instance's version, build and deployment type from
+// /serverInfo. The response is cached like other metadata, so serverTime reflects
+// the moment it was fetched.
+func (jc *JiraClient) GetServerInfo(ctx context.Context) (map[string]interface{}, error) {
+	return cachedLookup(jc, "serverinfo", func() (map[string]interface{}, error) {
+		return jc.getServerInfo(ctx)
+	})
+}
+
+// getServerInfo fetches /serverInfo from Jira
+func (jc *JiraClient) getServerInfo(ctx context.Context) (map[string]interface{}, error) {
+	resp, err := jc.makeRequest(ctx, "GET", jc.apiPath("/serverInfo"), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	bodyBytes, err := readResponseBody(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, parseJiraError(resp.StatusCode, bodyBytes, "Errors")
+	}
+
+	var info map[string]interface{}
+	err = sonic.Unmarshal(bodyBytes, &info)
+	if err != nil {
+		log.Printf("Failed to unmarshal server info response: %v, body: %s", err, RedactJSON(bodyBytes))
+		return nil, fmt.Errorf("failed to unmarshal server info: %w", err)
+	}
+
+	log.Printf("Successfully retrieved Jira server info (version %v, %v)", info["version"], info["deploymentType"])
+	return info, nil
+}
+
+// Deployment types reported by /serverInfo
+const (
+	DeploymentCloud      = "Cloud"
+	DeploymentServer     = "Server"
+	DeploymentDataCenter = "DataCenter"
+)
+
+// DetectDeploymentType asks the instance whether it is Jira Cloud, Server or Data Center.
+// The request is sent without credentials, since the right auth mode isn't known yet
+// and /serverInfo is readable anonymously. It always uses API v2, which every
+// deployment supports. Old Server versions don't report a type and are treated as Server.
+func (jc *JiraClient) DetectDeploymentType(ctx context.Context) (string, error) {
+	anonymous := *jc
+	anonymous.AuthMode = authModeAnonymous
+	resp, err := anonymous.makeRequest(ctx, "GET", "/rest/api/2/serverInfo", nil)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	bodyBytes, err := readResponseBody(resp)
+	if err != nil {
+		return "", err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return "", parseJiraError(resp.StatusCode, bodyBytes, "Errors")
+	}
+
+	var info struct {
+		DeploymentType string `json:"deploymentType"`
+		Version        string `json:"version"`
+	}
+	err = sonic.Unmarshal(bodyBytes, &info)
+	if err != nil {
+		log.Printf("Failed to unmarshal server info response: %v, body: %s", err, RedactJSON(bodyBytes))
+		return "", fmt.Errorf("failed to unmarshal server info: %w", err)
+	}
+
+	deploymentType := info.DeploymentType
+	if deploymentType == "" {
+		deploymentType = DeploymentServer
+	}
+	log.Printf("Detected Jira deployment type %s (version %s) at %s", deploymentType, info.Version, jc.BaseURL)
+	return deploymentType, nil
+}
+
+// DeploymentSettings returns the auth mode and API version suited to a deployment type:
+// Cloud uses basic auth with API v3 (Atlassian Document Format), Server and Data Center
+// use Bearer personal access tokens with API v2 (plain text)
+func DeploymentSettings(deploymentType string) (authMode, apiVersion string) {
+	if strings.EqualFold(deploymentType, DeploymentCloud) {
+		return AuthModeBasic, "3"
+	}
+	return AuthModeBearer, DefaultAPIVersion
+}
+
+// GetCurrentUser fetches the user the client is authenticated as
+func (jc *JiraClient) GetCurrentUser(ctx context.Context) (map[string]interface{}, error) {
+	resp, err := jc.makeRequest(ctx, "GET", jc.apiPath("/myself"), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	bodyBytes, err := readResponseBody(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusUnauthorized:
+		return nil, fmt.Errorf("Jira rejected the credentials (status %d): %w", resp.StatusCode, ErrUnauthorized)
+	case http.StatusForbidden:
+		return nil, fmt.Errorf("Jira denied access for these credentials (status %d): %w", resp.StatusCode, ErrForbidden)
+	default:
+		return nil, parseJiraError(resp.StatusCode, bodyBytes, "Errors")
+	}
+
+	// Parse response
+	var user map[string]interface{}
+	err = sonic.Unmarshal(bodyBytes, &user)
+	if err != nil {
+		log.Printf("Failed to unmarshal user response: %v, body: %s", err, RedactJSON(bodyBytes))
+		return nil, fmt.Errorf("failed to unmarshal user: %w", err)
+	}
+
+	return user, nil
+}
+
+// SearchUsers finds users matching a name, username or email address
+func (jc *JiraClient) SearchUsers(ctx context.Context, query string, maxResults int) ([]map[string]interface{}, error) {
+	params := url.Values{}
+	// Jira Cloud searches with "query"; Server/Data Center (Bearer PATs) still expects "username"
+	if jc.AuthMode == AuthModeBearer {
+		params.Set("username", query)
+	} else {
+		params.Set("query", query)
+	}
+	params.Set("maxResults", strconv.Itoa(maxResults))
+	endpoint := jc.apiPath("/user/search?" + params.Encode())
+
+	resp, err := jc.makeRequest(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	bodyBytes, err := readResponseBody(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, parseJiraError(resp.StatusCode, bodyBytes, "Errors")
+	}
+
+	// Parse response
+	users := []map[string]interface{}{}
+	err = sonic.Unmarshal(bodyBytes, &users)
+	if err != nil {
+		log.Printf("Failed to unmarshal user search response: %v, body: %s", err, RedactJSON(bodyBytes))
+		return nil, fmt.Errorf("failed to unmarshal users: %w", err)
+	}
+
+	log.Printf("Found %d Jira users matching %q", len(users), query)
+	return users, nil
+}
+
+// groupMembersPageSize is the number of members requested per page from /group/member
+const groupMembersPageSize = 50
+
+// MaxGroupMembers caps how many members GetGroupMembers collects across pages
+const MaxGroupMembers = 1000
+
+// SearchGroups finds groups whose name matches query (all groups, up to maxResults,
+// when query is empty) using the group picker
+func (jc *JiraClient) SearchGroups(ctx context.Context, query string, maxResults int) ([]map[string]interface{}, error) {
+	params := url.Values{}
+	params.Set("query", query)
+	params.Set("maxResults", strconv.Itoa(maxResults))
+	endpoint := jc.apiPath("/groups/picker?" + params.Encode())
+
+	resp, err := jc.makeRequest(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	bodyBytes, err := readResponseBody(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, parseJiraError(resp.StatusCode, bodyBytes, "Errors")
+	}
+
+	// Parse response: {"header": "Showing 2 of 2 matching groups", "total": 2, "groups": [{"name": "...", "groupId": "..."}]}
+	var pickerResponse struct {
+		Groups []map[string]interface{} `json:"groups"`
+	}
+	err = sonic.Unmarshal(bodyBytes, &pickerResponse)
+	if err != nil {
+		log.Printf("Failed to unmarshal group picker response: %v, body: %s", err, RedactJSON(bodyBytes))
+		return nil, fmt.Errorf("failed to unmarshal groups: %w", err)
+	}
+
+	log.Printf("Found %d Jira groups matching %q", len(pickerResponse.Groups), query)
+	return pickerResponse.Groups, nil
+}
+
+// GetGroupMembers returns the members of a group, following pagination until the last
+// page or MaxGroupMembers members. truncated reports whether members were left out.
+func (jc *JiraClient) GetGroupMembers(ctx context.Context, groupName string, includeInactive bool) (members []map[string]interface{}, truncated bool, err error) {
+	members, truncated, err = fetchAllPages(ctx, jc, pageQuery[map[string]interface{}]{
+		name: "group members",
+		endpoint: func(startAt, maxResults int) string {
+			params := url.Values{}
+			params.Set("groupname", groupName)
+			params.Set("includeInactiveUsers", strconv.FormatBool(includeInactive))
+			params.Set("startAt", strconv.Itoa(startAt))
+			params.Set("maxResults", strconv.Itoa(maxResults))
+			return jc.apiPath("/group/member?" + params.Encode())
+		},
+		pageSize: groupMembersPageSize,
+		limit:    MaxGroupMembers,
+		notFound: fmt.Errorf("group %s %w", groupName, ErrNotFound),
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	log.Printf("Successfully retrieved %d members of Jira group %s", len(members), groupName)
+	return members, truncated, nil
+}
+
+// FindAssignableUsers finds users who can be assigned issues in a project.
+// query is optional; without it Jira returns all assignable users.
+func (jc *JiraClient) FindAssignableUsers(ctx context.Context, projectKey, query string, maxResults int) ([]map[string]interface{}, error) {
+	params := url.Values{}
+	params.Set("project", projectKey)
+	if query != "" {
+		// Same split as SearchUsers: Server/Data Center expects "username"
+		if jc.AuthMode == AuthModeBearer {
+			params.Set("username", query)
+		} else {
+			params.Set("query", query)
+		}
+	}
+	params.Set("maxResults", strconv.Itoa(maxResults))
+	endpoint := jc.apiPath("/user/assignable/search?" + params.Encode())
+
+	resp, err := jc.makeRequest(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	bodyBytes, err := readResponseBody(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("project %s %w (or you do not have permission to view it)", projectKey, ErrNotFound)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, parseJiraError(resp.StatusCode, bodyBytes, "Errors")
+	}
+
+	// Parse response
+	users := []map[string]interface{}{}
+	err = sonic.Unmarshal(bodyBytes, &users)
+	if err != nil {
+		log.Printf("Failed to unmarshal assignable users response: %v, body: %s", err, RedactJSON(bodyBytes))
+		return nil, fmt.Errorf("failed to unmarshal users: %w", err)
+	}
+
+	log.Printf("Found %d assignable users in project %s", len(users), projectKey)
+	return users, nil
+}
+
+// ListProjects retrieves all projects from Jira, following the paginated
+// /project/search endpoint until every page is collected or MaxProjects is reached.
+// The result is cached for CacheTTL; call InvalidateCache to force a refresh.
+func (jc *JiraClient) ListProjects(ctx context.Context) ([]map[string]interface{}, error) {
+	return cachedLookup(jc, fmt.Sprintf("projects:%d", jc.MaxProjects), func() ([]map[string]interface{}, error) {
+		return jc.listProjects(ctx)
+	})
+}
+
+// listProjects fetches every page of /project/search
+func (jc *JiraClient) listProjects(ctx context.Context) ([]map[string]interface{}, error) {
+	maxProjects := jc.MaxProjects
+	if maxProjects <= 0 {
+		maxProjects = DefaultMaxProjects
+	}
+
+	projects, _, err := fetchAllPages(ctx, jc, pageQuery[map[string]interface{}]{
+		name: "projects",
+		endpoint: func(startAt, maxResults int) string {
+			return jc.apiPath(fmt.Sprintf("/project/search?startAt=%d&maxResults=%d", startAt, maxResults))
+		},
+		pageSize: projectsPageSize,
+		limit:    maxProjects,
+		parse:    parseProjectsPage,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("Successfully parsed %d projects from Jira API", len(projects))
+	return projects, nil
+}
+
+// GetProject fetches a single Jira project by key or ID
+func (jc *JiraClient) GetProject(ctx context.Context, projectKeyOrId string) (map[string]interface{}, error) {
+	endpoint := jc.apiPath(fmt.Sprintf("/project/%s", url.PathEscape(projectKeyOrId)))
+	resp, err := jc.makeRequest(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	bodyBytes, err := readResponseBody(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("project %s %w (or you do not have permission to view it)", projectKeyOrId, ErrNotFound)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, parseJiraError(resp.StatusCode, bodyBytes, "Errors")
+	}
+
+	// Parse response
+	var project map[string]interface{}
+	err = sonic.Unmarshal(bodyBytes, &project)
+	if err != nil {
+		log.Printf("Failed to unmarshal project response: %v, body: %s", err, RedactJSON(bodyBytes))
+		return nil, fmt.Errorf("failed to unmarshal project: %w", err)
+	}
+
+	log.Printf("Successfully retrieved Jira project: %s", projectKeyOrId)
+	return project, nil
+}
+
+// GetProjectDetails fetches the full details (lead, issue types, components) of every
+// project in the list with GetProject, using at most workers concurrent requests
+// (DefaultProjectDetailWorkers if workers <= 0). The result keeps the order of the
+// input. A project whose details can't be fetched is returned as a copy of its list
+// entry with an "error" field instead of failing the whole call. The input maps are
+// never modified, since they may be shared with the metadata cache. onDone, if not
+// nil, is called after every project with the number processed so far.
+func (jc *JiraClient) GetProjectDetails(ctx context.Context, projects []map[string]interface{}, workers int, onDone func(done, total int)) []map[string]interface{} {
+	if workers <= 0 {
+		workers = DefaultProjectDetailWorkers
+	}
+
+	detailed := make([]map[string]interface{}, len(projects))
+	jobs := make(chan int)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	done := 0
+	for range min(workers, len(projects)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				key, _ := projects[i]["key"].(string)
+				if key == "" {
+					key, _ = projects[i]["id"].(string)
+				}
+
+				project, err := jc.GetProject(ctx, key)
+				if err != nil {
+					// Keep the summary from the list and report why the details are missing
+					project = make(map[string]interface{}, len(projects[i])+1)
+					for k, v := range projects[i] {
+						project[k] = v
+					}
+					project["error"] = err.Error()
+				}
+				// Each worker writes its own index, so only the counter needs the lock
+				detailed[i] = project
+
+				mu.Lock()
+				done++
+				processed := done
+				mu.Unlock()
+				if onDone != nil {
+					onDone(processed, len(projects))
+				}
+			}
+		}()
+	}
+	for i := range projects {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return detailed
+}
+
+// ListIssueTypesForProject returns the issue types that can be created in a project.
+// The result is cached for CacheTTL; call InvalidateCache to force a refresh.
+func (jc *JiraClient) ListIssueTypesForProject(ctx context.Context, projectKeyOrId string) ([]map[string]interface{}, error) {
+	return cachedLookup(jc, "issuetypes:"+projectKeyOrId, func() ([]map[string]interface{}, error) {
+		return jc.listIssueTypesForProject(ctx, projectKeyOrId)
+	})
+}
+
+// listIssueTypesForProject fetches the project's issue types from createmeta
+func (jc *JiraClient) listIssueTypesForProject(ctx context.Context, projectKeyOrId string) ([]map[string]interface{}, error) {
+	query := url.Values{}
+	query.Set("expand", "projects.issuetypes")
+	// createmeta filters by key or by ID depending on the parameter name
+	if _, err := strconv.Atoi(projectKeyOrId); err == nil {
+		query.Set("projectIds", projectKeyOrId)
+	} else {
+		query.Set("projectKeys", projectKeyOrId)
+	}
+	endpoint := jc.apiPath("/issue/createmeta?" + query.Encode())
+
+	resp, err := jc.makeRequest(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	bodyBytes, err := readResponseBody(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, parseJiraError(resp.StatusCode, bodyBytes, "Errors")
+	}
+
+	// Parse response
+	var meta struct {
+		Projects []struct {
+			IssueTypes []map[string]interface{} `json:"issuetypes"`
+		} `json:"projects"`
+	}
+	err = sonic.Unmarshal(bodyBytes, &meta)
+	if err != nil {
+		log.Printf("Failed to unmarshal createmeta response: %v, body: %s", err, RedactJSON(bodyBytes))
+		return nil, fmt.Errorf("failed to unmarshal issue types: %w", err)
+	}
+
+	// Jira returns no projects (rather than a 404) for unknown or inaccessible projects
+	if len(meta.Projects) == 0 {
+		return nil, fmt.Errorf("project %s %w (or you do not have permission to create issues in it)", projectKeyOrId, ErrNotFound)
+	}
+
+	issueTypes := []map[string]interface{}{}
+	for _, issueType := range meta.Projects[0].IssueTypes {
+		subtask, _ := issueType["subtask"].(bool)
+		issueTypes = append(issueTypes, map[string]interface{}{
+			"id":          issueType["id"],
+			"name":        issueType["name"],
+			"description": issueType["description"],
+			"subtask":     subtask,
+		})
+	}
+
+	log.Printf("Successfully retrieved %d issue types for project %s", len(issueTypes), projectKeyOrId)
+	return issueTypes, nil
+}
+
+// maxListedOptions caps how many valid options a validation message lists
+const maxListedOptions = 20
+
+// CheckIssueType checks that an issue type (name or ID) can be created in a project,
+// using the project's issue types from createmeta (cached). It returns a readable
+// problem naming the allowed types, or the valid projects if the project doesn't
+// exist; an empty problem means the issue type is available.
+func (jc *JiraClient) CheckIssueType(ctx context.Context, projectKey, issueType string) (string, error) {
+	_, problem, err := jc.resolveIssueType(ctx, projectKey, issueType)
+	return problem, err
+}
+
+// resolveIssueType returns the ID of an issue type available in a project, or a
+// problem describing why it isn't
+func (jc *JiraClient) resolveIssueType(ctx context.Context, projectKey, issueType string) (string, string, error) {
+	issueTypes, err := jc.ListIssueTypesForProject(ctx, projectKey)
+	if errors.Is(err, ErrNotFound) {
+		problem := fmt.Sprintf("Project '%s' does not exist or you cannot create issues in it", projectKey)
+		if projects, err := jc.ListProjects(ctx); err == nil && len(projects) > 0 {
+			keys := make([]string, 0, len(projects))
+			for _, project := range projects {
+				if key, ok := project["key"].(string); ok {
+					keys = append(keys, key)
+				}
+			}
+			problem += ". Valid projects: " + listOptions(keys)
+		}
+		return "", problem, nil
+	}
+	if err != nil {
+		return "", "", err
+	}
+
+	names := make([]string, 0, len(issueTypes))
+	for _, t := range issueTypes {
+		id, _ := t["id"].(string)
+		name, _ := t["name"].(string)
+		names = append(names, name)
+		if id == issueType || strings.EqualFold(name, issueType) {
+			return id, "", nil
+		}
+	}
+	return "", fmt.Sprintf("Issue type '%s' is not available in project %s. Valid issue types: %s", issueType, projectKey, listOptions(names)), nil
+}
+
+// ValidateIssueCreate checks an issue against createmeta before it is created: the
+// project must exist, the issue type must be available in it, and every required
+// field without a default must be set. fields holds the additional fields keyed by
+// field ID (summary and description are passed separately). It returns one readable
+// problem per failed check, listing the valid options; an empty result means the
+// issue passed. The metadata lookups are cached.
+func (jc *JiraClient) ValidateIssueCreate(ctx context.Context, projectKey, issueType string, hasDescription bool, fields map[string]interface{}) ([]string, error) {
+	issueTypeID, problem, err := jc.resolveIssueType(ctx, projectKey, issueType)
+	if err != nil {
+		return nil, err
+	}
+	if problem != "" {
+		return []string{problem}, nil
+	}
+
+	createFields, err := cachedLookup(jc, "createfields:"+projectKey+":"+issueTypeID, func() (map[string]createField, error) {
+		return jc.listCreateFields(ctx, projectKey, issueTypeID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// These are filled in from the dedicated arguments of CreateIssue
+	provided := map[string]bool{"project": true, "issuetype": true, "summary": true, "description": hasDescription}
+	var problems []string
+	for id, field := range createFields {
+		if !field.Required || field.HasDefaultValue || provided[id] {
+			continue
+		}
+		if value, ok := fields[id]; ok && value != nil && value != "" {
+			continue
+		}
+		problems = append(problems, fmt.Sprintf("Required field '%s' (%s) is missing", field.Name, id))
+	}
+	sort.Strings(problems)
+	return problems, nil
+}
+
+// createField is the createmeta description of a field on the create screen
+type createField struct {
+	Name            string `json:"name"`
+	Required        bool   `json:"required"`
+	HasDefaultValue bool   `json:"hasDefaultValue"`
+}
+
+// listCreateFields fetches the create-screen fields of one issue type from createmeta
+func (jc *JiraClient) listCreateFields(ctx context.Context, projectKey, issueTypeID string) (map[string]createField, error) {
+	query := url.Values{}
+	query.Set("projectKeys", projectKey)
+	query.Set("issuetypeIds", issueTypeID)
+	query.Set("expand", "projects.issuetypes.fields")
+	endpoint := jc.apiPath("/issue/createmeta?" + query.Encode())
+
+	resp, err := jc.makeRequest(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	bodyBytes, err := readResponseBody(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, parseJiraError(resp.StatusCode, bodyBytes, "Errors")
+	}
+
+	var meta struct {
+		Projects []struct {
+			IssueTypes []struct {
+				Fields map[string]createField `json:"fields"`
+			} `json:"issuetypes"`
+		} `json:"projects"`
+	}
+	err = sonic.Unmarshal(bodyBytes, &meta)
+	if err != nil {
+		log.Printf("Failed to unmarshal createmeta response: %v, body: %s", err, RedactJSON(bodyBytes))
+		return nil, fmt.Errorf("failed to unmarshal create fields: %w", err)
+	}
+	if len(meta.Projects) == 0 || len(meta.Projects[0].IssueTypes) == 0 {
+		return nil, fmt.Errorf("issue type %s of project %s %w", issueTypeID, projectKey, ErrNotFound)
+	}
+
+	fields := meta.Projects[0].IssueTypes[0].Fields
+	log.Printf("Successfully retrieved %d create fields for project %s, issue type %s", len(fields), projectKey, issueTypeID)
+	return fields, nil
+}
+
+// GetCreateMeta describes the fields of the create screen of an issue type (name or ID)
+// in a project, simplified for rendering a form: {"projectKey", "issueType": {id, name},
+// "fields": [...], "required": [field IDs]}. Each field has id, name, required,
+// hasDefaultValue, type (plus items for arrays and custom for custom fields), and
+// allowedValues ({id, name}) for selects. Required fields come first. The result is
+// cached like other metadata and must not be modified.
+func (jc *JiraClient) GetCreateMeta(ctx context.Context, projectKey, issueType string) (map[string]interface{}, error) {
+	issueTypeID, problem, err := jc.resolveIssueType(ctx, projectKey, issueType)
+	if err != nil {
+		return nil, err
+	}
+	if problem != "" {
+		return nil, fmt.Errorf("%s: %w", problem, ErrNotFound)
+	}
+
+	return cachedLookup(jc, "createmeta:"+projectKey+":"+issueTypeID, func() (map[string]interface{}, error) {
+		return jc.getCreateMeta(ctx, projectKey, issueTypeID)
+	})
+}
+
+// getCreateMeta fetches and simplifies the createmeta of one issue type
+func (jc *JiraClient) getCreateMeta(ctx context.Context, projectKey, issueTypeID string) (map[string]interface{}, error) {
+	query := url.Values{}
+	query.Set("projectKeys", projectKey)
+	query.Set("issuetypeIds", issueTypeID)
+	query.Set("expand", "projects.issuetypes.fields")
+	endpoint := jc.apiPath("/issue/createmeta?" + query.Encode())
+
+	resp, err := jc.makeRequest(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	bodyBytes, err := readResponseBody(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, parseJiraError(resp.StatusCode, bodyBytes, "Errors")
+	}
+
+	var meta struct {
+		Projects []struct {
+			Key        string `json:"key"`
+			IssueTypes []struct {
+				ID     string                            `json:"id"`
+				Name   string                            `json:"name"`
+				Fields map[string]map[string]interface{} `json:"fields"`
+			} `json:"issuetypes"`
+		} `json:"projects"`
+	}
+	err = sonic.Unmarshal(bodyBytes, &meta)
+	if err != nil {
+		log.Printf("Failed to unmarshal createmeta response: %v, body: %s", err, RedactJSON(bodyBytes))
+		return nil, fmt.Errorf("failed to unmarshal createmeta: %w", err)
+	}
+	if len(meta.Projects) == 0 || len(meta.Projects[0].IssueTypes) == 0 {
+		return nil, fmt.Errorf("issue type %s of project %s %w", issueTypeID, projectKey, ErrNotFound)
+	}
+	issueType := meta.Projects[0].IssueTypes[0]
+
+	fields := make([]map[string]interface{}, 0, len(issueType.Fields))
+	for id, raw := range issueType.Fields {
+		required, _ := raw["required"].(bool)
+		hasDefaultValue, _ := raw["hasDefaultValue"].(bool)
+		field := map[string]interface{}{
+			"id":              id,
+			"name":            raw["name"],
+			"required":        required,
+			"hasDefaultValue": hasDefaultValue,
+		}
+		if schema, ok := raw["schema"].(map[string]interface{}); ok {
+			field["type"] = schema["type"]
+			if items, ok := schema["items"]; ok {
+				field["items"] = items
+			}
+			if custom, ok := schema["custom"]; ok {
+				field["custom"] = custom
+			}
+		}
+		if defaultValue, ok := raw["defaultValue"]; ok {
+			field["defaultValue"] = defaultValue
+		}
+		// Options are named by "name" (priorities, components, ...) or "value" (custom selects)
+		if allowed, ok := raw["allowedValues"].([]interface{}); ok {
+			options := make([]map[string]interface{}, 0, len(allowed))
+			for _, rawOption := range allowed {
+				option, ok := rawOption.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				name := option["name"]
+				if name == nil {
+					name = option["value"]
+				}
+				options = append(options, map[string]interface{}{"id": option["id"], "name": name})
+			}
+			field["allowedValues"] = options
+		}
+		fields = append(fields, field)
+	}
+
+	// Required fields first, then by name
+	sort.Slice(fields, func(i, j int) bool {
+		ri, rj := fields[i]["required"].(bool), fields[j]["required"].(bool)
+		if ri != rj {
+			return ri
+		}
+		ni, _ := fields[i]["name"].(string)
+		nj, _ := fields[j]["name"].(string)
+		return strings.ToLower(ni) < strings.ToLower(nj)
+	})
+	required := []string{}
+	for _, field := range fields {
+		if field["required"].(bool) {
+			required = append(required, field["id"].(string))
+		}
+	}
+
+	log.Printf("Successfully retrieved createmeta for project %s, issue type %s (%d fields, %d required)", projectKey, issueType.Name, len(fields), len(required))
+	return map[string]interface{}{
+		"projectKey": meta.Projects[0].Key,
+		"issueType": map[string]interface{}{
+			"id":   issueType.ID,
+			"name": issueType.Name,
+		},
+		"fields":   fields,
+		"required": required,
+	}, nil
+}
+
+// listOptions renders valid options for a validation message, capped at maxListedOptions
+func listOptions(options []string) string {
+	if len(options) > maxListedOptions {
+		return strings.Join(options[:maxListedOptions], ", ") + fmt.Sprintf(" (and %d more)", len(options)-maxListedOptions)
+	}
+	return strings.Join(options, ", ")
+}
+
+// ListComponents returns the components of a project
+func (jc *JiraClient) ListComponents(ctx context.Context, projectKeyOrId string) ([]map[string]interface{}, error) {
+	endpoint := jc.apiPath(fmt.Sprintf("/project/%s/components", url.PathEscape(projectKeyOrId)))
+	resp, err := jc.makeRequest(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	bodyBytes, err := readResponseBody(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("project %s %w (or you do not have permission to view it)", projectKeyOrId, ErrNotFound)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, parseJiraError(resp.StatusCode, bodyBytes, "Errors")
+	}
+
+	// Parse response (a project without components returns an empty array)
+	components := []map[string]interface{}{}
+	err = sonic.Unmarshal(bodyBytes, &components)
+	if err != nil {
+		log.Printf("Failed to unmarshal components response: %v, body: %s", err, RedactJSON(bodyBytes))
+		return nil, fmt.Errorf("failed to unmarshal components: %w", err)
+	}
+
+	log.Printf("Successfully retrieved %d components for project %s", len(components), projectKeyOrId)
+	return components, nil
+}
+
+// ListProjectRoles returns the roles of a project as {"id", "name"} maps sorted by name.
+// Jira answers with an object mapping each role name to the role's URL, whose last
+// path segment is the role ID.
+func (jc *JiraClient) ListProjectRoles(ctx context.Context, projectKeyOrId string) ([]map[string]interface{}, error) {
+	endpoint := jc.apiPath(fmt.Sprintf("/project/%s/role", url.PathEscape(projectKeyOrId)))
+	resp, err := jc.makeRequest(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	bodyBytes, err := readResponseBody(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("project %s %w (or you do not have permission to view it)", projectKeyOrId, ErrNotFound)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, parseJiraError(resp.StatusCode, bodyBytes, "Errors")
+	}
+
+	// Parse response: {"Administrators": "https://.../rest/api/2/project/10000/role/10002", ...}
+	var roleURLs map[string]string
+	err = sonic.Unmarshal(bodyBytes, &roleURLs)
+	if err != nil {
+		log.Printf("Failed to unmarshal project roles response: %v, body: %s", err, RedactJSON(bodyBytes))
+		return nil, fmt.Errorf("failed to unmarshal project roles: %w", err)
+	}
+
+	roles := make([]map[string]interface{}, 0, len(roleURLs))
+	for _, name := range slices.Sorted(maps.Keys(roleURLs)) {
+		roleURL := strings.TrimRight(roleURLs[name], "/")
+		roles = append(roles, map[string]interface{}{
+			"id":   roleURL[strings.LastIndex(roleURL, "/")+1:],
+			"name": name,
+		})
+	}
+
+	log.Printf("Successfully retrieved %d roles for project %s", len(roles), projectKeyOrId)
+	return roles, nil
+}
+
+// GetProjectRole returns a project role with its actors. Jira returns all actors of
+// a role in one response (the endpoint isn't paginated); each actor is either a user
+// (actorUser) or a group (actorGroup).
+func (jc *JiraClient) GetProjectRole(ctx context.Context, projectKeyOrId, roleID string) (map[string]interface{}, error) {
+	endpoint := jc.apiPath(fmt.Sprintf("/project/%s/role/%s", url.PathEscape(projectKeyOrId), url.PathEscape(roleID)))
+	resp, err := jc.makeRequest(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	bodyBytes, err := readResponseBody(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("role %s of project %s %w", roleID, projectKeyOrId, ErrNotFound)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, parseJiraError(resp.StatusCode, bodyBytes, "Errors")
+	}
+
+	var role map[string]interface{}
+	err = sonic.Unmarshal(bodyBytes, &role)
+	if err != nil {
+		log.Printf("Failed to unmarshal project role response: %v, body: %s", err, RedactJSON(bodyBytes))
+		return nil, fmt.Errorf("failed to unmarshal project role: %w", err)
+	}
+
+	log.Printf("Successfully retrieved role %v of project %s", role["name"], projectKeyOrId)
+	return role, nil
+}
+
+// CreateComponent creates a component in a project. description and leadAccountId are optional.
+func (jc *JiraClient) CreateComponent(ctx context.Context, projectKey, name, description, leadAccountId string) (map[string]interface{}, error) {
+	// Build the request body
+	requestBody := map[string]interface{}{
+		"project": projectKey,
+		"name":    name,
+	}
+	if description != "" {
+		requestBody["description"] = description
+	}
+	if leadAccountId != "" {
+		requestBody["leadAccountId"] = leadAccountId
+	}
+
+	bodyBytes, err := sonic.Marshal(requestBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request body: %w", err)
+	}
+
+	log.Printf("Creating component %q in project %s", name, projectKey)
+
+	resp, err := jc.makeRequest(ctx, "POST", jc.apiPath("/component"), bytes.NewReader(bodyBytes))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	bodyBytes, err = readResponseBody(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check for errors (201 Created is success)
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		return nil, parseJiraError(resp.StatusCode, bodyBytes, "Missing or invalid fields")
+	}
+
+	// Parse response
+	var component map[string]interface{}
+	err = sonic.Unmarshal(bodyBytes, &component)
+	if err != nil {
+		log.Printf("Failed to unmarshal component response: %v, body: %s", err, RedactJSON(bodyBytes))
+		return nil, fmt.Errorf("failed to unmarshal component: %w", err)
+	}
+
+	log.Printf("Successfully created component %q in project %s", name, projectKey)
+	return component, nil
+}
+
+// DeleteComponent deletes a project component by ID
+func (jc *JiraClient) DeleteComponent(ctx context.Context, componentID string) error {
+	endpoint := jc.apiPath(fmt.Sprintf("/component/%s", url.PathEscape(componentID)))
+
+	log.Printf("Deleting Jira component: %s", componentID)
+
+	resp, err := jc.makeRequest(ctx, "DELETE", endpoint, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	bodyBytes, err := readResponseBody(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("component %s %w", componentID, ErrNotFound)
+	}
+	// 204 No Content is success for DELETE
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+		return parseJiraError(resp.StatusCode, bodyBytes, "Errors")
+	}
+
+	log.Printf("Successfully deleted Jira component: %s", componentID)
+	return nil
+}
+
+// ListVersions returns the versions of a project
+func (jc *JiraClient) ListVersions(ctx context.Context, projectKeyOrId string) ([]map[string]interface{}, error) {
+	endpoint := jc.apiPath(fmt.Sprintf("/project/%s/versions", url.PathEscape(projectKeyOrId)))
+	resp, err := jc.makeRequest(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	bodyBytes, err := readResponseBody(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("project %s %w (or you do not have permission to view it)", projectKeyOrId, ErrNotFound)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, parseJiraError(resp.StatusCode, bodyBytes, "Errors")
+	}
+
+	// Parse response
+	versions := []map[string]interface{}{}
+	err = sonic.Unmarshal(bodyBytes, &versions)
+	if err != nil {
+		log.Printf("Failed to unmarshal versions response: %v, body: %s", err, RedactJSON(bodyBytes))
+		return nil, fmt.Errorf("failed to unmarshal versions: %w", err)
+	}
+
+	log.Printf("Successfully retrieved %d versions for project %s", len(versions), projectKeyOrId)
+	return versions, nil
+}
+
+// CreateVersion creates a version in a project. description and releaseDate (YYYY-MM-DD) are optional.
+func (jc *JiraClient) CreateVersion(ctx context.Context, projectKey, name, description, releaseDate string) (map[string]interface{}, error) {
+	// Build the request body
+	requestBody := map[string]interface{}{
+		"project": projectKey,
+		"name":    name,
+	}
+	if description != "" {
+		requestBody["description"] = description
+	}
+	if releaseDate != "" {
+		requestBody["releaseDate"] = releaseDate
+	}
+
+	bodyBytes, err := sonic.Marshal(requestBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request body: %w", err)
+	}
+
+	log.Printf("Creating version %q in project %s", name, projectKey)
+
+	resp, err := jc.makeRequest(ctx, "POST", jc.apiPath("/version"), bytes.NewReader(bodyBytes))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	bodyBytes, err = readResponseBody(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check for errors (201 Created is success)
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		return nil, parseJiraError(resp.StatusCode, bodyBytes, "Missing or invalid fields")
+	}
+
+	// Parse response
+	var version map[string]interface{}
+	err = sonic.Unmarshal(bodyBytes, &version)
+	if err != nil {
+		log.Printf("Failed to unmarshal version response: %v, body: %s", err, RedactJSON(bodyBytes))
+		return nil, fmt.Errorf("failed to unmarshal version: %w", err)
+	}
+
+	log.Printf("Successfully created version %q in project %s", name, projectKey)
+	return version, nil
+}
+
+// ReleaseVersion sets the released flag of a version, optionally with a release date (YYYY-MM-DD)
+func (jc *JiraClient) ReleaseVersion(ctx context.Context, versionID string, released bool, releaseDate string) (map[string]interface{}, error) {
+	// Build the request body
+	requestBody := map[string]interface{}{
+		"released": released,
+	}
+	if releaseDate != "" {
+		requestBody["releaseDate"] = releaseDate
+	}
+
+	bodyBytes, err := sonic.Marshal(requestBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request body: %w", err)
+	}
+
+	endpoint := jc.apiPath(fmt.Sprintf("/version/%s", url.PathEscape(versionID)))
+	resp, err := jc.makeRequest(ctx, "PUT", endpoint, bytes.NewReader(bodyBytes))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	bodyBytes, err = readResponseBody(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("version %s %w", versionID, ErrNotFound)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, parseJiraError(resp.StatusCode, bodyBytes, "Missing or invalid fields")
+	}
+
+	// Parse response
+	var version map[string]interface{}
+	err = sonic.Unmarshal(bodyBytes, &version)
+	if err != nil {
+		log.Printf("Failed to unmarshal version response: %v, body: %s", err, RedactJSON(bodyBytes))
+		return nil, fmt.Errorf("failed to unmarshal version: %w", err)
+	}
+
+	log.Printf("Successfully updated version %s (released: %v)", versionID, released)
+	return version, nil
+}
+
+// ListBoards returns one page of Agile boards, optionally filtered by project.
+// The result contains "values", "startAt", "maxResults", "total" and "isLast".
+func (jc *JiraClient) ListBoards(ctx context.Context, projectKeyOrId string, startAt, maxResults int) (map[string]interface{}, error) {
+	params := url.Values{}
+	if projectKeyOrId != "" {
+		params.Set("projectKeyOrId", projectKeyOrId)
+	}
+	params.Set("startAt", strconv.Itoa(startAt))
+	params.Set("maxResults", strconv.Itoa(maxResults))
+	endpoint := agilePath("/board?" + params.Encode())
+
+	resp, err := jc.makeRequest(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	bodyBytes, err := readResponseBody(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("Agile API or project %s %w (is Jira Software installed?)", projectKeyOrId, ErrNotFound)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, parseJiraError(resp.StatusCode, bodyBytes, "Errors")
+	}
+
+	// Parse response
+	var boardsResult map[string]interface{}
+	err = sonic.Unmarshal(bodyBytes, &boardsResult)
+	if err != nil {
+		log.Printf("Failed to unmarshal boards response: %v, body: %s", err, RedactJSON(bodyBytes))
+		return nil, fmt.Errorf("failed to unmarshal boards: %w", err)
+	}
+
+	log.Printf("Successfully retrieved Jira boards (total: %v)", boardsResult["total"])
+	return boardsResult, nil
+}
+
+// ListSprints returns all sprints of a board, following pagination until the last page.
+// state is an optional comma-separated filter of active, future and closed.
+func (jc *JiraClient) ListSprints(ctx context.Context, boardID int, state string) ([]map[string]interface{}, error) {
+	sprints, _, err := fetchAllPages(ctx, jc, pageQuery[map[string]interface{}]{
+		name: "sprints",
+		endpoint: func(startAt, maxResults int) string {
+			params := url.Values{}
+			if state != "" {
+				params.Set("state", state)
+			}
+			params.Set("startAt", strconv.Itoa(startAt))
+			params.Set("maxResults", strconv.Itoa(maxResults))
+			return agilePath(fmt.Sprintf("/board/%d/sprint?%s", boardID, params.Encode()))
+		},
+		pageSize: sprintsPageSize,
+		notFound: fmt.Errorf("board %d %w (or you do not have permission to view it)", boardID, ErrNotFound),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("Successfully retrieved %d sprints for board %d", len(sprints), boardID)
+	return sprints, nil
+}
+
+// MoveIssuesToSprint moves issues into a sprint. Jira accepts at most 50 issues per
+// call, so the keys are sent in batches; every batch is attempted and the errors of
+// failed batches are joined into the returned error.
+func (jc *JiraClient) MoveIssuesToSprint(ctx context.Context, sprintID int, issueKeys []string) error {
+	if len(issueKeys) == 0 {
+		return errors.New("at least one issue key is required")
+	}
+
+	endpoint := agilePath(fmt.Sprintf("/sprint/%d/issue", sprintID))
+	var batchErrors []error
+	for start := 0; start < len(issueKeys); start += sprintIssuesBatchSize {
+		end := min(start+sprintIssuesBatchSize, len(issueKeys))
+		batch := issueKeys[start:end]
+
+		bodyBytes, err := sonic.Marshal(map[string]interface{}{"issues": batch})
+		if err != nil {
+			return fmt.Errorf("failed to marshal request body: %w", err)
+		}
+
+		log.Printf("Moving %d issues to sprint %d (batch %d-%d of %d)", len(batch), sprintID, start+1, end, len(issueKeys))
+
+		if err := jc.moveIssuesBatch(ctx, endpoint, sprintID, bodyBytes); err != nil {
+			batchErrors = append(batchErrors, fmt.Errorf("issues %s: %w", strings.Join(batch, ", "), err))
+			// A cancelled context fails every remaining batch too
+			if ctx.Err() != nil {
+				break
+			}
+		}
+	}
+
+	if len(batchErrors) > 0 {
+		return errors.Join(batchErrors...)
+	}
+	log.Printf("Successfully moved %d issues to sprint %d", len(issueKeys), sprintID)
+	return nil
+}
+
+// moveIssuesBatch sends a single move-to-sprint request
+func (jc *JiraClient) moveIssuesBatch(ctx context.Context, endpoint string, sprintID int, bodyBytes []byte) error {
+	resp, err := jc.makeRequest(ctx, "POST", endpoint, bytes.NewReader(bodyBytes))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	respBytes, err := readResponseBody(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("sprint %d %w (or you do not have permission to view it)", sprintID, ErrNotFound)
+	}
+	// 204 No Content is success
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+		return parseJiraError(resp.StatusCode, respBytes, "Errors")
+	}
+	return nil
+}
+
+// CreateSprint creates a future sprint on a board. Zero startDate/endDate and an empty goal are omitted.
+func (jc *JiraClient) CreateSprint(ctx context.Context, boardID int, name string, startDate, endDate time.Time, goal string) (map[string]interface{}, error) {
+	// Build the request body
+	requestBody := map[string]interface{}{
+		"originBoardId": boardID,
+		"name":          name,
+	}
+	if !startDate.IsZero() {
+		requestBody["startDate"] = startDate.Format(JiraTimeFormat)
+	}
+	if !endDate.IsZero() {
+		requestBody["endDate"] = endDate.Format(JiraTimeFormat)
+	}
+	if goal != "" {
+		requestBody["goal"] = goal
+	}
+
+	bodyBytes, err := sonic.Marshal(requestBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request body: %w", err)
+	}
+
+	log.Printf("Creating sprint %q on board %d", name, boardID)
+
+	resp, err := jc.makeRequest(ctx, "POST", agilePath("/sprint"), bytes.NewReader(bodyBytes))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	bodyBytes, err = readResponseBody(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check for errors (201 Created is success)
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		return nil, parseJiraError(resp.StatusCode, bodyBytes, "Missing or invalid fields")
+	}
+
+	// Parse response
+	var sprint map[string]interface{}
+	err = sonic.Unmarshal(bodyBytes, &sprint)
+	if err != nil {
+		log.Printf("Failed to unmarshal sprint response: %v, body: %s", err, RedactJSON(bodyBytes))
+		return nil, fmt.Errorf("failed to unmarshal sprint: %w", err)
+	}
+
+	log.Printf("Successfully created sprint %q on board %d", name, boardID)
+	return sprint, nil
+}
+
+// TransitionSprint changes the state of a sprint ("active" to start it, "closed" to complete it).
+// Non-zero startDate/endDate are sent along, which Jira requires when starting a sprint
+// that doesn't have them yet. This uses the partial update (POST) so other sprint
+// fields are left untouched; a full update (PUT) would clear any field not sent.
+func (jc *JiraClient) TransitionSprint(ctx context.Context, sprintID int, state string, startDate, endDate time.Time) (map[string]interface{}, error) {
+	// Build the request body
+	requestBody := map[string]interface{}{
+		"state": state,
+	}
+	if !startDate.IsZero() {
+		requestBody["startDate"] = startDate.Format(JiraTimeFormat)
+	}
+	if !endDate.IsZero() {
+		requestBody["endDate"] = endDate.Format(JiraTimeFormat)
+	}
+
+	bodyBytes, err := sonic.Marshal(requestBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request body: %w", err)
+	}
+
+	log.Printf("Transitioning sprint %d to state %s", sprintID, state)
+
+	endpoint := agilePath(fmt.Sprintf("/sprint/%d", sprintID))
+	resp, err := jc.makeRequest(ctx, "POST", endpoint, bytes.NewReader(bodyBytes))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	bodyBytes, err = readResponseBody(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("sprint %d %w (or you do not have permission to view it)", sprintID, ErrNotFound)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, parseJiraError(resp.StatusCode, bodyBytes, "Errors")
+	}
+
+	// Parse response
+	var sprint map[string]interface{}
+	err = sonic.Unmarshal(bodyBytes, &sprint)
+	if err != nil {
+		log.Printf("Failed to unmarshal sprint response: %v, body: %s", err, RedactJSON(bodyBytes))
+		return nil, fmt.Errorf("failed to unmarshal sprint: %w", err)
+	}
+
+	log.Printf("Successfully transitioned sprint %d to state %s", sprintID, state)
+	return sprint, nil
+}
+
+// projectsPage is one page of the /project/search response
+type projectsPage = jiraPage[map[string]interface{}]
+
+// parseProjectsPage decodes a /project/search page. Some proxies answer with a 200
+// and an error object or an HTML page instead, so the body shape is checked first
+// to report that clearly rather than as an unmarshal error or an empty project list.
+func parseProjectsPage(bodyBytes []byte) (*projectsPage, error) {
+	trimmed := bytes.TrimSpace(bodyBytes)
+	switch {
+	case len(trimmed) > 0 && trimmed[0] == '[':
+		// A plain array (the legacy /project endpoint) is a single, complete page
+		var projects []map[string]interface{}
+		if err := sonic.Unmarshal(trimmed, &projects); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal projects: %w", err)
+		}
+		return &projectsPage{Values: projects, Total: len(projects), IsLast: true}, nil
+	case len(trimmed) > 0 && trimmed[0] == '{':
+		var raw map[string]interface{}
+		if err := sonic.Unmarshal(trimmed, &raw); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal projects: %w", err)
+		}
+		if _, ok := raw["values"]; !ok {
+			// An error wrapper such as {"errorMessages": [...]} returned with status 200
+			apiErr := parseJiraError(http.StatusOK, trimmed, "Errors")
+			return nil, fmt.Errorf("unexpected projects response without a project list (is a proxy in front of Jira?): %w", apiErr)
+		}
+		var page projectsPage
+		if err := sonic.Unmarshal(trimmed, &page); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal projects: %w", err)
+		}
+		return &page, nil
+	}
+	return nil, fmt.Errorf("unexpected non-JSON projects response (is the instance URL pointing at Jira, or is a login page or proxy in the way?): %.200s", string(trimmed))
+}
+
+// CreateIssue creates a new issue in Jira
+func (jc *JiraClient) CreateIssue(ctx context.Context, projectKey, issueType, summary, description string, additionalFields map[string]interface{}) (map[string]interface{}, error) {
+	requestBody := map[string]interface{}{
+		"fields": jc.buildIssueFields(projectKey, issueType, summary, description, additionalFields),
+	}
+
+	// Marshal request body
+	bodyBytes, err := sonic.Marshal(requestBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request body: %w", err)
+	}
+
+	Debugf("Creating Jira issue with body: %s", RedactJSON(bodyBytes))
+
+	// Create request body reader
+	bodyReader := bytes.NewReader(bodyBytes)
+
+	// Make the API call
+	resp, err := jc.makeRequest(ctx, "POST", jc.apiPath("/issue"), bodyReader)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	// Read response body
+	bodyBytes, err = readResponseBody(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check for errors
+	if resp.StatusCode != http.StatusCreated {
+		return nil, parseJiraError(resp.StatusCode, bodyBytes, "Missing or invalid fields")
+	}
+
+	// Parse response
+	var issue map[string]interface{}
+	err = sonic.Unmarshal(bodyBytes, &issue)
+	if err != nil {
+		log.Printf("Failed to unmarshal issue response: %v, body: %s", err, RedactJSON(bodyBytes))
+		return nil, fmt.Errorf("failed to unmarshal issue: %w", err)
+	}
+
+	log.Printf("Successfully created Jira issue: %v", issue)
+	return issue, nil
+}
+
+// buildIssueFields builds the "fields" object for creating an issue
+func (jc *JiraClient) buildIssueFields(projectKey, issueType, summary, description string, additionalFields map[string]interface{}) map[string]interface{} {
+	fields := map[string]interface{}{
+		"project": map[string]interface{}{
+			"key": projectKey,
+		},
+		"summary": summary,
+		"issuetype": map[string]interface{}{
+			"name": issueType,
+		},
+	}
+
+	// Add description if provided
+	if description != "" {
+		fields["description"] = jc.formatRichText(description)
+	}
+
+	// Add any additional fields (like duedate, assignee, etc.).
+	// Only nil is skipped: explicit false, 0 and "" values are sent as provided.
+	for key, value := range additionalFields {
+		if value != nil {
+			fields[key] = value
+		}
+	}
+	return fields
+}
+
+// CreateSubtask creates a subtask of parentKey. issueType must be a subtask issue type
+// of the project; the parent is set in the issue fields.
+func (jc *JiraClient) CreateSubtask(ctx context.Context, parentKey, projectKey, issueType, summary, description string, additionalFields map[string]interface{}) (map[string]interface{}, error) {
+	fields := make(map[string]interface{}, len(additionalFields)+1)
+	maps.Copy(fields, additionalFields)
+	fields["parent"] = map[string]interface{}{"key": parentKey}
+	return jc.CreateIssue(ctx, projectKey, issueType, summary, description, fields)
+}
+
+// CommonIssueFields holds frequently set issue fields in plain form; Fields converts
+// them into the structures Jira expects. Empty values are left out.
+type CommonIssueFields struct {
+	// Priority is the priority name (e.g. High)
+	Priority string
+	// Labels must not contain spaces (see ValidateLabels)
+	Labels []string
+	// Components are component names
+	Components []string
+	// Assignee is the assignee's account ID
+	Assignee string
+	// OriginalEstimate and RemainingEstimate use Jira's duration format (see ValidateDuration)
+	OriginalEstimate  string
+	RemainingEstimate string
+}
+
+// Fields returns the Jira fields for the values that are set
+func (c CommonIssueFields) Fields() map[string]interface{} {
+	fields := map[string]interface{}{}
+	if c.Priority != "" {
+		fields["priority"] = map[string]interface{}{"name": c.Priority}
+	}
+	if len(c.Labels) > 0 {
+		fields["labels"] = c.Labels
+	}
+	if len(c.Components) > 0 {
+		components := make([]map[string]interface{}, 0, len(c.Components))
+		for _, name := range c.Components {
+			components = append(components, map[string]interface{}{"name": name})
+		}
+		fields["components"] = components
+	}
+	if c.Assignee != "" {
+		fields["assignee"] = map[string]interface{}{"accountId": c.Assignee}
+	}
+	// Both estimates live in the timetracking field
+	if c.OriginalEstimate != "" || c.RemainingEstimate != "" {
+		timetracking := map[string]interface{}{}
+		if c.OriginalEstimate != "" {
+			timetracking["originalEstimate"] = c.OriginalEstimate
+		}
+		if c.RemainingEstimate != "" {
+			timetracking["remainingEstimate"] = c.RemainingEstimate
+		}
+		fields["timetracking"] = timetracking
+	}
+	return fields
+}
+
+// jiraDuration matches Jira's duration format: one or more numbers with a unit of
+// w(eeks), d(ays), h(ours) or m(inutes), e.g. "2w 3d 4h" or "1.5h"
+var jiraDuration = regexp.MustCompile(`^(?i)\d+(\.\d+)?[wdhm](\s*\d+(\.\d+)?[wdhm])*$`)
+
+// ValidateDuration rejects values Jira wouldn't accept as a time estimate
+func ValidateDuration(duration string) error {
+	if !jiraDuration.MatchString(strings.TrimSpace(duration)) {
+		return fmt.Errorf("invalid duration %q: use Jira's format, e.g. \"2w 3d 4h 30m\"", duration)
+	}
+	return nil
+}
+
+// ValidateLabels rejects labels Jira would refuse: empty ones and ones containing whitespace
+func ValidateLabels(labels []string) error {
+	for _, label := range labels {
+		if label == "" || strings.ContainsFunc(label, unicode.IsSpace) {
+			return fmt.Errorf("invalid label %q: labels cannot be empty or contain spaces", label)
+		}
+	}
+	return nil
+}
+
+// BulkCreateIssues creates several issues via /issue/bulk, sending at most 50 per request.
+// Each input has projectKey, issueType, summary and optional description and
+// additionalFields. A failing row (or batch) does not stop the others: the result
+// holds one entry per input in "issues" (with "index" and either "key"/"id" or
+// "error" plus the structured "status", "errorMessages" and "fieldErrors"), the
+// "created" and "failed" counts, and any "warnings" Jira returned. onBatch, if not
+// nil, is called after every batch with the number of inputs processed so far.
+func (jc *JiraClient) BulkCreateIssues(ctx context.Context, issues []map[string]interface{}, onBatch func(done, total int)) (map[string]interface{}, error) {
+	if len(issues) == 0 {
+		return nil, errors.New("at least one issue is required")
+	}
+
+	results := make([]map[string]interface{}, len(issues))
+	warnings := []string{}
+	for start := 0; start < len(issues); start += bulkCreateBatchSize {
+		end := min(start+bulkCreateBatchSize, len(issues))
+		log.Printf("Bulk creating Jira issues %d-%d of %d", start+1, end, len(issues))
+
+		batchResults, batchWarnings, err := jc.bulkCreateBatch(ctx, issues[start:end])
+		warnings = append(warnings, batchWarnings...)
+		if err != nil {
+			// The whole request failed, so every row of the batch failed with it
+			for i := start; i < end; i++ {
+				results[i] = bulkRowFailure(err)
+				results[i]["index"] = i
+			}
+			if onBatch != nil {
+				onBatch(end, len(issues))
+			}
+			continue
+		}
+		for i, result := range batchResults {
+			result["index"] = start + i
+			results[start+i] = result
+		}
+		if onBatch != nil {
+			onBatch(end, len(issues))
+		}
+	}
+
+	created, failed := 0, 0
+	for _, result := range results {
+		if _, ok := result["error"]; ok {
+			failed++
+		} else {
+			created++
+		}
+	}
+
+	log.Printf("Bulk create finished: %d created, %d failed, %d warnings", created, failed, len(warnings))
+	return map[string]interface{}{
+		"issues":   results,
+		"created":  created,
+		"failed":   failed,
+		"warnings": warnings,
+	}, nil
+}
+
+// bulkCreateResponse is the body of an /issue/bulk response. Jira returns it with
+// 201 when at least one row was created and with 400 when every row failed.
+type bulkCreateResponse struct {
+	Issues []map[string]interface{} `json:"issues"`
+	Errors []struct {
+		Status int `json:"status"`
+		// ElementErrors has the shape of a regular Jira error body
+		ElementErrors struct {
+			ErrorMessages   []string               `json:"errorMessages"`
+			Errors          map[string]interface{} `json:"errors"`
+			WarningMessages []string               `json:"warningMessages"`
+		} `json:"elementErrors"`
+		FailedElementNumber int `json:"failedElementNumber"`
+	} `json:"errors"`
+	WarningMessages []string `json:"warningMessages"`
+}
+
+// parseBulkCreateResponse turns an /issue/bulk response for rows input rows into one
+// result per row, plus the warnings Jira reported for the batch and its rows.
+// Created issues are listed in input order without the failed rows, so they are
+// matched to the rows that have no error.
+func parseBulkCreateResponse(bodyBytes []byte, rows int) ([]map[string]interface{}, []string, error) {
+	var bulkResult bulkCreateResponse
+	if err := sonic.Unmarshal(bodyBytes, &bulkResult); err != nil {
+		return nil, nil, err
+	}
+
+	warnings := append([]string{}, bulkResult.WarningMessages...)
+	results := make([]map[string]interface{}, rows)
+	for _, rowErr := range bulkResult.Errors {
+		if rowErr.FailedElementNumber < 0 || rowErr.FailedElementNumber >= rows {
+			continue
+		}
+		apiErr := &JiraAPIError{
+			StatusCode:       rowErr.Status,
+			ErrorMessages:    rowErr.ElementErrors.ErrorMessages,
+			fieldErrorsLabel: "Missing or invalid fields",
+		}
+		if len(rowErr.ElementErrors.Errors) > 0 {
+			apiErr.Errors = make(map[string]string, len(rowErr.ElementErrors.Errors))
+			for field, value := range rowErr.ElementErrors.Errors {
+				apiErr.Errors[field] = stringifyErrorValue(value)
+			}
+		}
+		result := bulkRowFailure(apiErr)
+		if len(rowErr.ElementErrors.WarningMessages) > 0 {
+			result["warnings"] = rowErr.ElementErrors.WarningMessages
+			for _, warning := range rowErr.ElementErrors.WarningMessages {
+				warnings = append(warnings, fmt.Sprintf("issue %d: %s", rowErr.FailedElementNumber, warning))
+			}
+		}
+		results[rowErr.FailedElementNumber] = result
+	}
+
+	created := bulkResult.Issues
+	for i := range results {
+		if results[i] != nil {
+			continue
+		}
+		if len(created) == 0 {
+			results[i] = map[string]interface{}{"error": "Jira did not report a result for this issue"}
+			continue
+		}
+		results[i] = map[string]interface{}{
+			"key": created[0]["key"],
+			"id":  created[0]["id"],
+		}
+		created = created[1:]
+	}
+	return results, warnings, nil
+}
+
+// bulkRowFailure describes a failed bulk row. Jira errors are broken down into their
+// status, general messages and per-field messages next to the readable "error".
+func bulkRowFailure(err error) map[string]interface{} {
+	result := map[string]interface{}{"error": err.Error()}
+	var apiErr *JiraAPIError
+	if errors.As(err, &apiErr) {
+		if apiErr.StatusCode != 0 {
+			result["status"] = apiErr.StatusCode
+		}
+		if len(apiErr.ErrorMessages) > 0 {
+			result["errorMessages"] = apiErr.ErrorMessages
+		}
+		if len(apiErr.Errors) > 0 {
+			result["fieldErrors"] = apiErr.Errors
+		}
+	}
+	return result
+}
+
+// bulkCreateBatch sends one /issue/bulk request and returns a result per input row
+// and the warnings Jira reported
+func (jc *JiraClient) bulkCreateBatch(ctx context.Context, issues []map[string]interface{}) ([]map[string]interface{}, []string, error) {
+	issueUpdates := make([]map[string]interface{}, 0, len(issues))
+	for _, issue := range issues {
+		projectKey, _ := issue["projectKey"].(string)
+		issueType, _ := issue["issueType"].(string)
+		summary, _ := issue["summary"].(string)
+		description, _ := issue["description"].(string)
+		additionalFields, _ := issue["additionalFields"].(map[string]interface{})
+		issueUpdates = append(issueUpdates, map[string]interface{}{
+			"fields": jc.buildIssueFields(projectKey, issueType, summary, description, additionalFields),
+		})
+	}
+
+	bodyBytes, err := sonic.Marshal(map[string]interface{}{"issueUpdates": issueUpdates})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal request body: %w", err)
+	}
+
+	resp, err := jc.makeRequest(ctx, "POST", jc.apiPath("/issue/bulk"), bytes.NewReader(bodyBytes))
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+
+	bodyBytes, err = readResponseBody(resp)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// 201 means at least one issue was created; 400 with the same body means every row failed
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusBadRequest {
+		return nil, nil, parseJiraError(resp.StatusCode, bodyBytes, "Missing or invalid fields")
+	}
+
+	results, warnings, err := parseBulkCreateResponse(bodyBytes, len(issues))
+	if err != nil {
+		log.Printf("Failed to unmarshal bulk create response: %v, body: %s", err, RedactJSON(bodyBytes))
+		if resp.StatusCode == http.StatusBadRequest {
+			return nil, nil, parseJiraError(resp.StatusCode, bodyBytes, "Missing or invalid fields")
+		}
+		return nil, nil, fmt.Errorf("failed to unmarshal bulk create result: %w", err)
+	}
+	for _, warning := range warnings {
+		log.Printf("Jira bulk create warning: %s", warning)
+	}
+	return results, warnings, nil
+}
+
+// ListFields returns all system and custom fields of the Jira instance.
+// Fields rarely change, so the list is cached for CacheTTL; callers must not modify
+// it. Call InvalidateCache to force a refresh.
+func (jc *JiraClient) ListFields(ctx context.Context) ([]map[string]interface{}, error) {
+	return cachedLookup(jc, "fieldlist", func() ([]map[string]interface{}, error) {
+		return jc.listFields(ctx)
+	})
+}
+
+// listFields fetches the /field list
+func (jc *JiraClient) listFields(ctx context.Context) ([]map[string]interface{}, error) {
+	resp, err := jc.makeRequest(ctx, "GET", jc.apiPath("/field"), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	bodyBytes, err := readResponseBody(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, parseJiraError(resp.StatusCode, bodyBytes, "Errors")
+	}
+
+	// Parse response
+	fields := []map[string]interface{}{}
+	err = sonic.Unmarshal(bodyBytes, &fields)
+	if err != nil {
+		log.Printf("Failed to unmarshal fields response: %v, body: %s", err, RedactJSON(bodyBytes))
+		return nil, fmt.Errorf("failed to unmarshal fields: %w", err)
+	}
+
+	log.Printf("Successfully retrieved %d Jira fields", len(fields))
+	return fields, nil
+}
+
+// fieldIndex is the cached lookup table built from the /field list
+type fieldIndex struct {
+	ids    map[string]bool
+	byName map[string]string
+}
+
+// loadFields returns the field ID set and the lowercased name-to-ID map (cached)
+func (jc *JiraClient) loadFields(ctx context.Context) (map[string]bool, map[string]string, error) {
+	index, err := cachedLookup(jc, "fields", func() (*fieldIndex, error) {
+		fields, err := jc.ListFields(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		index := &fieldIndex{
+			ids:    make(map[string]bool, len(fields)),
+			byName: make(map[string]string, len(fields)),
+		}
+		for _, field := range fields {
+			id, _ := field["id"].(string)
+			name, _ := field["name"].(string)
+			if id == "" {
+				continue
+			}
+			index.ids[id] = true
+			if name != "" {
+				index.byName[strings.ToLower(name)] = id
+			}
+		}
+		return index, nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return index.ids, index.byName, nil
+}
+
+// ResolveFieldIDs maps field names (e.g. "Story Points") to field IDs (e.g. customfield_10016).
+// Names are matched case-insensitively; values that already are field IDs map to themselves.
+// Names that match no field are left out of the result.
+func (jc *JiraClient) ResolveFieldIDs(ctx context.Context, names []string) (map[string]string, error) {
+	fieldIDs, fieldsByName, err := jc.loadFields(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resolved := make(map[string]string, len(names))
+	for _, name := range names {
+		if fieldIDs[name] {
+			resolved[name] = name
+		} else if id, ok := fieldsByName[strings.ToLower(strings.TrimSpace(name))]; ok {
+			resolved[name] = id
+		}
+	}
+	return resolved, nil
+}
+
+// TranslateFieldNames returns a copy of fields where keys given as field names are
+// replaced by their field IDs, plus the name-to-ID mapping that was applied.
+// Keys that already look like field IDs are never looked up, and if the field
+// list can't be fetched the fields are returned unchanged.
+func (jc *JiraClient) TranslateFieldNames(ctx context.Context, fields map[string]interface{}) (map[string]interface{}, map[string]string) {
+	var names []string
+	for key := range fields {
+		if !looksLikeFieldID(key) {
+			names = append(names, key)
+		}
+	}
+	if len(names) == 0 {
+		return fields, nil
+	}
+
+	resolved, err := jc.ResolveFieldIDs(ctx, names)
+	if err != nil {
+		log.Printf("Failed to resolve field names %v, sending them unchanged: %v", names, err)
+		return fields, nil
+	}
+
+	translated := make(map[string]interface{}, len(fields))
+	applied := make(map[string]string)
+	for key, value := range fields {
+		if id, ok := resolved[key]; ok && id != key {
+			translated[id] = value
+			applied[key] = id
+		} else {
+			translated[key] = value
+		}
+	}
+	if len(applied) > 0 {
+		log.Printf("Resolved field names to IDs: %v", applied)
+	}
+	return translated, applied
+}
+
+// looksLikeFieldID reports whether a key is a custom field ID or a single lowercase/camelCase
+// word such as "duedate" or "fixVersions", which are treated as IDs without a lookup
+func looksLikeFieldID(key string) bool {
+	if strings.HasPrefix(key, "customfield_") {
+		return true
+	}
+	if key == "" || strings.ContainsAny(key, " -_") {
+		return false
+	}
+	first := key[0]
+	return first >= 'a' && first <= 'z'
+}
+
+// GetIssue retrieves a single issue by key or ID.
+// fields and expand are optional and restrict/extend the returned data.
+func (jc *JiraClient) GetIssue(ctx context.Context, issueKeyOrId string, fields []string, expand []string) (map[string]interface{}, error) {
+	// Build the endpoint with optional query parameters
+	endpoint := jc.apiPath(fmt.Sprintf("/issue/%s", url.PathEscape(issueKeyOrId)))
+	query := readQuery(fields, expand)
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+
+	resp, err := jc.makeRequest(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	bodyBytes, err := readResponseBody(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("issue %s %w (or you do not have permission to view it)", issueKeyOrId, ErrNotFound)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, parseJiraError(resp.StatusCode, bodyBytes, "Errors")
+	}
+
+	// Parse response
+	var issue map[string]interface{}
+	err = sonic.Unmarshal(bodyBytes, &issue)
+	if err != nil {
+		log.Printf("Failed to unmarshal issue response: %v, body: %s", err, RedactJSON(bodyBytes))
+		return nil, fmt.Errorf("failed to unmarshal issue: %w", err)
+	}
+
+	log.Printf("Successfully retrieved Jira issue: %s", issueKeyOrId)
+	return issue, nil
+}
+
+// DeleteIssue deletes an issue from Jira by issue key or ID
+func (jc *JiraClient) DeleteIssue(ctx context.Context, issueKeyOrId string, deleteSubtasks bool) error {
+	// Build the endpoint with optional query parameter
+	endpoint := jc.apiPath(fmt.Sprintf("/issue/%s", url.PathEscape(issueKeyOrId)))
+	if deleteSubtasks {
+		endpoint += "?deleteSubtasks=true"
+	}
+
+	log.Printf("Deleting Jira issue: %s (deleteSubtasks: %v)", issueKeyOrId, deleteSubtasks)
+
+	// Make the DELETE request
+	resp, err := jc.makeRequest(ctx, "DELETE", endpoint, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// Read response body (even for successful deletes, there might be a response)
+	bodyBytes, err := readResponseBody(resp)
+	if err != nil {
+		return err
+	}
+
+	// Check for errors (204 No Content is success for DELETE)
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+		return parseJiraError(resp.StatusCode, bodyBytes, "Errors")
+	}
+
+	log.Printf("Successfully deleted Jira issue: %s", issueKeyOrId)
+	return nil
+}
+
+// BulkDeleteIssues deletes several issues. Jira has no bulk delete endpoint, so the
+// issues are deleted one by one by a bounded pool of workers. A failing delete does
+// not stop the others: the result maps every key in "issues" to either
+// {"deleted": true} or {"deleted": false, "error": ..., "code": ...}, plus "deleted"
+// and "failed" counts. onDelete, if not nil, is called after every processed issue
+// with the number of issues processed so far.
+func (jc *JiraClient) BulkDeleteIssues(ctx context.Context, keys []string, deleteSubtasks bool, onDelete func(done, total int)) (map[string]interface{}, error) {
+	if len(keys) == 0 {
+		return nil, errors.New("at least one issue key is required")
+	}
+
+	// Deleting the same issue twice would report a spurious not_found for the second
+	// call, which forEachIssue prevents
+	results, deleted, failed := forEachIssue(keys, "deleted", func(key string) (map[string]interface{}, error) {
+		return map[string]interface{}{}, jc.DeleteIssue(ctx, key, deleteSubtasks)
+	}, onDelete)
+
+	log.Printf("Bulk delete finished: %d deleted, %d failed", deleted, failed)
+	return map[string]interface{}{
+		"issues":  results,
+		"deleted": deleted,
+		"failed":  failed,
+	}, nil
+}
+
+// dedupeKeys returns keys without repetitions, in their original order
+func dedupeKeys(keys []string) []string {
+	unique := make([]string, 0, len(keys))
+	seen := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		if !seen[key] {
+			seen[key] = true
+			unique = append(unique, key)
+		}
+	}
+	return unique
+}
+
+// forEachIssue runs op once for every distinct key, at most bulkIssueWorkers at a time,
+// for bulk operations Jira has no endpoint for. A failing key does not stop the others.
+// results maps every key to op's outcome with doneKey set to true, or to
+// {doneKey: false, "error": ..., "code": ...} if op failed; succeeded and failed count
+// them. onDone, if not nil, is called after every key with the number processed so far.
+func forEachIssue(keys []string, doneKey string, op func(key string) (map[string]interface{}, error), onDone func(done, total int)) (results map[string]interface{}, succeeded, failed int) {
+	uniqueKeys := dedupeKeys(keys)
+
+	jobs := make(chan string)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	results = make(map[string]interface{}, len(uniqueKeys))
+	for range min(bulkIssueWorkers, len(uniqueKeys)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for key := range jobs {
+				outcome, err := op(key)
+
+				mu.Lock()
+				if err != nil {
+					results[key] = map[string]interface{}{doneKey: false, "error": err.Error(), "code": ErrorCode(err)}
+					failed++
+				} else {
+					if outcome == nil {
+						outcome = map[string]interface{}{}
+					}
+					outcome[doneKey] = true
+					results[key] = outcome
+					succeeded++
+				}
+				done := succeeded + failed
+				mu.Unlock()
+
+				if onDone != nil {
+					onDone(done, len(uniqueKeys))
+				}
+			}
+		}()
+	}
+	for _, key := range uniqueKeys {
+		jobs <- key
+	}
+	close(jobs)
+	wg.Wait()
+	return results, succeeded, failed
+}
+
+// IsServiceDeskIssue reports whether an issue belongs to a Jira Service Management
+// project, the only kind where comments can be internal
+func (jc *JiraClient) IsServiceDeskIssue(ctx context.Context, issueKeyOrId string) (bool, error) {
+	issue, err := jc.GetIssue(ctx, issueKeyOrId, []string{"project"}, nil)
+	if err != nil {
+		return false, err
+	}
+	fields, _ := issue["fields"].(map[string]interface{})
+	project, _ := fields["project"].(map[string]interface{})
+	projectType, _ := project["projectTypeKey"].(string)
+	return projectType == ServiceDeskProjectType, nil
+}
+
+// AddComment adds a comment to a Jira issue. internal marks the comment as agent-only
+// on Jira Service Management projects; see IsServiceDeskIssue.
+func (jc *JiraClient) AddComment(ctx context.Context, issueKeyOrId, commentBody string, visibility map[string]interface{}, additionalFields map[string]interface{}, internal bool) (map[string]interface{}, error) {
+	// Build the request body
+	requestBody := map[string]interface{}{
+		"body": jc.formatRichText(commentBody),
+	}
+
+	// Add visibility if provided
+	if len(visibility) > 0 {
+		requestBody["visibility"] = visibility
+	}
+
+	// Add any additional fields (for future Jira API extensions or custom fields)
+	// Only nil is skipped: explicit false, 0 and "" values are sent as provided.
+	for key, value := range additionalFields {
+		if value != nil {
+			requestBody[key] = value
+		}
+	}
+
+	// Internal comments carry the JSM property, next to any properties passed in additionalFields
+	if internal {
+		properties, _ := requestBody["properties"].([]interface{})
+		requestBody["properties"] = append(properties, map[string]interface{}{
+			"key":   internalCommentProperty,
+			"value": map[string]interface{}{"internal": true},
+		})
+	}
+
+	// Marshal request body
+	bodyBytes, err := sonic.Marshal(requestBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request body: %w", err)
+	}
+
+	Debugf("Adding comment to Jira issue %s with body: %s", issueKeyOrId, RedactJSON(bodyBytes))
+
+	// Create request body reader
+	bodyReader := bytes.NewReader(bodyBytes)
+
+	// Build the endpoint
+	endpoint := jc.apiPath(fmt.Sprintf("/issue/%s/comment", url.PathEscape(issueKeyOrId)))
+
+	// Make the API call
+	resp, err := jc.makeRequest(ctx, "POST", endpoint, bodyReader)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	// Read response body
+	bodyBytes, err = readResponseBody(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check for errors (201 Created is success for POST comment)
+	if resp.StatusCode != http.StatusCreated {
+		return nil, parseJiraError(resp.StatusCode, bodyBytes, "Errors")
+	}
+
+	// Parse response
+	var comment map[string]interface{}
+	err = sonic.Unmarshal(bodyBytes, &comment)
+	if err != nil {
+		log.Printf("Failed to unmarshal comment response: %v, body: %s", err, RedactJSON(bodyBytes))
+		return nil, fmt.Errorf("failed to unmarshal comment: %w", err)
+	}
+
+	log.Printf("Successfully added comment %v to Jira issue %s", comment["id"], issueKeyOrId)
+	return comment, nil
+}
+
+// BulkAddComments adds the same comment to several issues. Like BulkDeleteIssues, the
+// comments are added one by one by a bounded pool of workers and a failure does not
+// stop the others: the result maps every key in "issues" to either
+// {"added": true, "commentId": ...} or {"added": false, "error": ..., "code": ...}, plus
+// "added" and "failed" counts. Repeated keys are commented on once. onComment, if not
+// nil, is called after every processed issue with the number of issues processed so far.
+func (jc *JiraClient) BulkAddComments(ctx context.Context, keys []string, commentBody string, onComment func(done, total int)) (map[string]interface{}, error) {
+	if len(keys) == 0 {
+		return nil, errors.New("at least one issue key is required")
+	}
+
+	results, added, failed := forEachIssue(keys, "added", func(key string) (map[string]interface{}, error) {
+		comment, err := jc.AddComment(ctx, key, commentBody, nil, nil, false)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"commentId": comment["id"]}, nil
+	}, onComment)
+
+	log.Printf("Bulk comment finished: %d added, %d failed", added, failed)
+	return map[string]interface{}{
+		"issues": results,
+		"added":  added,
+		"failed": failed,
+	}, nil
+}
+
+// ListComments retrieves a page of comments for a Jira issue
+func (jc *JiraClient) ListComments(ctx context.Context, issueKeyOrId string, startAt, maxResults int) (map[string]interface{}, error) {
+	endpoint := jc.apiPath(fmt.Sprintf("/issue/%s/comment?startAt=%d&maxResults=%d", url.PathEscape(issueKeyOrId), startAt, maxResults))
+
+	resp, err := jc.makeRequest(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	bodyBytes, err := readResponseBody(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("issue %s %w (or you do not have permission to view it)", issueKeyOrId, ErrNotFound)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, parseJiraError(resp.StatusCode, bodyBytes, "Errors")
+	}
+
+	// Parse response: {"startAt": 0, "maxResults": 50, "total": 1, "comments": [...]}
+	var commentsResult map[string]interface{}
+	err = sonic.Unmarshal(bodyBytes, &commentsResult)
+	if err != nil {
+		log.Printf("Failed to unmarshal comments response: %v, body: %s", err, RedactJSON(bodyBytes))
+		return nil, fmt.Errorf("failed to unmarshal comments: %w", err)
+	}
+
+	log.Printf("Successfully retrieved comments for Jira issue %s (total: %v)", issueKeyOrId, commentsResult["total"])
+	return commentsResult, nil
+}
+
+// GetIssueChangelog returns one page of an issue's change history
+func (jc *JiraClient) GetIssueChangelog(ctx context.Context, issueKeyOrId string, startAt, maxResults int) (map[string]interface{}, error) {
+	endpoint := jc.apiPath(fmt.Sprintf("/issue/%s/changelog?startAt=%d&maxResults=%d", url.PathEscape(issueKeyOrId), startAt, maxResults))
+
+	resp, err := jc.makeRequest(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	bodyBytes, err := readResponseBody(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("issue %s %w (or you do not have permission to view it)", issueKeyOrId, ErrNotFound)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, parseJiraError(resp.StatusCode, bodyBytes, "Errors")
+	}
+
+	// Parse response: {"startAt": 0, "maxResults": 100, "total": 1, "isLast": true, "values": [...]}
+	var changelog map[string]interface{}
+	err = sonic.Unmarshal(bodyBytes, &changelog)
+	if err != nil {
+		log.Printf("Failed to unmarshal changelog response: %v, body: %s", err, RedactJSON(bodyBytes))
+		return nil, fmt.Errorf("failed to unmarshal changelog: %w", err)
+	}
+
+	log.Printf("Successfully retrieved changelog for Jira issue %s (total: %v)", issueKeyOrId, changelog["total"])
+	return changelog, nil
+}
+
+// UpdateComment replaces the body of an existing comment and returns the updated comment
+func (jc *JiraClient) UpdateComment(ctx context.Context, issueKeyOrId, commentID, body string) (map[string]interface{}, error) {
+	requestBody := map[string]interface{}{
+		"body": jc.formatRichText(body),
+	}
+
+	// Marshal request body
+	bodyBytes, err := sonic.Marshal(requestBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request body: %w", err)
+	}
+
+	log.Printf("Updating comment %s on Jira issue %s", commentID, issueKeyOrId)
+
+	// Build the endpoint
+	endpoint := jc.apiPath(fmt.Sprintf("/issue/%s/comment/%s", url.PathEscape(issueKeyOrId), url.PathEscape(commentID)))
+
+	// Make the API call
+	resp, err := jc.makeRequest(ctx, "PUT", endpoint, bytes.NewReader(bodyBytes))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	// Read response body
+	bodyBytes, err = readResponseBody(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("comment %s on issue %s %w", commentID, issueKeyOrId, ErrNotFound)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, parseJiraError(resp.StatusCode, bodyBytes, "Errors")
+	}
+
+	// Parse response
+	var comment map[string]interface{}
+	err = sonic.Unmarshal(bodyBytes, &comment)
+	if err != nil {
+		log.Printf("Failed to unmarshal comment response: %v, body: %s", err, RedactJSON(bodyBytes))
+		return nil, fmt.Errorf("failed to unmarshal comment: %w", err)
+	}
+
+	log.Printf("Successfully updated comment %s on Jira issue %s", commentID, issueKeyOrId)
+	return comment, nil
+}
+
+// DeleteComment deletes a comment from a Jira issue
+func (jc *JiraClient) DeleteComment(ctx context.Context, issueKeyOrId, commentID string) error {
+	log.Printf("Deleting comment %s from Jira issue %s", commentID, issueKeyOrId)
+
+	// Build the endpoint
+	endpoint := jc.apiPath(fmt.Sprintf("/issue/%s/comment/%s", url.PathEscape(issueKeyOrId), url.PathEscape(commentID)))
+
+	// Make the DELETE request
+	resp, err := jc.makeRequest(ctx, "DELETE", endpoint, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// Read response body
+	bodyBytes, err := readResponseBody(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("comment %s on issue %s %w", commentID, issueKeyOrId, ErrNotFound)
+	}
+	// Check for errors (204 No Content is success for DELETE)
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+		return parseJiraError(resp.StatusCode, bodyBytes, "Errors")
+	}
+
+	log.Printf("Successfully deleted comment %s from Jira issue %s", commentID, issueKeyOrId)
+	return nil
+}
+
+// AddAttachment uploads a file as an attachment to a Jira issue
+func (jc *JiraClient) AddAttachment(ctx context.Context, issueKeyOrId, filename string, content []byte) ([]map[string]interface{}, error) {
+	// Build the multipart body in memory so it can be replayed on retries
+	var buf bytes.Buffer
+	writer := multipart.NewWriter(&buf)
+	part, err := writer.CreateFormFile("file", filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create multipart form: %w", err)
+	}
+	if _, err := part.Write(content); err != nil {
+		return nil, fmt.Errorf("failed to write attachment content: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("failed to finalize multipart form: %w", err)
+	}
+
+	log.Printf("Uploading attachment %s (%d bytes) to Jira issue %s", filename, len(content), issueKeyOrId)
+
+	// Build the endpoint
+	endpoint := jc.apiPath(fmt.Sprintf("/issue/%s/attachments", url.PathEscape(issueKeyOrId)))
+
+	// Jira requires the XSRF check to be disabled for attachment uploads
+	headers := map[string]string{
+		"Content-Type":      writer.FormDataContentType(),
+		"X-Atlassian-Token": "no-check",
+	}
+
+	// Make the API call
+	resp, err := jc.makeRequestWithHeaders(ctx, "POST", endpoint, &buf, headers)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	// Read response body
+	bodyBytes, err := readResponseBody(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("issue %s %w (or you do not have permission to attach files)", issueKeyOrId, ErrNotFound)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, parseJiraError(resp.StatusCode, bodyBytes, "Errors")
+	}
+
+	// Parse response: an array of the created attachments
+	var attachments []map[string]interface{}
+	err = sonic.Unmarshal(bodyBytes, &attachments)
+	if err != nil {
+		log.Printf("Failed to unmarshal attachments response: %v, body: %s", err, RedactJSON(bodyBytes))
+		return nil, fmt.Errorf("failed to unmarshal attachments: %w", err)
+	}
+
+	log.Printf("Successfully uploaded %d attachment(s) to Jira issue %s", len(attachments), issueKeyOrId)
+	return attachments, nil
+}
+
+// MaxAttachmentDownloadSize caps the size of an attachment DownloadAttachment will fetch
+const MaxAttachmentDownloadSize = 10 << 20
+
+// GetIssueAttachments returns the attachment metadata of an issue (id, filename, size,
+// mimeType, content download URL, ...), read from the issue's attachment field
+func (jc *JiraClient) GetIssueAttachments(ctx context.Context, issueKeyOrId string) ([]map[string]interface{}, error) {
+	issue, err := jc.GetIssue(ctx, issueKeyOrId, []string{"attachment"}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	fields, _ := issue["fields"].(map[string]interface{})
+	rawAttachments, _ := fields["attachment"].([]interface{})
+	attachments := make([]map[string]interface{}, 0, len(rawAttachments))
+	for _, raw := range rawAttachments {
+		if attachment, ok := raw.(map[string]interface{}); ok {
+			attachments = append(attachments, attachment)
+		}
+	}
+
+	log.Printf("Found %d attachments on Jira issue %s", len(attachments), issueKeyOrId)
+	return attachments, nil
+}
+
+// GetAttachment retrieves the metadata of a single attachment
+func (jc *JiraClient) GetAttachment(ctx context.Context, attachmentID string) (map[string]interface{}, error) {
+	endpoint := jc.apiPath(fmt.Sprintf("/attachment/%s", url.PathEscape(attachmentID)))
+
+	resp, err := jc.makeRequest(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	bodyBytes, err := readResponseBody(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("attachment %s %w (or you do not have permission to view it)", attachmentID, ErrNotFound)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, parseJiraError(resp.StatusCode, bodyBytes, "Errors")
+	}
+
+	var attachment map[string]interface{}
+	err = sonic.Unmarshal(bodyBytes, &attachment)
+	if err != nil {
+		log.Printf("Failed to unmarshal attachment response: %v, body: %s", err, RedactJSON(bodyBytes))
+		return nil, fmt.Errorf("failed to unmarshal attachment: %w", err)
+	}
+
+	return attachment, nil
+}
+
+// DownloadAttachment fetches the content of an attachment and returns it with its
+// filename. Attachments larger than MaxAttachmentDownloadSize are refused. The
+// content URL comes from the attachment metadata; credentials are only sent to it
+// if it is on the configured Jira instance.
+func (jc *JiraClient) DownloadAttachment(ctx context.Context, attachmentID string) ([]byte, string, error) {
+	attachment, err := jc.GetAttachment(ctx, attachmentID)
+	if err != nil {
+		return nil, "", err
+	}
+	filename, _ := attachment["filename"].(string)
+	if size, ok := attachment["size"].(float64); ok && size > MaxAttachmentDownloadSize {
+		return nil, "", fmt.Errorf("attachment %s is %d bytes, larger than the %d byte download limit", attachmentID, int64(size), MaxAttachmentDownloadSize)
+	}
+
+	contentURL, _ := attachment["content"].(string)
+	// OAuth clients get content URLs on the site, but must download through the gateway
+	endpoint, ok := strings.CutPrefix(contentURL, jc.baseURL())
+	if !ok && jc.SiteURL != "" {
+		endpoint, ok = strings.CutPrefix(contentURL, jc.siteURL())
+	}
+	if !ok || !strings.HasPrefix(endpoint, "/") {
+		return nil, "", fmt.Errorf("attachment %s has a content URL outside the Jira instance: %q", attachmentID, contentURL)
+	}
+
+	log.Printf("Downloading attachment %s (%s) from Jira", attachmentID, filename)
+
+	// The content is binary, so accept any type
+	resp, err := jc.makeRequestWithHeaders(ctx, "GET", endpoint, nil, map[string]string{"Accept": "*/*"})
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+
+	// Don't log the body like readResponseBody does: it isn't JSON on success
+	content, err := io.ReadAll(io.LimitReader(resp.Body, MaxAttachmentDownloadSize+1))
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read attachment content: %w", err)
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, "", fmt.Errorf("attachment %s %w", attachmentID, ErrNotFound)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", parseJiraError(resp.StatusCode, content, "Errors")
+	}
+	if len(content) > MaxAttachmentDownloadSize {
+		return nil, "", fmt.Errorf("attachment %s is larger than the %d byte download limit", attachmentID, MaxAttachmentDownloadSize)
+	}
+
+	log.Printf("Successfully downloaded attachment %s (%d bytes)", attachmentID, len(content))
+	return content, filename, nil
+}
+
+// NotifyIssue emails a notification about an issue. to follows Jira's structure:
+// {"reporter": bool, "assignee": bool, "watchers": bool, "voters": bool,
+// "users": [{"accountId": "..."}], "groups": [{"name": "..."}]}; at least one
+// recipient must be set. An empty subject lets Jira generate one. Jira may drop recipients who can't view the issue.
+func (jc *JiraClient) NotifyIssue(ctx context.Context, issueKeyOrId, subject, textBody string, to map[string]interface{}) error {
+	if !hasNotifyRecipients(to) {
+		return errors.New("at least one notification recipient is required")
+	}
+
+	// Jira generates a subject from the issue key and summary when none is sent
+	requestBody := map[string]interface{}{
+		"textBody": textBody,
+		"to":       to,
+	}
+	if subject != "" {
+		requestBody["subject"] = subject
+	}
+
+	// Marshal request body
+	bodyBytes, err := sonic.Marshal(requestBody)
+	if err != nil {
+		return fmt.Errorf("failed to marshal request body: %w", err)
+	}
+
+	Debugf("Sending notification for Jira issue %s with body: %s", issueKeyOrId, RedactJSON(bodyBytes))
+
+	// Build the endpoint
+	endpoint := jc.apiPath(fmt.Sprintf("/issue/%s/notify", url.PathEscape(issueKeyOrId)))
+
+	// Make the API call
+	resp, err := jc.makeRequest(ctx, "POST", endpoint, bytes.NewReader(bodyBytes))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// Read response body
+	bodyBytes, err = readResponseBody(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("issue %s %w (or you do not have permission to view it)", issueKeyOrId, ErrNotFound)
+	}
+	// Check for errors (204 No Content is success; the email is queued by Jira)
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+		return parseJiraError(resp.StatusCode, bodyBytes, "Errors")
+	}
+
+	log.Printf("Successfully queued notification for Jira issue %s", issueKeyOrId)
+	return nil
+}
+
+// hasNotifyRecipients reports whether a notify "to" object names at least one recipient
+func hasNotifyRecipients(to map[string]interface{}) bool {
+	for _, role := range []string{"reporter", "assignee", "watchers", "voters"} {
+		if enabled, _ := to[role].(bool); enabled {
+			return true
+		}
+	}
+	for _, list := range []string{"users", "groups"} {
+		switch recipients := to[list].(type) {
+		case []map[string]interface{}:
+			if len(recipients) > 0 {
+				return true
+			}
+		case []interface{}:
+			if len(recipients) > 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// AddWorklog logs time spent against a Jira issue.
+// timeSpent uses Jira's duration format (e.g. "3h 30m"); a zero started time means now.
+func (jc *JiraClient) AddWorklog(ctx context.Context, issueKeyOrId, timeSpent, comment string, started time.Time) (map[string]interface{}, error) {
+	if started.IsZero() {
+		started = time.Now()
+	}
+
+	// Build the request body
+	requestBody := map[string]interface{}{
+		"timeSpent": timeSpent,
+		"started":   started.Format(JiraTimeFormat),
+	}
+	if comment != "" {
+		requestBody["comment"] = jc.formatRichText(comment)
+	}
+
+	// Marshal request body
+	bodyBytes, err := sonic.Marshal(requestBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request body: %w", err)
+	}
+
+	Debugf("Adding worklog to Jira issue %s with body: %s", issueKeyOrId, RedactJSON(bodyBytes))
+
+	// Build the endpoint
+	endpoint := jc.apiPath(fmt.Sprintf("/issue/%s/worklog", url.PathEscape(issueKeyOrId)))
+
+	// Make the API call
+	resp, err := jc.makeRequest(ctx, "POST", endpoint, bytes.NewReader(bodyBytes))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	// Read response body
+	bodyBytes, err = readResponseBody(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("issue %s %w (or you do not have permission to log work)", issueKeyOrId, ErrNotFound)
+	}
+	// Check for errors (201 Created is success for POST worklog)
+	if resp.StatusCode != http.StatusCreated {
+		return nil, parseJiraError(resp.StatusCode, bodyBytes, "Errors")
+	}
+
+	// Parse response
+	var worklog map[string]interface{}
+	err = sonic.Unmarshal(bodyBytes, &worklog)
+	if err != nil {
+		log.Printf("Failed to unmarshal worklog response: %v, body: %s", err, RedactJSON(bodyBytes))
+		return nil, fmt.Errorf("failed to unmarshal worklog: %w", err)
+	}
+
+	log.Printf("Successfully added worklog to Jira issue %s: %v", issueKeyOrId, worklog["id"])
+	return worklog, nil
+}
+
+// LinkIssues creates a link of the given type (e.g. "Blocks", "Relates") between two issues,
+// optionally adding a comment to the outward issue
+func (jc *JiraClient) LinkIssues(ctx context.Context, inwardKey, outwardKey, linkType string, comment string) error {
+	// Build the request body
+	requestBody := map[string]interface{}{
+		"type": map[string]interface{}{
+			"name": linkType,
+		},
+		"inwardIssue": map[string]interface{}{
+			"key": inwardKey,
+		},
+		"outwardIssue": map[string]interface{}{
+			"key": outwardKey,
+		},
+	}
+	if comment != "" {
+		requestBody["comment"] = map[string]interface{}{
+			"body": jc.formatRichText(comment),
+		}
+	}
+
+	// Marshal request body
+	bodyBytes, err := sonic.Marshal(requestBody)
+	if err != nil {
+		return fmt.Errorf("failed to marshal request body: %w", err)
+	}
+
+	Debugf("Linking Jira issues with body: %s", RedactJSON(bodyBytes))
+
+	// Make the API call
+	resp, err := jc.makeRequest(ctx, "POST", jc.apiPath("/issueLink"), bytes.NewReader(bodyBytes))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// Read response body
+	bodyBytes, err = readResponseBody(resp)
+	if err != nil {
+		return err
+	}
+
+	// Check for errors (201 Created is success for POST issueLink)
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		return parseJiraError(resp.StatusCode, bodyBytes, "Errors")
+	}
+
+	log.Printf("Successfully linked Jira issues %s and %s (%s)", inwardKey, outwardKey, linkType)
+	return nil
+}
+
+// ListLinkTypes retrieves the issue link types configured in Jira
+func (jc *JiraClient) ListLinkTypes(ctx context.Context) ([]map[string]interface{}, error) {
+	resp, err := jc.makeRequest(ctx, "GET", jc.apiPath("/issueLinkType"), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	bodyBytes, err := readResponseBody(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, parseJiraError(resp.StatusCode, bodyBytes, "Errors")
+	}
+
+	// Response is wrapped: {"issueLinkTypes": [...]}
+	var linkTypesResponse struct {
+		IssueLinkTypes []map[string]interface{} `json:"issueLinkTypes"`
+	}
+	err = sonic.Unmarshal(bodyBytes, &linkTypesResponse)
+	if err != nil {
+		log.Printf("Failed to unmarshal link types response: %v, body: %s", err, RedactJSON(bodyBytes))
+		return nil, fmt.Errorf("failed to unmarshal link types: %w", err)
+	}
+
+	log.Printf("Successfully retrieved %d issue link types", len(linkTypesResponse.IssueLinkTypes))
+	return linkTypesResponse.IssueLinkTypes, nil
+}
+
+// AddWatcher adds a user as a watcher of an issue
+func (jc *JiraClient) AddWatcher(ctx context.Context, issueKeyOrId, accountId string) error {
+	// The watchers endpoint expects the account ID as a bare JSON string ("5b10a..."), not an object
+	bodyBytes, err := sonic.Marshal(accountId)
+	if err != nil {
+		return fmt.Errorf("failed to marshal request body: %w", err)
+	}
+
+	log.Printf("Adding watcher %s to Jira issue %s", accountId, issueKeyOrId)
+
+	// Build the endpoint
+	endpoint := jc.apiPath(fmt.Sprintf("/issue/%s/watchers", url.PathEscape(issueKeyOrId)))
+
+	// Make the API call
+	resp, err := jc.makeRequest(ctx, "POST", endpoint, bytes.NewReader(bodyBytes))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// Read response body
+	bodyBytes, err = readResponseBody(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("issue %s or user %s %w", issueKeyOrId, accountId, ErrNotFound)
+	}
+	// Check for errors (204 No Content is success for POST watchers)
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+		return parseJiraError(resp.StatusCode, bodyBytes, "Errors")
+	}
+
+	log.Printf("Successfully added watcher %s to Jira issue %s", accountId, issueKeyOrId)
+	return nil
+}
+
+// RemoveWatcher removes a user from the watchers of an issue
+func (jc *JiraClient) RemoveWatcher(ctx context.Context, issueKeyOrId, accountId string) error {
+	log.Printf("Removing watcher %s from Jira issue %s", accountId, issueKeyOrId)
+
+	// Build the endpoint
+	endpoint := jc.apiPath(fmt.Sprintf("/issue/%s/watchers?accountId=%s", url.PathEscape(issueKeyOrId), url.QueryEscape(accountId)))
+
+	// Make the DELETE request
+	resp, err := jc.makeRequest(ctx, "DELETE", endpoint, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// Read response body
+	bodyBytes, err := readResponseBody(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("issue %s or user %s %w", issueKeyOrId, accountId, ErrNotFound)
+	}
+	// Check for errors (204 No Content is success for DELETE)
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+		return parseJiraError(resp.StatusCode, bodyBytes, "Errors")
+	}
+
+	log.Printf("Successfully removed watcher %s from Jira issue %s", accountId, issueKeyOrId)
+	return nil
+}
+
+// ListWatchers retrieves the watchers of an issue
+func (jc *JiraClient) ListWatchers(ctx context.Context, issueKeyOrId string) (map[string]interface{}, error) {
+	endpoint := jc.apiPath(fmt.Sprintf("/issue/%s/watchers", url.PathEscape(issueKeyOrId)))
+
+	resp, err := jc.makeRequest(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	bodyBytes, err := readResponseBody(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("issue %s %w (or you do not have permission to view it)", issueKeyOrId, ErrNotFound)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, parseJiraError(resp.StatusCode, bodyBytes, "Errors")
+	}
+
+	// Parse response: {"isWatching": false, "watchCount": 1, "watchers": [...]}
+	var watchers map[string]interface{}
+	err = sonic.Unmarshal(bodyBytes, &watchers)
+	if err != nil {
+		log.Printf("Failed to unmarshal watchers response: %v, body: %s", err, RedactJSON(bodyBytes))
+		return nil, fmt.Errorf("failed to unmarshal watchers: %w", err)
+	}
+
+	log.Printf("Successfully retrieved watchers for Jira issue %s (count: %v)", issueKeyOrId, watchers["watchCount"])
+	return watchers, nil
+}
+
+// AddVote votes for an issue as the authenticated user. Jira doesn't allow voting for
+// issues you reported or that are resolved.
+func (jc *JiraClient) AddVote(ctx context.Context, issueKeyOrId string) error {
+	return jc.changeVote(ctx, "POST", issueKeyOrId)
+}
+
+// RemoveVote withdraws the authenticated user's vote for an issue
+func (jc *JiraClient) RemoveVote(ctx context.Context, issueKeyOrId string) error {
+	return jc.changeVote(ctx, "DELETE", issueKeyOrId)
+}
+
+// changeVote adds (POST) or removes (DELETE) the authenticated user's vote
+func (jc *JiraClient) changeVote(ctx context.Context, method, issueKeyOrId string) error {
+	endpoint := jc.apiPath(fmt.Sprintf("/issue/%s/votes", url.PathEscape(issueKeyOrId)))
+
+	resp, err := jc.makeRequest(ctx, method, endpoint, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	bodyBytes, err := readResponseBody(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		return votesNotFoundError(issueKeyOrId, bodyBytes)
+	}
+	// Check for errors (204 No Content is success)
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+		return parseJiraError(resp.StatusCode, bodyBytes, "Errors")
+	}
+
+	log.Printf("Successfully changed vote (%s) on Jira issue %s", method, issueKeyOrId)
+	return nil
+}
+
+// GetVotes returns the votes of an issue: {"votes": count, "hasVoted": bool, "voters": [...]}.
+// Voters are only listed if the user may view them.
+func (jc *JiraClient) GetVotes(ctx context.Context, issueKeyOrId string) (map[string]interface{}, error) {
+	endpoint := jc.apiPath(fmt.Sprintf("/issue/%s/votes", url.PathEscape(issueKeyOrId)))
+
+	resp, err := jc.makeRequest(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	bodyBytes, err := readResponseBody(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, votesNotFoundError(issueKeyOrId, bodyBytes)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, parseJiraError(resp.StatusCode, bodyBytes, "Errors")
+	}
+
+	var votes map[string]interface{}
+	err = sonic.Unmarshal(bodyBytes, &votes)
+	if err != nil {
+		log.Printf("Failed to unmarshal votes response: %v, body: %s", err, RedactJSON(bodyBytes))
+		return nil, fmt.Errorf("failed to unmarshal votes: %w", err)
+	}
+
+	log.Printf("Successfully retrieved votes for Jira issue %s (count: %v)", issueKeyOrId, votes["votes"])
+	return votes, nil
+}
+
+// votesNotFoundError tells apart the 404 Jira returns when voting is disabled from a
+// missing issue, using the error message
+func votesNotFoundError(issueKeyOrId string, bodyBytes []byte) error {
+	var apiErr *JiraAPIError
+	if errors.As(parseJiraError(http.StatusNotFound, bodyBytes, "Errors"), &apiErr) {
+		for _, message := range apiErr.ErrorMessages {
+			message = strings.ToLower(message)
+			if strings.Contains(message, "voting") && strings.Contains(message, "disabled") {
+				return ErrVotingDisabled
+			}
+		}
+	}
+	return fmt.Errorf("issue %s %w (or you do not have permission to view it)", issueKeyOrId, ErrNotFound)
+}
+
+// ModifyLabels adds and removes labels on an issue without replacing its other labels
+func (jc *JiraClient) ModifyLabels(ctx context.Context, issueKeyOrId string, add []string, remove []string) error {
+	// Build the update operations: {"update": {"labels": [{"add": "x"}, {"remove": "y"}]}}
+	operations := make([]map[string]interface{}, 0, len(add)+len(remove))
+	for _, label := range add {
+		operations = append(operations, map[string]interface{}{"add": label})
+	}
+	for _, label := range remove {
+		operations = append(operations, map[string]interface{}{"remove": label})
+	}
+
+	requestBody := map[string]interface{}{
+		"update": map[string]interface{}{
+			"labels": operations,
+		},
+	}
+
+	// Marshal request body
+	bodyBytes, err := sonic.Marshal(requestBody)
+	if err != nil {
+		return fmt.Errorf("failed to marshal request body: %w", err)
+	}
+
+	Debugf("Modifying labels on Jira issue %s with body: %s", issueKeyOrId, RedactJSON(bodyBytes))
+
+	// Build the endpoint
+	endpoint := jc.apiPath(fmt.Sprintf("/issue/%s", url.PathEscape(issueKeyOrId)))
+
+	// Make the API call
+	resp, err := jc.makeRequest(ctx, "PUT", endpoint, bytes.NewReader(bodyBytes))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// Read response body
+	bodyBytes, err = readResponseBody(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("issue %s %w (or you do not have permission to edit it)", issueKeyOrId, ErrNotFound)
+	}
+	// Check for errors (204 No Content is success for PUT issue)
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+		return parseJiraError(resp.StatusCode, bodyBytes, "Errors")
+	}
+
+	log.Printf("Successfully modified labels on Jira issue %s (added: %v, removed: %v)", issueKeyOrId, add, remove)
+	return nil
+}
+
+// labelsSuggestPath is the legacy (1.0) endpoint the Jira UI uses for label autocomplete.
+// It is not versioned like the rest of the API.
+const labelsSuggestPath = "/rest/api/1.0/labels/suggest"
+
+// labelsPageSize is the number of labels requested per page from /label
+const labelsPageSize = 1000
+
+// MaxLabelSuggestions caps the number of labels returned by SuggestLabels
+const MaxLabelSuggestions = 50
+
+// SuggestLabels returns existing labels matching query, deduplicated and capped at limit
+// (at most MaxLabelSuggestions). It uses the label autocomplete endpoint and falls
+// back to filtering the full label list where that endpoint doesn't exist.
+func (jc *JiraClient) SuggestLabels(ctx context.Context, query string, limit int) ([]string, error) {
+	if limit <= 0 || limit > MaxLabelSuggestions {
+		limit = MaxLabelSuggestions
+	}
+
+	labels, err := jc.suggestLabels(ctx, query)
+	if errors.Is(err, ErrNotFound) {
+		log.Printf("Label suggestions are not available on this Jira, filtering the label list instead")
+		labels, err = jc.searchLabels(ctx, query, limit)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Suggestions can repeat labels that differ only in their highlighting
+	seen := make(map[string]bool, len(labels))
+	suggestions := make([]string, 0, min(len(labels), limit))
+	for _, label := range labels {
+		if label == "" || seen[label] {
+			continue
+		}
+		seen[label] = true
+		suggestions = append(suggestions, label)
+		if len(suggestions) == limit {
+			break
+		}
+	}
+
+	log.Printf("Found %d label suggestions for query %q", len(suggestions), query)
+	return suggestions, nil
+}
+
+// suggestLabels calls the label autocomplete endpoint
+func (jc *JiraClient) suggestLabels(ctx context.Context, query string) ([]string, error) {
+	endpoint := labelsSuggestPath + "?query=" + url.QueryEscape(query)
+
+	resp, err := jc.makeRequest(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	bodyBytes, err := readResponseBody(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("label suggestions %w", ErrNotFound)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, parseJiraError(resp.StatusCode, bodyBytes, "Errors")
+	}
+
+	// Parse response: {"token": "...", "suggestions": [{"label": "backend", "html": "<b>back</b>end"}]}
+	var suggestResponse struct {
+		Suggestions []struct {
+			Label string `json:"label"`
+		} `json:"suggestions"`
+	}
+	err = sonic.Unmarshal(bodyBytes, &suggestResponse)
+	if err != nil {
+		log.Printf("Failed to unmarshal label suggestions response: %v, body: %s", err, RedactJSON(bodyBytes))
+		return nil, fmt.Errorf("failed to unmarshal label suggestions: %w", err)
+	}
+
+	labels := make([]string, 0, len(suggestResponse.Suggestions))
+	for _, suggestion := range suggestResponse.Suggestions {
+		labels = append(labels, suggestion.Label)
+	}
+	return labels, nil
+}
+
+// searchLabels pages through /label and keeps the labels containing query (case-insensitive),
+// stopping once limit labels matched
+func (jc *JiraClient) searchLabels(ctx context.Context, query string, limit int) ([]string, error) {
+	query = strings.ToLower(query)
+	labels, _, err := fetchAllPages(ctx, jc, pageQuery[string]{
+		name: "labels",
+		endpoint: func(startAt, maxResults int) string {
+			return jc.apiPath(fmt.Sprintf("/label?startAt=%d&maxResults=%d", startAt, maxResults))
+		},
+		pageSize: labelsPageSize,
+		limit:    limit,
+		keep: func(label string) bool {
+			return strings.Contains(strings.ToLower(label), query)
+		},
+		notFound: fmt.Errorf("label list %w", ErrNotFound),
+	})
+	return labels, err
+}
+
+// UpdateIssue updates fields of an existing Jira issue
+func (jc *JiraClient) UpdateIssue(ctx context.Context, issueKeyOrId string, fields map[string]interface{}) error {
+	// Skip unset (nil) fields; explicit false, 0 and "" values are sent as provided
+	updateFields := make(map[string]interface{})
+	for key, value := range fields {
+		if value != nil {
+			updateFields[key] = value
+		}
+	}
+	if description, ok := updateFields["description"].(string); ok {
+		updateFields["description"] = jc.formatRichText(description)
+	}
+
+	requestBody := map[string]interface{}{
+		"fields": updateFields,
+	}
+
+	// Marshal request body
+	bodyBytes, err := sonic.Marshal(requestBody)
+	if err != nil {
+		return fmt.Errorf("failed to marshal request body: %w", err)
+	}
+
+	Debugf("Updating Jira issue %s with body: %s", issueKeyOrId, RedactJSON(bodyBytes))
+
+	// Build the endpoint
+	endpoint := jc.apiPath(fmt.Sprintf("/issue/%s", url.PathEscape(issueKeyOrId)))
+
+	// Make the API call
+	resp, err := jc.makeRequest(ctx, "PUT", endpoint, bytes.NewReader(bodyBytes))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// Read response body
+	bodyBytes, err = readResponseBody(resp)
+	if err != nil {
+		return err
+	}
+
+	// Check for errors (204 No Content is success for PUT issue)
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+		return parseJiraError(resp.StatusCode, bodyBytes, "Missing or invalid fields")
+	}
+
+	log.Printf("Successfully updated Jira issue: %s", issueKeyOrId)
+	return nil
+}
+
+// AssignIssue assigns an issue to a user by account ID.
+// Pass "-1" to use the project's default assignee, or an empty string to unassign.
+func (jc *JiraClient) AssignIssue(ctx context.Context, issueKeyOrId, accountId string) error {
+	// Jira encodes "unassigned" as {"accountId": null}
+	var value interface{}
+	if accountId != "" {
+		value = accountId
+	}
+	return jc.assignIssue(ctx, issueKeyOrId, map[string]interface{}{"accountId": value})
+}
+
+// AssignIssueByName assigns an issue to a user by username (Jira Server/Data Center).
+// Pass "-1" to use the project's default assignee, or an empty string to unassign.
+func (jc *JiraClient) AssignIssueByName(ctx context.Context, issueKeyOrId, name string) error {
+	var value interface{}
+	if name != "" {
+		value = name
+	}
+	return jc.assignIssue(ctx, issueKeyOrId, map[string]interface{}{"name": value})
+}
+
+// assignIssue sends the assignee payload to the issue's assignee endpoint
+func (jc *JiraClient) assignIssue(ctx context.Context, issueKeyOrId string, requestBody map[string]interface{}) error {
+	// Marshal request body
+	bodyBytes, err := sonic.Marshal(requestBody)
+	if err != nil {
+		return fmt.Errorf("failed to marshal request body: %w", err)
+	}
+
+	Debugf("Assigning Jira issue %s with body: %s", issueKeyOrId, RedactJSON(bodyBytes))
+
+	// Build the endpoint
+	endpoint := jc.apiPath(fmt.Sprintf("/issue/%s/assignee", url.PathEscape(issueKeyOrId)))
+
+	// Make the API call
+	resp, err := jc.makeRequest(ctx, "PUT", endpoint, bytes.NewReader(bodyBytes))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// Read response body
+	bodyBytes, err = readResponseBody(resp)
+	if err != nil {
+		return err
 	}
-}
 
-// makeRequest makes an authenticated HTTP request to Jira API
-func (jc *JiraClient) makeRequest(method, endpoint string, body io.Reader) (*http.Response, error) {
-	// Normalize base URL (remove trailing slash) and ensure endpoint starts with /
-	baseURL := strings.TrimSuffix(jc.BaseURL, "/")
-	if !strings.HasPrefix(endpoint, "/") {
-		endpoint = "/" + endpoint
+	// Check for errors (204 No Content is success for PUT assignee)
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+		return parseJiraError(resp.StatusCode, bodyBytes, "Errors")
 	}
-	url := fmt.Sprintf("%s%s", baseURL, endpoint)
 
-	log.Printf("Making Jira API request: %s %s", method, url)
+	log.Printf("Successfully assigned Jira issue: %s", issueKeyOrId)
+	return nil
+}
+
+// ListTransitions retrieves the workflow transitions available for an issue
+func (jc *JiraClient) ListTransitions(ctx context.Context, issueKeyOrId string) ([]map[string]interface{}, error) {
+	endpoint := jc.apiPath(fmt.Sprintf("/issue/%s/transitions", url.PathEscape(issueKeyOrId)))
+
+	resp, err := jc.makeRequest(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
 
-	req, err := http.NewRequest(method, url, body)
+	bodyBytes, err := readResponseBody(resp)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, err
 	}
 
-	// Use Bearer token authentication with PAT (Personal Access Token)
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", jc.APIToken))
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Accept", "application/json")
+	if resp.StatusCode != http.StatusOK {
+		return nil, parseJiraError(resp.StatusCode, bodyBytes, "Errors")
+	}
 
-	resp, err := jc.HTTPClient.Do(req)
+	// Response is wrapped: {"expand": "...", "transitions": [...]}
+	var transitionsResponse struct {
+		Transitions []map[string]interface{} `json:"transitions"`
+	}
+	err = sonic.Unmarshal(bodyBytes, &transitionsResponse)
 	if err != nil {
-		return nil, fmt.Errorf("failed to make request: %w", err)
+		log.Printf("Failed to unmarshal transitions response: %v, body: %s", err, RedactJSON(bodyBytes))
+		return nil, fmt.Errorf("failed to unmarshal transitions: %w", err)
 	}
 
-	return resp, nil
+	log.Printf("Successfully retrieved %d transitions for Jira issue %s", len(transitionsResponse.Transitions), issueKeyOrId)
+	return transitionsResponse.Transitions, nil
 }
 
-// ListProjects retrieves all projects from Jira
-func (jc *JiraClient) ListProjects() ([]map[string]interface{}, error) {
-	resp, err := jc.makeRequest("GET", "/rest/api/2/project", nil)
+// TransitionIssue moves an issue through its workflow using the given transition ID
+func (jc *JiraClient) TransitionIssue(ctx context.Context, issueKeyOrId, transitionID string, fields map[string]interface{}) error {
+	// Build the request body
+	requestBody := map[string]interface{}{
+		"transition": map[string]interface{}{
+			"id": transitionID,
+		},
+	}
+
+	// Add fields to set during the transition (e.g. resolution)
+	if len(fields) > 0 {
+		requestBody["fields"] = fields
+	}
+
+	// Marshal request body
+	bodyBytes, err := sonic.Marshal(requestBody)
 	if err != nil {
-		return nil, err
+		return fmt.Errorf("failed to marshal request body: %w", err)
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("Jira API error (status %d): %s", resp.StatusCode, string(bodyBytes))
+	Debugf("Transitioning Jira issue %s with body: %s", issueKeyOrId, RedactJSON(bodyBytes))
+
+	// Build the endpoint
+	endpoint := jc.apiPath(fmt.Sprintf("/issue/%s/transitions", url.PathEscape(issueKeyOrId)))
+
+	// Make the API call
+	resp, err := jc.makeRequest(ctx, "POST", endpoint, bytes.NewReader(bodyBytes))
+	if err != nil {
+		return err
 	}
+	defer resp.Body.Close()
 
-	bodyBytes, err := io.ReadAll(resp.Body)
+	// Read response body
+	bodyBytes, err = readResponseBody(resp)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
+		return err
 	}
 
-	log.Printf("Jira API response status: %d, body length: %d bytes", resp.StatusCode, len(bodyBytes))
-	if len(bodyBytes) > 0 && len(bodyBytes) < 1000 {
-		log.Printf("Jira API response body: %s", string(bodyBytes))
+	// Check for errors (204 No Content is success for POST transition)
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+		return parseJiraError(resp.StatusCode, bodyBytes, "Errors")
 	}
 
-	var projects []map[string]interface{}
-	err = sonic.Unmarshal(bodyBytes, &projects)
+	log.Printf("Successfully transitioned Jira issue %s using transition %s", issueKeyOrId, transitionID)
+	return nil
+}
+
+// TransitionIssueByName executes the transition with the given name (case-insensitive)
+// or ID. Transition IDs differ per workflow, so the transition is resolved against the
+// ones available for the issue's current status; if none matches, the error wraps
+// ErrTransitionUnavailable and lists the available transitions. It returns the
+// executed transition.
+func (jc *JiraClient) TransitionIssueByName(ctx context.Context, issueKeyOrId, transition string, fields map[string]interface{}) (map[string]interface{}, error) {
+	transitions, err := jc.ListTransitions(ctx, issueKeyOrId)
 	if err != nil {
-		log.Printf("Failed to unmarshal projects response: %v, body: %s", err, string(bodyBytes))
-		return nil, fmt.Errorf("failed to unmarshal projects: %w", err)
+		return nil, err
 	}
 
-	log.Printf("Successfully parsed %d projects from Jira API", len(projects))
-	return projects, nil
+	availableNames := make([]string, 0, len(transitions))
+	for _, t := range transitions {
+		id, _ := t["id"].(string)
+		name, _ := t["name"].(string)
+		if id == transition || strings.EqualFold(name, transition) {
+			return t, jc.TransitionIssue(ctx, issueKeyOrId, id, fields)
+		}
+		availableNames = append(availableNames, name)
+	}
+
+	if len(availableNames) == 0 {
+		return nil, fmt.Errorf("transition '%s' is not available for issue %s: no transitions are available: %w", transition, issueKeyOrId, ErrTransitionUnavailable)
+	}
+	return nil, fmt.Errorf("transition '%s' is not available for issue %s; available transitions: %s: %w", transition, issueKeyOrId, strings.Join(availableNames, ", "), ErrTransitionUnavailable)
 }
 
-// CreateIssue creates a new issue in Jira
-func (jc *JiraClient) CreateIssue(projectKey, issueType, summary, description string, additionalFields map[string]interface{}) (map[string]interface{}, error) {
-	// Build the request body
-	fields := map[string]interface{}{
-		"project": map[string]interface{}{
-			"key": projectKey,
-		},
-		"summary": summary,
-		"issuetype": map[string]interface{}{
-			"name": issueType,
-		},
+// BulkTransitionIssues runs the same transition (name or ID) on several issues with
+// bounded concurrency. The transition is resolved per issue, since the issues may be
+// in different statuses or workflows. A failing issue does not stop the others: the
+// result holds a per-key map with "transitioned" and either the resulting "status" or
+// "error" and "code", plus the "transitioned" and "failed" counts. onTransition, if
+// not nil, is called after every issue with the number of issues processed so far.
+func (jc *JiraClient) BulkTransitionIssues(ctx context.Context, keys []string, transition string, fields map[string]interface{}, onTransition func(done, total int)) (map[string]interface{}, error) {
+	if len(keys) == 0 {
+		return nil, errors.New("at least one issue key is required")
 	}
 
-	// Add description if provided
-	if description != "" {
-		fields["description"] = description
+	// Transitioning the same issue twice would fail the second time, from the new
+	// status, which forEachIssue prevents
+	results, transitioned, failed := forEachIssue(keys, "transitioned", func(key string) (map[string]interface{}, error) {
+		matched, err := jc.TransitionIssueByName(ctx, key, transition, fields)
+		if err != nil {
+			return nil, err
+		}
+		// The target status of the transition is the issue's resulting status
+		to, _ := matched["to"].(map[string]interface{})
+		return map[string]interface{}{"status": to["name"]}, nil
+	}, onTransition)
+
+	log.Printf("Bulk transition '%s' finished: %d transitioned, %d failed", transition, transitioned, failed)
+	return map[string]interface{}{
+		"issues":       results,
+		"transitioned": transitioned,
+		"failed":       failed,
+	}, nil
+}
+
+// TransitionIssueToStatus moves an issue to the status with the given name (case-insensitive)
+// by executing the available transition whose target status matches. If no such transition
+// is available from the issue's current status, the error wraps ErrTransitionUnavailable
+// and lists the statuses that can be reached.
+func (jc *JiraClient) TransitionIssueToStatus(ctx context.Context, issueKeyOrId, targetStatusName string) error {
+	transitions, err := jc.ListTransitions(ctx, issueKeyOrId)
+	if err != nil {
+		return err
 	}
 
-	// Add any additional fields (like duedate, assignee, etc.)
-	for key, value := range additionalFields {
-		if value != nil && value != "" {
-			fields[key] = value
+	targetStatuses := make([]string, 0, len(transitions))
+	for _, t := range transitions {
+		to, _ := t["to"].(map[string]interface{})
+		statusName, _ := to["name"].(string)
+		if strings.EqualFold(statusName, targetStatusName) {
+			transitionID, _ := t["id"].(string)
+			return jc.TransitionIssue(ctx, issueKeyOrId, transitionID, nil)
 		}
+		if statusName != "" {
+			targetStatuses = append(targetStatuses, statusName)
+		}
+	}
+
+	if len(targetStatuses) == 0 {
+		return fmt.Errorf("cannot move issue %s to status '%s': no transitions are available: %w", issueKeyOrId, targetStatusName, ErrTransitionUnavailable)
 	}
+	return fmt.Errorf("cannot move issue %s to status '%s' from its current status; valid target statuses: %s: %w", issueKeyOrId, targetStatusName, strings.Join(targetStatuses, ", "), ErrTransitionUnavailable)
+}
 
+// SearchIssues searches for issues using JQL
+// fields and expand are optional and restrict/extend the data returned for each issue.
+func (jc *JiraClient) SearchIssues(ctx context.Context, jql string, startAt, maxResults int, fields []string, expand []string) (map[string]interface{}, error) {
+	// Build the request body
 	requestBody := map[string]interface{}{
-		"fields": fields,
+		"jql":        jql,
+		"startAt":    startAt,
+		"maxResults": maxResults,
+	}
+
+	// Restrict returned fields and add expansions if requested; the POST body
+	// takes both as arrays rather than comma-joined strings
+	if fields = readParamValues(fields); len(fields) > 0 {
+		requestBody["fields"] = fields
+	}
+	if expand = readParamValues(expand); len(expand) > 0 {
+		requestBody["expand"] = expand
 	}
 
 	// Marshal request body
@@ -132,243 +3950,441 @@ func (jc *JiraClient) CreateIssue(projectKey, issueType, summary, description st
 		return nil, fmt.Errorf("failed to marshal request body: %w", err)
 	}
 
-	log.Printf("Creating Jira issue with body: %s", string(bodyBytes))
-
-	// Create request body reader
-	bodyReader := bytes.NewReader(bodyBytes)
+	Debugf("Searching Jira issues with body: %s", RedactJSON(bodyBytes))
 
 	// Make the API call
-	resp, err := jc.makeRequest("POST", "/rest/api/2/issue", bodyReader)
+	resp, err := jc.makeRequest(ctx, "POST", jc.apiPath("/search"), bytes.NewReader(bodyBytes))
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
 	// Read response body
-	bodyBytes, err = io.ReadAll(resp.Body)
+	bodyBytes, err = readResponseBody(resp)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
+		return nil, err
 	}
 
-	log.Printf("Jira API response status: %d, body length: %d bytes", resp.StatusCode, len(bodyBytes))
-	if len(bodyBytes) > 0 && len(bodyBytes) < 1000 {
-		log.Printf("Jira API response body: %s", string(bodyBytes))
+	if resp.StatusCode != http.StatusOK {
+		return nil, parseJiraError(resp.StatusCode, bodyBytes, "Errors")
 	}
 
-	// Check for errors
-	if resp.StatusCode != http.StatusCreated {
-		// Try to parse Jira error response for better error messages
-		var jiraError struct {
-			ErrorMessages []string          `json:"errorMessages"`
-			Errors        map[string]string `json:"errors"`
-		}
-
-		if err := sonic.Unmarshal(bodyBytes, &jiraError); err == nil {
-			// Build a user-friendly error message
-			var errorParts []string
-
-			// Add error messages
-			for _, msg := range jiraError.ErrorMessages {
-				errorParts = append(errorParts, msg)
-			}
+	// Parse response
+	var searchResult map[string]interface{}
+	err = sonic.Unmarshal(bodyBytes, &searchResult)
+	if err != nil {
+		log.Printf("Failed to unmarshal search response: %v, body: %s", err, RedactJSON(bodyBytes))
+		return nil, fmt.Errorf("failed to unmarshal search result: %w", err)
+	}
 
-			// Add field-specific errors
-			if len(jiraError.Errors) > 0 {
-				fieldErrors := []string{}
-				for field, msg := range jiraError.Errors {
-					fieldErrors = append(fieldErrors, fmt.Sprintf("%s: %s", field, msg))
-				}
-				if len(fieldErrors) > 0 {
-					errorParts = append(errorParts, fmt.Sprintf("Missing or invalid fields: %s", strings.Join(fieldErrors, "; ")))
-				}
-			}
+	log.Printf("Successfully searched Jira issues (total: %v)", searchResult["total"])
+	return searchResult, nil
+}
 
-			if len(errorParts) > 0 {
-				return nil, fmt.Errorf("Jira API error (status %d): %s", resp.StatusCode, strings.Join(errorParts, ". "))
-			}
-		}
+// ListFilters returns one page of the saved filters visible to the authenticated user:
+// {"values": [...], "startAt": n, "total": n, "isLast": bool}. It uses /filter/search and
+// falls back to the user's favourite filters on Server/Data Center versions without it;
+// that list isn't paginated, so the page is cut from it here.
+func (jc *JiraClient) ListFilters(ctx context.Context, startAt, maxResults int) (map[string]interface{}, error) {
+	params := url.Values{}
+	params.Set("startAt", strconv.Itoa(startAt))
+	params.Set("maxResults", strconv.Itoa(maxResults))
+	// owner and jql are only included when expanded
+	params.Set("expand", "description,owner,jql,viewUrl")
+	endpoint := jc.apiPath("/filter/search?" + params.Encode())
 
-		// Fallback to raw error message
-		return nil, fmt.Errorf("Jira API error (status %d): %s", resp.StatusCode, string(bodyBytes))
+	resp, err := jc.makeRequest(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, err
 	}
+	defer resp.Body.Close()
 
-	// Parse response
-	var issue map[string]interface{}
-	err = sonic.Unmarshal(bodyBytes, &issue)
+	bodyBytes, err := readResponseBody(resp)
 	if err != nil {
-		log.Printf("Failed to unmarshal issue response: %v, body: %s", err, string(bodyBytes))
-		return nil, fmt.Errorf("failed to unmarshal issue: %w", err)
+		return nil, err
 	}
 
-	log.Printf("Successfully created Jira issue: %v", issue)
-	return issue, nil
-}
+	if resp.StatusCode == http.StatusNotFound {
+		Debugf("Filter search endpoint not available, listing favourite filters instead")
+		return jc.listFavouriteFilters(ctx, startAt, maxResults)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, parseJiraError(resp.StatusCode, bodyBytes, "Errors")
+	}
 
-// DeleteIssue deletes an issue from Jira by issue key or ID
-func (jc *JiraClient) DeleteIssue(issueKeyOrId string, deleteSubtasks bool) error {
-	// Build the endpoint with optional query parameter
-	endpoint := fmt.Sprintf("/rest/api/2/issue/%s", issueKeyOrId)
-	if deleteSubtasks {
-		endpoint += "?deleteSubtasks=true"
+	// Parse response: {"startAt": 0, "maxResults": 50, "total": 3, "isLast": true, "values": [...]}
+	var page map[string]interface{}
+	err = sonic.Unmarshal(bodyBytes, &page)
+	if err != nil {
+		log.Printf("Failed to unmarshal filter search response: %v, body: %s", err, RedactJSON(bodyBytes))
+		return nil, fmt.Errorf("failed to unmarshal filters: %w", err)
 	}
 
-	log.Printf("Deleting Jira issue: %s (deleteSubtasks: %v)", issueKeyOrId, deleteSubtasks)
+	log.Printf("Successfully listed Jira filters (total: %v)", page["total"])
+	return page, nil
+}
 
-	// Make the DELETE request
-	resp, err := jc.makeRequest("DELETE", endpoint, nil)
+// listFavouriteFilters is the ListFilters fallback for instances without /filter/search
+func (jc *JiraClient) listFavouriteFilters(ctx context.Context, startAt, maxResults int) (map[string]interface{}, error) {
+	resp, err := jc.makeRequest(ctx, "GET", jc.apiPath("/filter/favourite"), nil)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	defer resp.Body.Close()
 
-	// Read response body (even for successful deletes, there might be a response)
-	bodyBytes, err := io.ReadAll(resp.Body)
+	bodyBytes, err := readResponseBody(resp)
 	if err != nil {
-		return fmt.Errorf("failed to read response: %w", err)
+		return nil, err
 	}
 
-	log.Printf("Jira API response status: %d, body length: %d bytes", resp.StatusCode, len(bodyBytes))
-	if len(bodyBytes) > 0 && len(bodyBytes) < 1000 {
-		log.Printf("Jira API response body: %s", string(bodyBytes))
+	if resp.StatusCode != http.StatusOK {
+		return nil, parseJiraError(resp.StatusCode, bodyBytes, "Errors")
 	}
 
-	// Check for errors (204 No Content is success for DELETE)
-	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
-		// Try to parse Jira error response for better error messages
-		var jiraError struct {
-			ErrorMessages []string          `json:"errorMessages"`
-			Errors        map[string]string `json:"errors"`
-		}
+	var filters []interface{}
+	err = sonic.Unmarshal(bodyBytes, &filters)
+	if err != nil {
+		log.Printf("Failed to unmarshal favourite filters response: %v, body: %s", err, RedactJSON(bodyBytes))
+		return nil, fmt.Errorf("failed to unmarshal filters: %w", err)
+	}
 
-		if err := sonic.Unmarshal(bodyBytes, &jiraError); err == nil {
-			// Build a user-friendly error message
-			var errorParts []string
+	total := len(filters)
+	start := min(startAt, total)
+	end := min(start+maxResults, total)
 
-			// Add error messages
-			for _, msg := range jiraError.ErrorMessages {
-				errorParts = append(errorParts, msg)
-			}
+	log.Printf("Successfully listed favourite Jira filters (total: %d)", total)
+	return map[string]interface{}{
+		"values":     filters[start:end],
+		"startAt":    startAt,
+		"maxResults": maxResults,
+		"total":      total,
+		"isLast":     end >= total,
+	}, nil
+}
 
-			// Add field-specific errors
-			if len(jiraError.Errors) > 0 {
-				fieldErrors := []string{}
-				for field, msg := range jiraError.Errors {
-					fieldErrors = append(fieldErrors, fmt.Sprintf("%s: %s", field, msg))
-				}
-				if len(fieldErrors) > 0 {
-					errorParts = append(errorParts, fmt.Sprintf("Errors: %s", strings.Join(fieldErrors, "; ")))
-				}
-			}
+// GetFilter returns a saved filter by ID, including its name, owner and jql
+func (jc *JiraClient) GetFilter(ctx context.Context, filterID string) (map[string]interface{}, error) {
+	endpoint := jc.apiPath(fmt.Sprintf("/filter/%s", url.PathEscape(filterID)))
 
-			if len(errorParts) > 0 {
-				return fmt.Errorf("Jira API error (status %d): %s", resp.StatusCode, strings.Join(errorParts, ". "))
-			}
-		}
+	resp, err := jc.makeRequest(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
 
-		// Fallback to raw error message
-		return fmt.Errorf("Jira API error (status %d): %s", resp.StatusCode, string(bodyBytes))
+	bodyBytes, err := readResponseBody(resp)
+	if err != nil {
+		return nil, err
 	}
 
-	log.Printf("Successfully deleted Jira issue: %s", issueKeyOrId)
-	return nil
-}
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("filter %s %w (or it is not shared with you)", filterID, ErrNotFound)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, parseJiraError(resp.StatusCode, bodyBytes, "Errors")
+	}
 
-// AddComment adds a comment to a Jira issue
-func (jc *JiraClient) AddComment(issueKeyOrId, commentBody string, visibility map[string]interface{}, additionalFields map[string]interface{}) (map[string]interface{}, error) {
-	// Build the request body
-	requestBody := map[string]interface{}{
-		"body": commentBody,
+	var filter map[string]interface{}
+	err = sonic.Unmarshal(bodyBytes, &filter)
+	if err != nil {
+		log.Printf("Failed to unmarshal filter response: %v, body: %s", err, RedactJSON(bodyBytes))
+		return nil, fmt.Errorf("failed to unmarshal filter: %w", err)
 	}
 
-	// Add visibility if provided
-	if len(visibility) > 0 {
-		requestBody["visibility"] = visibility
+	log.Printf("Successfully retrieved Jira filter %s (%v)", filterID, filter["name"])
+	return filter, nil
+}
+
+// jqlErrorPosition extracts the position Jira reports in JQL parse errors,
+// e.g. "... (line 1, character 15)"
+var jqlErrorPosition = regexp.MustCompile(`line (\d+), character (\d+)`)
+
+// ValidateJQL checks a JQL query without running it. It uses /jql/parse where available
+// (Jira Cloud) and falls back to a search with maxResults=0 on Server/Data Center, which
+// lack that endpoint. The result holds "valid", "errors" (each with "message" and, when
+// Jira reports one, the "line" and "character" position) and the "method" used.
+// An error is only returned when the query couldn't be checked at all.
+func (jc *JiraClient) ValidateJQL(ctx context.Context, jql string) (map[string]interface{}, error) {
+	messages, err := jc.parseJQL(ctx, jql)
+	method := "parse"
+	if errors.Is(err, ErrNotFound) {
+		Debugf("JQL parse endpoint not available, validating with a search instead")
+		messages, err = jc.validateJQLWithSearch(ctx, jql)
+		method = "search"
+	}
+	if err != nil {
+		return nil, err
 	}
 
-	// Add any additional fields (for future Jira API extensions or custom fields)
-	for key, value := range additionalFields {
-		if value != nil && value != "" {
-			requestBody[key] = value
+	parseErrors := make([]map[string]interface{}, 0, len(messages))
+	for _, message := range messages {
+		parseError := map[string]interface{}{"message": message}
+		if match := jqlErrorPosition.FindStringSubmatch(message); match != nil {
+			line, _ := strconv.Atoi(match[1])
+			character, _ := strconv.Atoi(match[2])
+			parseError["line"] = line
+			parseError["character"] = character
 		}
+		parseErrors = append(parseErrors, parseError)
 	}
 
-	// Marshal request body
-	bodyBytes, err := sonic.Marshal(requestBody)
+	log.Printf("Validated JQL via %s: %d errors", method, len(parseErrors))
+	return map[string]interface{}{
+		"valid":  len(parseErrors) == 0,
+		"errors": parseErrors,
+		"method": method,
+	}, nil
+}
+
+// parseJQL validates a query with POST /jql/parse and returns its parse errors.
+// The error wraps ErrNotFound when the instance has no parse endpoint.
+func (jc *JiraClient) parseJQL(ctx context.Context, jql string) ([]string, error) {
+	bodyBytes, err := sonic.Marshal(map[string]interface{}{
+		"queries": []string{jql},
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal request body: %w", err)
 	}
 
-	log.Printf("Adding comment to Jira issue %s with body: %s", issueKeyOrId, string(bodyBytes))
+	resp, err := jc.makeRequest(ctx, "POST", jc.apiPath("/jql/parse?validation=strict"), bytes.NewReader(bodyBytes))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	bodyBytes, err = readResponseBody(resp)
+	if err != nil {
+		return nil, err
+	}
 
-	// Create request body reader
-	bodyReader := bytes.NewReader(bodyBytes)
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("JQL parse endpoint %w", ErrNotFound)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, parseJiraError(resp.StatusCode, bodyBytes, "Errors")
+	}
 
-	// Build the endpoint
-	endpoint := fmt.Sprintf("/rest/api/2/issue/%s/comment", issueKeyOrId)
+	// Response is wrapped: {"queries": [{"query": "...", "structure": {...}, "errors": [...]}]}
+	var parseResponse struct {
+		Queries []struct {
+			Errors []string `json:"errors"`
+		} `json:"queries"`
+	}
+	err = sonic.Unmarshal(bodyBytes, &parseResponse)
+	if err != nil {
+		log.Printf("Failed to unmarshal JQL parse response: %v, body: %s", err, RedactJSON(bodyBytes))
+		return nil, fmt.Errorf("failed to unmarshal JQL parse result: %w", err)
+	}
+	if len(parseResponse.Queries) == 0 {
+		return nil, errors.New("JQL parse response contained no queries")
+	}
+	return parseResponse.Queries[0].Errors, nil
+}
 
-	// Make the API call
-	resp, err := jc.makeRequest("POST", endpoint, bodyReader)
+// validateJQLWithSearch validates a query by running a search that returns no issues.
+// Jira answers an invalid query with 400 and the parse errors in errorMessages.
+func (jc *JiraClient) validateJQLWithSearch(ctx context.Context, jql string) ([]string, error) {
+	bodyBytes, err := sonic.Marshal(map[string]interface{}{
+		"jql":           jql,
+		"maxResults":    0,
+		"validateQuery": "strict",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request body: %w", err)
+	}
+
+	resp, err := jc.makeRequest(ctx, "POST", jc.apiPath("/search"), bytes.NewReader(bodyBytes))
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
-	// Read response body
-	bodyBytes, err = io.ReadAll(resp.Body)
+	bodyBytes, err = readResponseBody(resp)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
+		return nil, err
 	}
 
-	log.Printf("Jira API response status: %d, body length: %d bytes", resp.StatusCode, len(bodyBytes))
-	if len(bodyBytes) > 0 && len(bodyBytes) < 1000 {
-		log.Printf("Jira API response body: %s", string(bodyBytes))
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return nil, nil
+	case http.StatusBadRequest:
+		var apiErr *JiraAPIError
+		if errors.As(parseJiraError(resp.StatusCode, bodyBytes, "Errors"), &apiErr) {
+			messages := apiErr.ErrorMessages
+			for _, message := range apiErr.Errors {
+				messages = append(messages, message)
+			}
+			if len(messages) > 0 {
+				return messages, nil
+			}
+		}
+		return []string{"Jira rejected the query"}, nil
 	}
+	return nil, parseJiraError(resp.StatusCode, bodyBytes, "Errors")
+}
 
-	// Check for errors (201 Created is success for POST comment)
-	if resp.StatusCode != http.StatusCreated {
-		// Try to parse Jira error response for better error messages
-		var jiraError struct {
-			ErrorMessages []string          `json:"errorMessages"`
-			Errors        map[string]string `json:"errors"`
+// readQuery builds the fields and expand query parameters shared by read endpoints.
+// Both are sent comma-joined and URL-escaped; empty lists are omitted.
+func readQuery(fields, expand []string) url.Values {
+	query := url.Values{}
+	if fields = readParamValues(fields); len(fields) > 0 {
+		query.Set("fields", strings.Join(fields, ","))
+	}
+	if expand = readParamValues(expand); len(expand) > 0 {
+		query.Set("expand", strings.Join(expand, ","))
+	}
+	return query
+}
+
+// readParamValues normalizes a fields or expand list: entries may themselves be
+// comma-separated, whitespace is trimmed, and empty or duplicate values are dropped
+func readParamValues(values []string) []string {
+	normalized := make([]string, 0, len(values))
+	seen := make(map[string]bool, len(values))
+	for _, value := range values {
+		for _, part := range strings.Split(value, ",") {
+			part = strings.TrimSpace(part)
+			if part != "" && !seen[part] {
+				seen[part] = true
+				normalized = append(normalized, part)
+			}
 		}
+	}
+	return normalized
+}
 
-		if err := sonic.Unmarshal(bodyBytes, &jiraError); err == nil {
-			// Build a user-friendly error message
-			var errorParts []string
+// apiPath builds a REST API endpoint for the configured API version (e.g. /rest/api/2/issue).
+// Credentials saved before the API version was configurable default to version 2.
+func (jc *JiraClient) apiPath(path string) string {
+	version := jc.APIVersion
+	if version == "" {
+		version = DefaultAPIVersion
+	}
+	return fmt.Sprintf("/rest/api/%s%s", version, path)
+}
 
-			// Add error messages
-			for _, msg := range jiraError.ErrorMessages {
-				errorParts = append(errorParts, msg)
-			}
+// agilePath builds a Jira Software Agile REST API endpoint (e.g. /rest/agile/1.0/board)
+func agilePath(path string) string {
+	return agileAPIPrefix + path
+}
 
-			// Add field-specific errors
-			if len(jiraError.Errors) > 0 {
-				fieldErrors := []string{}
-				for field, msg := range jiraError.Errors {
-					fieldErrors = append(fieldErrors, fmt.Sprintf("%s: %s", field, msg))
-				}
-				if len(fieldErrors) > 0 {
-					errorParts = append(errorParts, fmt.Sprintf("Errors: %s", strings.Join(fieldErrors, "; ")))
-				}
-			}
+// BrowseURL returns the link to an issue in the Jira web UI ({instanceUrl}/browse/{key})
+func (jc *JiraClient) BrowseURL(issueKey string) string {
+	if issueKey == "" {
+		return ""
+	}
+	return jc.siteURL() + "/browse/" + url.PathEscape(issueKey)
+}
 
-			if len(errorParts) > 0 {
-				return nil, fmt.Errorf("Jira API error (status %d): %s", resp.StatusCode, strings.Join(errorParts, ". "))
-			}
+// siteURL returns the address of the Jira web UI without trailing slashes
+func (jc *JiraClient) siteURL() string {
+	if jc.SiteURL != "" {
+		return strings.TrimRight(jc.SiteURL, "/")
+	}
+	return jc.baseURL()
+}
+
+// baseURL returns BaseURL without trailing slashes, so a context path such as /jira
+// is kept and https://host/ and https://host/jira/ join endpoints without a double slash
+func (jc *JiraClient) baseURL() string {
+	return strings.TrimRight(jc.BaseURL, "/")
+}
+
+// formatRichText returns the value to send for a rich text field such as a description
+// or comment body: plain text for API v2, an ADF document for API v3
+func (jc *JiraClient) formatRichText(text string) interface{} {
+	if jc.APIVersion == "3" {
+		return textToADF(text)
+	}
+	return text
+}
+
+// textToADF wraps plain text into a minimal Atlassian Document Format document.
+// Each line of the input becomes its own paragraph.
+func textToADF(text string) map[string]interface{} {
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	paragraphs := make([]interface{}, 0, len(lines))
+	for _, line := range lines {
+		// ADF text nodes must not be empty, so blank lines become empty paragraphs
+		content := []interface{}{}
+		if line != "" {
+			content = append(content, map[string]interface{}{
+				"type": "text",
+				"text": line,
+			})
 		}
+		paragraphs = append(paragraphs, map[string]interface{}{
+			"type":    "paragraph",
+			"content": content,
+		})
+	}
 
-		// Fallback to raw error message
-		return nil, fmt.Errorf("Jira API error (status %d): %s", resp.StatusCode, string(bodyBytes))
+	return map[string]interface{}{
+		"type":    "doc",
+		"version": 1,
+		"content": paragraphs,
 	}
+}
 
-	// Parse response
-	var comment map[string]interface{}
-	err = sonic.Unmarshal(bodyBytes, &comment)
+// readResponseBody reads the full response body and logs its status and size
+// (and, at debug level, the redacted body)
+func readResponseBody(resp *http.Response) ([]byte, error) {
+	bodyBytes, err := io.ReadAll(resp.Body)
 	if err != nil {
-		log.Printf("Failed to unmarshal comment response: %v, body: %s", err, string(bodyBytes))
-		return nil, fmt.Errorf("failed to unmarshal comment: %w", err)
+		return nil, fmt.Errorf("failed to read response: %w", err)
 	}
 
-	log.Printf("Successfully added comment to Jira issue %s: %v", issueKeyOrId, comment)
-	return comment, nil
+	log.Printf("Jira API response status: %d, body length: %d bytes", resp.StatusCode, len(bodyBytes))
+	if len(bodyBytes) > 0 {
+		Debugf("Jira API response body: %s", RedactJSON(bodyBytes))
+	}
+
+	return bodyBytes, nil
+}
+
+// parseJiraError builds a *JiraAPIError from a Jira error response body.
+// fieldErrorsLabel prefixes the field-specific errors in the error message.
+func parseJiraError(statusCode int, bodyBytes []byte, fieldErrorsLabel string) error {
+	apiErr := &JiraAPIError{
+		StatusCode:       statusCode,
+		fieldErrorsLabel: fieldErrorsLabel,
+		rawBody:          string(bodyBytes),
+	}
+
+	// Some endpoints return nested objects (not strings) under "errors", so decode
+	// the values generically and stringify them
+	var jiraError struct {
+		ErrorMessages []string               `json:"errorMessages"`
+		Errors        map[string]interface{} `json:"errors"`
+	}
+	if err := sonic.Unmarshal(bodyBytes, &jiraError); err == nil {
+		apiErr.ErrorMessages = jiraError.ErrorMessages
+		if len(jiraError.Errors) > 0 {
+			apiErr.Errors = make(map[string]string, len(jiraError.Errors))
+			for field, value := range jiraError.Errors {
+				apiErr.Errors[field] = stringifyErrorValue(value)
+			}
+		}
+	}
+	return apiErr
+}
+
+// stringifyErrorValue renders a Jira field error value as a readable string
+func stringifyErrorValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case []interface{}:
+		parts := make([]string, 0, len(v))
+		for _, item := range v {
+			parts = append(parts, stringifyErrorValue(item))
+		}
+		return strings.Join(parts, ", ")
+	}
+	if encoded, err := sonic.Marshal(value); err == nil {
+		return string(encoded)
+	}
+	return fmt.Sprintf("%v", value)
 }
@@ -0,0 +1,1139 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sorenhq/jira-plugin/credentials"
+)
+
+// TestMain silences the client's request logging, which would drown the test output
+func TestMain(m *testing.M) {
+	log.SetOutput(io.Discard)
+	os.Exit(m.Run())
+}
+
+// testCredentials are Jira Cloud style credentials for a test server; API v2 keeps
+// rich text fields as plain strings
+func testCredentials(instanceURL string) *credentials.JiraCredentials {
+	return &credentials.JiraCredentials{
+		InstanceURL: instanceURL,
+		Email:       "user@example.com",
+		APIToken:    "secret-token",
+		AuthMode:    AuthModeBasic,
+		APIVersion:  "2",
+	}
+}
+
+// newTestClient starts an httptest server running handler and returns a client for it
+// with fast retries and caching disabled
+func newTestClient(t *testing.T, handler http.HandlerFunc, opts ...ClientOption) *JiraClient {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	jc := NewJiraClient(testCredentials(server.URL), append([]ClientOption{WithCacheTTL(0)}, opts...)...)
+	jc.RetryBaseDelay = time.Millisecond
+	jc.RetryJitter = 0
+	return jc
+}
+
+// decodeJSONBody decodes the JSON body of a request received by a test server
+func decodeJSONBody(t *testing.T, r *http.Request) map[string]any {
+	t.Helper()
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		t.Fatalf("failed to read request body: %v", err)
+	}
+	var decoded map[string]any
+	if err := json.Unmarshal(body, &decoded); err != nil {
+		t.Fatalf("request body is not a JSON object: %v (%s)", err, body)
+	}
+	return decoded
+}
+
+// respondJSON writes a JSON response with the given status
+func respondJSON(w http.ResponseWriter, status int, body string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	io.WriteString(w, body)
+}
+
+// checkBasicAuth fails the test unless the request carries the test credentials
+func checkBasicAuth(t *testing.T, r *http.Request) {
+	t.Helper()
+	user, password, ok := r.BasicAuth()
+	if !ok || user != "user@example.com" || password != "secret-token" {
+		t.Errorf("Authorization = %q, want basic auth for user@example.com", r.Header.Get("Authorization"))
+	}
+}
+
+// errorResponseTests are the error responses every client method maps the same way
+var errorResponseTests = []struct {
+	name       string
+	status     int
+	body       string
+	sentinel   error
+	wantInText []string
+}{
+	{
+		name:       "400 with errorMessages and field errors",
+		status:     http.StatusBadRequest,
+		body:       `{"errorMessages":["Something is wrong"],"errors":{"summary":"You must specify a summary of the issue."}}`,
+		wantInText: []string{"status 400", "Something is wrong", "summary: You must specify a summary of the issue."},
+	},
+	{
+		name:       "401",
+		status:     http.StatusUnauthorized,
+		body:       `{"errorMessages":["You are not authenticated."]}`,
+		sentinel:   ErrUnauthorized,
+		wantInText: []string{"status 401", "You are not authenticated."},
+	},
+	{
+		name:       "403",
+		status:     http.StatusForbidden,
+		body:       `{"errorMessages":["You do not have permission."]}`,
+		sentinel:   ErrForbidden,
+		wantInText: []string{"status 403", "You do not have permission."},
+	},
+	{
+		name:       "404",
+		status:     http.StatusNotFound,
+		body:       `{"errorMessages":["Issue does not exist or you do not have permission to see it."]}`,
+		sentinel:   ErrNotFound,
+		wantInText: []string{"status 404"},
+	},
+}
+
+// checkAPIError fails the test unless err is a *JiraAPIError with the given status that
+// matches sentinel (if any) and mentions every string of wantInText
+func checkAPIError(t *testing.T, err error, status int, sentinel error, wantInText []string) {
+	t.Helper()
+	if err == nil {
+		t.Fatalf("expected an error for status %d", status)
+	}
+	var apiErr *JiraAPIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("error %v (%T) is not a *JiraAPIError", err, err)
+	}
+	if apiErr.StatusCode != status {
+		t.Errorf("StatusCode = %d, want %d", apiErr.StatusCode, status)
+	}
+	if sentinel != nil && !errors.Is(err, sentinel) {
+		t.Errorf("errors.Is(%v, %v) = false", err, sentinel)
+	}
+	for _, want := range wantInText {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not mention %q", err.Error(), want)
+		}
+	}
+}
+
+func TestCreateIssue(t *testing.T) {
+	jc := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/rest/api/2/issue" {
+			t.Errorf("request = %s %s, want POST /rest/api/2/issue", r.Method, r.URL.Path)
+		}
+		checkBasicAuth(t, r)
+		if got := r.Header.Get("Content-Type"); got != "application/json" {
+			t.Errorf("Content-Type = %q, want application/json", got)
+		}
+
+		fields, _ := decodeJSONBody(t, r)["fields"].(map[string]any)
+		project, _ := fields["project"].(map[string]any)
+		issueType, _ := fields["issuetype"].(map[string]any)
+		if project["key"] != "COM" || issueType["name"] != "Task" || fields["summary"] != "Fix login" {
+			t.Errorf("unexpected fields: %v", fields)
+		}
+		if fields["description"] != "Steps to reproduce" {
+			t.Errorf("description = %v, want the plain text (API v2)", fields["description"])
+		}
+		if fields["duedate"] != "2026-01-31" {
+			t.Errorf("duedate = %v, want the additional field", fields["duedate"])
+		}
+		respondJSON(w, http.StatusCreated, `{"id":"10000","key":"COM-1","self":"https://example.atlassian.net/rest/api/2/issue/10000"}`)
+	})
+
+	issue, err := jc.CreateIssue(context.Background(), "COM", "Task", "Fix login", "Steps to reproduce", map[string]interface{}{"duedate": "2026-01-31"})
+	if err != nil {
+		t.Fatalf("CreateIssue: %v", err)
+	}
+	if issue["key"] != "COM-1" || issue["id"] != "10000" {
+		t.Errorf("issue = %v, want COM-1", issue)
+	}
+}
+
+func TestCreateIssueErrors(t *testing.T) {
+	for _, tt := range errorResponseTests {
+		t.Run(tt.name, func(t *testing.T) {
+			jc := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				respondJSON(w, tt.status, tt.body)
+			})
+			_, err := jc.CreateIssue(context.Background(), "COM", "Task", "Fix login", "", nil)
+			checkAPIError(t, err, tt.status, tt.sentinel, tt.wantInText)
+		})
+	}
+
+	t.Run("field errors are labelled", func(t *testing.T) {
+		jc := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			respondJSON(w, http.StatusBadRequest, `{"errorMessages":[],"errors":{"priority":"Priority is required."}}`)
+		})
+		_, err := jc.CreateIssue(context.Background(), "COM", "Task", "Fix login", "", nil)
+		checkAPIError(t, err, http.StatusBadRequest, nil, []string{"Missing or invalid fields: priority: Priority is required."})
+		var apiErr *JiraAPIError
+		if errors.As(err, &apiErr) && apiErr.Errors["priority"] != "Priority is required." {
+			t.Errorf("Errors = %v, want the priority field error", apiErr.Errors)
+		}
+	})
+}
+
+func TestCreateIssueRetriesServerErrors(t *testing.T) {
+	var attempts atomic.Int32
+	jc := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		respondJSON(w, http.StatusInternalServerError, `{"errorMessages":["Internal server error"]}`)
+	})
+
+	_, err := jc.CreateIssue(context.Background(), "COM", "Task", "Fix login", "", nil)
+	checkAPIError(t, err, http.StatusInternalServerError, nil, []string{"Internal server error"})
+	if got, want := int(attempts.Load()), jc.MaxRetries+1; got != want {
+		t.Errorf("attempts = %d, want %d", got, want)
+	}
+	if ErrorCode(err) != "jira_unavailable" {
+		t.Errorf("ErrorCode = %q, want jira_unavailable", ErrorCode(err))
+	}
+}
+
+func TestCreateIssueRecoversFromServerError(t *testing.T) {
+	var attempts atomic.Int32
+	jc := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		// The retried request must carry the same body
+		fields, _ := decodeJSONBody(t, r)["fields"].(map[string]any)
+		if fields["summary"] != "Fix login" {
+			t.Errorf("attempt %d lost the request body: %v", attempts.Load()+1, fields)
+		}
+		if attempts.Add(1) == 1 {
+			respondJSON(w, http.StatusBadGateway, `bad gateway`)
+			return
+		}
+		respondJSON(w, http.StatusCreated, `{"id":"10000","key":"COM-1"}`)
+	})
+
+	issue, err := jc.CreateIssue(context.Background(), "COM", "Task", "Fix login", "", nil)
+	if err != nil {
+		t.Fatalf("CreateIssue: %v", err)
+	}
+	if issue["key"] != "COM-1" || attempts.Load() != 2 {
+		t.Errorf("issue = %v after %d attempts, want COM-1 after 2", issue, attempts.Load())
+	}
+}
+
+func TestDeleteIssue(t *testing.T) {
+	for _, deleteSubtasks := range []bool{false, true} {
+		jc := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodDelete || r.URL.Path != "/rest/api/2/issue/COM-1" {
+				t.Errorf("request = %s %s, want DELETE /rest/api/2/issue/COM-1", r.Method, r.URL.Path)
+			}
+			checkBasicAuth(t, r)
+			want := ""
+			if deleteSubtasks {
+				want = "true"
+			}
+			if got := r.URL.Query().Get("deleteSubtasks"); got != want {
+				t.Errorf("deleteSubtasks = %q, want %q", got, want)
+			}
+			w.WriteHeader(http.StatusNoContent)
+		})
+		if err := jc.DeleteIssue(context.Background(), "COM-1", deleteSubtasks); err != nil {
+			t.Errorf("DeleteIssue(deleteSubtasks=%v): %v", deleteSubtasks, err)
+		}
+	}
+}
+
+func TestDeleteIssueErrors(t *testing.T) {
+	for _, tt := range errorResponseTests {
+		t.Run(tt.name, func(t *testing.T) {
+			jc := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				respondJSON(w, tt.status, tt.body)
+			})
+			err := jc.DeleteIssue(context.Background(), "COM-1", false)
+			checkAPIError(t, err, tt.status, tt.sentinel, tt.wantInText)
+		})
+	}
+
+	t.Run("503", func(t *testing.T) {
+		jc := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			respondJSON(w, http.StatusServiceUnavailable, `{"errorMessages":["Service unavailable"]}`)
+		})
+		err := jc.DeleteIssue(context.Background(), "COM-1", false)
+		checkAPIError(t, err, http.StatusServiceUnavailable, nil, []string{"Service unavailable"})
+	})
+}
+
+func TestAddComment(t *testing.T) {
+	jc := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/rest/api/2/issue/COM-1/comment" {
+			t.Errorf("request = %s %s, want POST /rest/api/2/issue/COM-1/comment", r.Method, r.URL.Path)
+		}
+		checkBasicAuth(t, r)
+
+		body := decodeJSONBody(t, r)
+		if body["body"] != "Deployed to staging" {
+			t.Errorf("body = %v, want the plain comment text (API v2)", body["body"])
+		}
+		visibility, _ := body["visibility"].(map[string]any)
+		if visibility["type"] != "role" || visibility["value"] != "Developers" {
+			t.Errorf("visibility = %v, want role Developers", body["visibility"])
+		}
+		if _, ok := body["properties"]; ok {
+			t.Errorf("properties = %v, want none for a public comment", body["properties"])
+		}
+		respondJSON(w, http.StatusCreated, `{"id":"10100","body":"Deployed to staging"}`)
+	})
+
+	visibility := map[string]interface{}{"type": "role", "value": "Developers"}
+	comment, err := jc.AddComment(context.Background(), "COM-1", "Deployed to staging", visibility, nil, false)
+	if err != nil {
+		t.Fatalf("AddComment: %v", err)
+	}
+	if comment["id"] != "10100" {
+		t.Errorf("comment = %v, want ID 10100", comment)
+	}
+}
+
+func TestAddCommentErrors(t *testing.T) {
+	for _, tt := range errorResponseTests {
+		t.Run(tt.name, func(t *testing.T) {
+			jc := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				respondJSON(w, tt.status, tt.body)
+			})
+			_, err := jc.AddComment(context.Background(), "COM-1", "Deployed", nil, nil, false)
+			checkAPIError(t, err, tt.status, tt.sentinel, tt.wantInText)
+		})
+	}
+
+	t.Run("500", func(t *testing.T) {
+		jc := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			respondJSON(w, http.StatusInternalServerError, `{"errorMessages":["Internal server error"]}`)
+		})
+		_, err := jc.AddComment(context.Background(), "COM-1", "Deployed", nil, nil, false)
+		checkAPIError(t, err, http.StatusInternalServerError, nil, []string{"Internal server error"})
+	})
+}
+
+func TestListProjects(t *testing.T) {
+	var requests atomic.Int32
+	jc := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.Method != http.MethodGet || r.URL.Path != "/rest/api/2/project/search" {
+			t.Errorf("request = %s %s, want GET /rest/api/2/project/search", r.Method, r.URL.Path)
+		}
+		checkBasicAuth(t, r)
+		switch r.URL.Query().Get("startAt") {
+		case "0":
+			respondJSON(w, http.StatusOK, `{"startAt":0,"maxResults":2,"total":3,"isLast":false,"values":[{"key":"COM"},{"key":"OPS"}]}`)
+		case "2":
+			respondJSON(w, http.StatusOK, `{"startAt":2,"maxResults":2,"total":3,"isLast":true,"values":[{"key":"WEB"}]}`)
+		default:
+			t.Errorf("unexpected startAt %q", r.URL.Query().Get("startAt"))
+			respondJSON(w, http.StatusOK, `{"values":[],"isLast":true}`)
+		}
+	})
+
+	projects, err := jc.ListProjects(context.Background())
+	if err != nil {
+		t.Fatalf("ListProjects: %v", err)
+	}
+	var keys []string
+	for _, project := range projects {
+		key, _ := project["key"].(string)
+		keys = append(keys, key)
+	}
+	if strings.Join(keys, ",") != "COM,OPS,WEB" {
+		t.Errorf("project keys = %v, want COM,OPS,WEB", keys)
+	}
+	if requests.Load() != 2 {
+		t.Errorf("requests = %d, want 2 pages", requests.Load())
+	}
+}
+
+func TestListProjectsErrors(t *testing.T) {
+	for _, tt := range errorResponseTests {
+		t.Run(tt.name, func(t *testing.T) {
+			jc := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				respondJSON(w, tt.status, tt.body)
+			})
+			_, err := jc.ListProjects(context.Background())
+			checkAPIError(t, err, tt.status, tt.sentinel, tt.wantInText)
+		})
+	}
+
+	t.Run("500", func(t *testing.T) {
+		jc := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			respondJSON(w, http.StatusInternalServerError, `{"errorMessages":["Internal server error"]}`)
+		})
+		_, err := jc.ListProjects(context.Background())
+		checkAPIError(t, err, http.StatusInternalServerError, nil, []string{"Internal server error"})
+	})
+}
+
+func TestAuthHeader(t *testing.T) {
+	tests := []struct {
+		name     string
+		authMode string
+		want     string
+	}{
+		{"basic", AuthModeBasic, "Basic dXNlckBleGFtcGxlLmNvbTpzZWNyZXQtdG9rZW4="},
+		{"bearer", AuthModeBearer, "Bearer secret-token"},
+		// A non-Cloud URL without a detected mode defaults to Bearer
+		{"derived from URL", "", "Bearer secret-token"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = r.Header.Get("Authorization")
+				respondJSON(w, http.StatusOK, `{"accountId":"abc"}`)
+			}))
+			defer server.Close()
+
+			creds := testCredentials(server.URL)
+			creds.AuthMode = tt.authMode
+			if _, err := NewJiraClient(creds).TestConnection(context.Background()); err != nil {
+				t.Fatalf("TestConnection: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Authorization = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDefaultAuthMode(t *testing.T) {
+	tests := map[string]string{
+		"https://example.atlassian.net":          AuthModeBasic,
+		"https://EXAMPLE.atlassian.net/":         AuthModeBasic,
+		"example.atlassian.net":                  AuthModeBasic,
+		"https://jira.example.com":               AuthModeBearer,
+		"https://atlassian.net.example.com":      AuthModeBearer,
+		"https://jira.example.com/atlassian.net": AuthModeBearer,
+	}
+	for instanceURL, want := range tests {
+		if got := defaultAuthMode(instanceURL); got != want {
+			t.Errorf("defaultAuthMode(%q) = %q, want %q", instanceURL, got, want)
+		}
+	}
+}
+
+func TestAddWatcher(t *testing.T) {
+	jc := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/rest/api/2/issue/COM-1/watchers" {
+			t.Errorf("request = %s %s, want POST /rest/api/2/issue/COM-1/watchers", r.Method, r.URL.Path)
+		}
+		// The account ID is sent as a bare JSON string, not an object
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != `"5b10ac8d82e05b22cc7d4ef5"` {
+			t.Errorf("body = %s, want the quoted account ID", body)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	if err := jc.AddWatcher(context.Background(), "COM-1", "5b10ac8d82e05b22cc7d4ef5"); err != nil {
+		t.Fatalf("AddWatcher: %v", err)
+	}
+}
+
+func TestAddWatcherNotFound(t *testing.T) {
+	jc := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		respondJSON(w, http.StatusNotFound, `{"errorMessages":["Issue does not exist"]}`)
+	})
+
+	err := jc.AddWatcher(context.Background(), "COM-404", "5b10ac8d82e05b22cc7d4ef5")
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("AddWatcher error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestRemoveWatcher(t *testing.T) {
+	jc := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete || r.URL.Path != "/rest/api/2/issue/COM-1/watchers" {
+			t.Errorf("request = %s %s, want DELETE /rest/api/2/issue/COM-1/watchers", r.Method, r.URL.Path)
+		}
+		if got := r.URL.Query().Get("accountId"); got != "557058:f58131cb&x" {
+			t.Errorf("accountId = %q, want the escaped account ID", got)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	if err := jc.RemoveWatcher(context.Background(), "COM-1", "557058:f58131cb&x"); err != nil {
+		t.Fatalf("RemoveWatcher: %v", err)
+	}
+}
+
+func TestListWatchers(t *testing.T) {
+	jc := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/rest/api/2/issue/COM-1/watchers" {
+			t.Errorf("request = %s %s, want GET /rest/api/2/issue/COM-1/watchers", r.Method, r.URL.Path)
+		}
+		respondJSON(w, http.StatusOK, `{"isWatching":true,"watchCount":1,"watchers":[{"accountId":"abc","displayName":"Ada"}]}`)
+	})
+
+	watchers, err := jc.ListWatchers(context.Background(), "COM-1")
+	if err != nil {
+		t.Fatalf("ListWatchers: %v", err)
+	}
+	list, _ := watchers["watchers"].([]interface{})
+	if watchers["watchCount"] != float64(1) || len(list) != 1 {
+		t.Errorf("watchers = %v, want one watcher", watchers)
+	}
+}
+
+func TestParseJiraErrorNestedValues(t *testing.T) {
+	body := `{"errorMessages":[],"errors":{"customfield_10010":{"message":"bad","code":1},"labels":["too long","invalid"],"summary":"required"}}`
+	err := parseJiraError(http.StatusBadRequest, []byte(body), "Field errors")
+
+	var apiErr *JiraAPIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("error %v (%T) is not a *JiraAPIError", err, err)
+	}
+	want := map[string]string{
+		"labels":  "too long, invalid",
+		"summary": "required",
+	}
+	for field, msg := range want {
+		if apiErr.Errors[field] != msg {
+			t.Errorf("Errors[%q] = %q, want %q", field, apiErr.Errors[field], msg)
+		}
+	}
+	// Objects are kept as JSON; key order isn't guaranteed
+	var nested map[string]any
+	if err := json.Unmarshal([]byte(apiErr.Errors["customfield_10010"]), &nested); err != nil || nested["message"] != "bad" {
+		t.Errorf("Errors[customfield_10010] = %q, want the object as JSON", apiErr.Errors["customfield_10010"])
+	}
+	// The decoded errors are reported, not the raw body
+	text := err.Error()
+	if strings.Contains(text, `"errorMessages"`) {
+		t.Errorf("error %q fell back to the raw body", text)
+	}
+	for _, part := range []string{"Field errors: ", "labels: too long, invalid", "summary: required"} {
+		if !strings.Contains(text, part) {
+			t.Errorf("error %q does not mention %q", text, part)
+		}
+	}
+}
+
+func TestParseJiraErrorNonJSONBody(t *testing.T) {
+	err := parseJiraError(http.StatusBadGateway, []byte("<html>Bad Gateway</html>"), "")
+	if got := err.Error(); got != "Jira API error (status 502): <html>Bad Gateway</html>" {
+		t.Errorf("error = %q, want the raw body fallback", got)
+	}
+}
+
+func TestCreateIssueKeepsFalsyAdditionalFields(t *testing.T) {
+	jc := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		fields, _ := decodeJSONBody(t, r)["fields"].(map[string]any)
+		want := map[string]any{"customfield_1": false, "customfield_2": float64(0), "customfield_3": ""}
+		for key, value := range want {
+			got, ok := fields[key]
+			if !ok || got != value {
+				t.Errorf("fields[%q] = %#v (present: %v), want %#v", key, got, ok, value)
+			}
+		}
+		if _, ok := fields["customfield_4"]; ok {
+			t.Errorf("nil customfield_4 was sent: %v", fields)
+		}
+		respondJSON(w, http.StatusCreated, `{"id":"10000","key":"COM-1"}`)
+	})
+
+	additionalFields := map[string]interface{}{
+		"customfield_1": false,
+		"customfield_2": 0,
+		"customfield_3": "",
+		"customfield_4": nil,
+	}
+	if _, err := jc.CreateIssue(context.Background(), "COM", "Task", "Fix login", "", additionalFields); err != nil {
+		t.Fatalf("CreateIssue: %v", err)
+	}
+}
+
+func TestAddCommentKeepsFalsyAdditionalFields(t *testing.T) {
+	jc := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		body := decodeJSONBody(t, r)
+		if got, ok := body["notify"]; !ok || got != false {
+			t.Errorf("notify = %#v (present: %v), want false", got, ok)
+		}
+		if _, ok := body["extra"]; ok {
+			t.Errorf("nil extra was sent: %v", body)
+		}
+		respondJSON(w, http.StatusCreated, `{"id":"10100"}`)
+	})
+
+	additionalFields := map[string]interface{}{"notify": false, "extra": nil}
+	if _, err := jc.AddComment(context.Background(), "COM-1", "Deployed", nil, additionalFields, false); err != nil {
+		t.Fatalf("AddComment: %v", err)
+	}
+}
+
+func TestListProjectsEmpty(t *testing.T) {
+	for name, body := range map[string]string{
+		"empty page":  `{"startAt":0,"maxResults":50,"total":0,"isLast":true,"values":[]}`,
+		"empty array": `[]`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			jc := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				respondJSON(w, http.StatusOK, body)
+			})
+			projects, err := jc.ListProjects(context.Background())
+			if err != nil {
+				t.Fatalf("ListProjects: %v", err)
+			}
+			if len(projects) != 0 {
+				t.Errorf("projects = %v, want none", projects)
+			}
+		})
+	}
+}
+
+func TestListProjectsUnexpectedResponse(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		wantInText []string
+	}{
+		{
+			name:       "error wrapper with status 200",
+			body:       `{"errorMessages":["Gateway says no"]}`,
+			wantInText: []string{"without a project list", "Gateway says no"},
+		},
+		{
+			name:       "HTML login page",
+			body:       `<html><body>Please log in</body></html>`,
+			wantInText: []string{"non-JSON projects response", "Please log in"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jc := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+				io.WriteString(w, tt.body)
+			})
+			_, err := jc.ListProjects(context.Background())
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			for _, want := range tt.wantInText {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("error %q does not mention %q", err.Error(), want)
+				}
+			}
+		})
+	}
+}
+
+func TestBulkCreateIssuesPartialFailure(t *testing.T) {
+	jc := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/rest/api/2/issue/bulk" {
+			t.Errorf("request = %s %s, want POST /rest/api/2/issue/bulk", r.Method, r.URL.Path)
+		}
+		updates, _ := decodeJSONBody(t, r)["issueUpdates"].([]any)
+		if len(updates) != 3 {
+			t.Errorf("issueUpdates has %d rows, want 3", len(updates))
+		}
+		respondJSON(w, http.StatusCreated, `{
+			"issues": [
+				{"id": "10001", "key": "COM-1", "self": "https://example.atlassian.net/rest/api/2/issue/10001"},
+				{"id": "10002", "key": "COM-2", "self": "https://example.atlassian.net/rest/api/2/issue/10002"}
+			],
+			"errors": [{
+				"status": 400,
+				"elementErrors": {
+					"errorMessages": [],
+					"errors": {"summary": "You must specify a summary of the issue.", "labels": ["too long"]},
+					"warningMessages": ["customfield_10050 was ignored"]
+				},
+				"failedElementNumber": 1
+			}],
+			"warningMessages": ["Notifications are disabled"]
+		}`)
+	})
+
+	issues := []map[string]interface{}{
+		{"projectKey": "COM", "issueType": "Task", "summary": "First"},
+		{"projectKey": "COM", "issueType": "Task"},
+		{"projectKey": "COM", "issueType": "Task", "summary": "Third"},
+	}
+	result, err := jc.BulkCreateIssues(context.Background(), issues, nil)
+	if err != nil {
+		t.Fatalf("BulkCreateIssues: %v", err)
+	}
+	if result["created"] != 2 || result["failed"] != 1 {
+		t.Errorf("created/failed = %v/%v, want 2/1", result["created"], result["failed"])
+	}
+
+	rows, _ := result["issues"].([]map[string]interface{})
+	if len(rows) != 3 {
+		t.Fatalf("issues = %v, want 3 rows", result["issues"])
+	}
+	// Created issues are matched to the rows without an error, in order
+	if rows[0]["key"] != "COM-1" || rows[2]["key"] != "COM-2" || rows[2]["index"] != 2 {
+		t.Errorf("created rows = %v, %v, want COM-1 and COM-2", rows[0], rows[2])
+	}
+	failed := rows[1]
+	if failed["status"] != http.StatusBadRequest || failed["index"] != 1 {
+		t.Errorf("failed row = %v, want status 400 at index 1", failed)
+	}
+	fieldErrors, _ := failed["fieldErrors"].(map[string]string)
+	if fieldErrors["summary"] != "You must specify a summary of the issue." || fieldErrors["labels"] != "too long" {
+		t.Errorf("fieldErrors = %v", failed["fieldErrors"])
+	}
+	if text, _ := failed["error"].(string); !strings.Contains(text, "Missing or invalid fields") {
+		t.Errorf("error = %q, want the field errors", text)
+	}
+
+	warnings, _ := result["warnings"].([]string)
+	if strings.Join(warnings, "|") != "Notifications are disabled|issue 1: customfield_10050 was ignored" {
+		t.Errorf("warnings = %v, want the batch and row warnings", warnings)
+	}
+}
+
+func TestBulkCreateIssuesAllRowsFailed(t *testing.T) {
+	jc := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		respondJSON(w, http.StatusBadRequest, `{
+			"issues": [],
+			"errors": [
+				{"status": 400, "elementErrors": {"errors": {"project": "project is required"}}, "failedElementNumber": 0},
+				{"status": 400, "elementErrors": {"errorMessages": ["Issue type is invalid"]}, "failedElementNumber": 1}
+			]
+		}`)
+	})
+
+	issues := []map[string]interface{}{{"summary": "First"}, {"projectKey": "COM", "summary": "Second"}}
+	result, err := jc.BulkCreateIssues(context.Background(), issues, nil)
+	if err != nil {
+		t.Fatalf("BulkCreateIssues: %v", err)
+	}
+	if result["created"] != 0 || result["failed"] != 2 {
+		t.Errorf("created/failed = %v/%v, want 0/2", result["created"], result["failed"])
+	}
+	rows, _ := result["issues"].([]map[string]interface{})
+	if messages, _ := rows[1]["errorMessages"].([]string); len(messages) != 1 || messages[0] != "Issue type is invalid" {
+		t.Errorf("row 1 errorMessages = %v", rows[1]["errorMessages"])
+	}
+}
+
+func TestBulkCreateIssuesRequestFailure(t *testing.T) {
+	jc := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		respondJSON(w, http.StatusForbidden, `{"errorMessages":["You do not have permission to create issues."]}`)
+	})
+
+	var progress []int
+	issues := []map[string]interface{}{{"summary": "First"}, {"summary": "Second"}}
+	result, err := jc.BulkCreateIssues(context.Background(), issues, func(done, total int) {
+		progress = append(progress, done, total)
+	})
+	if err != nil {
+		t.Fatalf("BulkCreateIssues: %v", err)
+	}
+	// The whole batch failed, so every row carries the request error
+	rows, _ := result["issues"].([]map[string]interface{})
+	for i, row := range rows {
+		if row["status"] != http.StatusForbidden || row["index"] != i {
+			t.Errorf("row %d = %v, want status 403", i, row)
+		}
+	}
+	if result["failed"] != 2 {
+		t.Errorf("failed = %v, want 2", result["failed"])
+	}
+	if len(progress) != 2 || progress[0] != 2 || progress[1] != 2 {
+		t.Errorf("progress = %v, want one report of 2/2", progress)
+	}
+}
+
+func TestBaseURLContextPath(t *testing.T) {
+	tests := []struct {
+		name       string
+		suffix     string
+		wantPrefix string
+	}{
+		{name: "root", suffix: "", wantPrefix: ""},
+		{name: "root with trailing slash", suffix: "/", wantPrefix: ""},
+		{name: "context path", suffix: "/jira", wantPrefix: "/jira"},
+		{name: "context path with trailing slashes", suffix: "/jira//", wantPrefix: "/jira"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotPath string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotPath = r.URL.Path
+				respondJSON(w, http.StatusNoContent, "")
+			}))
+			t.Cleanup(server.Close)
+
+			jc := NewJiraClient(testCredentials(server.URL+tt.suffix), WithCacheTTL(0))
+			if err := jc.DeleteIssue(context.Background(), "COM-1", false); err != nil {
+				t.Fatalf("DeleteIssue: %v", err)
+			}
+			if want := tt.wantPrefix + "/rest/api/2/issue/COM-1"; gotPath != want {
+				t.Errorf("path = %q, want %q", gotPath, want)
+			}
+			if got, want := jc.BrowseURL("COM-1"), server.URL+tt.wantPrefix+"/browse/COM-1"; got != want {
+				t.Errorf("BrowseURL = %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestBackoffDelay(t *testing.T) {
+	jc := NewJiraClient(testCredentials("https://example.atlassian.net"))
+	jc.RetryBaseDelay = 100 * time.Millisecond
+
+	jc.RetryJitter = 0
+	for attempt, want := range []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond, 800 * time.Millisecond} {
+		if got := jc.backoffDelay(attempt); got != want {
+			t.Errorf("backoffDelay(%d) = %v, want %v", attempt, got, want)
+		}
+	}
+
+	// Jitter adds up to RetryJitter of the delay on top
+	jc.RetryJitter = 0.5
+	for range 50 {
+		if got := jc.backoffDelay(1); got < 200*time.Millisecond || got > 300*time.Millisecond {
+			t.Fatalf("backoffDelay(1) = %v, want 200ms-300ms", got)
+		}
+	}
+}
+
+func TestClientErrorsAreNotRetried(t *testing.T) {
+	var attempts atomic.Int32
+	jc := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		respondJSON(w, http.StatusBadRequest, `{"errorMessages":["Bad JQL"]}`)
+	})
+
+	err := jc.DeleteIssue(context.Background(), "COM-1", false)
+	checkAPIError(t, err, http.StatusBadRequest, nil, []string{"Bad JQL"})
+	if attempts.Load() != 1 {
+		t.Errorf("attempts = %d, want 1 (4xx responses are not retried)", attempts.Load())
+	}
+}
+
+func TestRetriesNetworkErrors(t *testing.T) {
+	var attempts atomic.Int32
+	jc := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) == 1 {
+			// Drop the connection without a response
+			conn, _, err := w.(http.Hijacker).Hijack()
+			if err != nil {
+				t.Errorf("Hijack: %v", err)
+				return
+			}
+			conn.Close()
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	if err := jc.DeleteIssue(context.Background(), "COM-1", false); err != nil {
+		t.Fatalf("DeleteIssue: %v", err)
+	}
+	if attempts.Load() != 2 {
+		t.Errorf("attempts = %d, want 2", attempts.Load())
+	}
+}
+
+func TestRetryBudget(t *testing.T) {
+	var attempts atomic.Int32
+	jc := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		respondJSON(w, http.StatusServiceUnavailable, `{"errorMessages":["Down for maintenance"]}`)
+	})
+	jc.RetryBaseDelay = time.Hour
+	jc.MaxRetryElapsedTime = time.Second
+
+	err := jc.DeleteIssue(context.Background(), "COM-1", false)
+	var budgetErr *RetryBudgetError
+	if !errors.As(err, &budgetErr) || budgetErr.LastStatus != http.StatusServiceUnavailable {
+		t.Fatalf("error = %v, want a RetryBudgetError for status 503", err)
+	}
+	if ErrorCode(err) != "retries_exhausted" {
+		t.Errorf("ErrorCode = %q, want retries_exhausted", ErrorCode(err))
+	}
+	if attempts.Load() != 1 {
+		t.Errorf("attempts = %d, want 1 (the backoff exceeds the budget)", attempts.Load())
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	tests := map[string]time.Duration{
+		"":            0,
+		"0":           0,
+		"5":           5 * time.Second,
+		" 120 ":       2 * time.Minute,
+		"-1":          0,
+		"soon":        0,
+		"Wed, 21 Oct": 0,
+	}
+	for value, want := range tests {
+		if got := parseRetryAfter(value); got != want {
+			t.Errorf("parseRetryAfter(%q) = %v, want %v", value, got, want)
+		}
+	}
+
+	// HTTP-dates are turned into the time left until then
+	date := time.Now().Add(30 * time.Second).UTC().Format(http.TimeFormat)
+	if got := parseRetryAfter(date); got <= 28*time.Second || got > 30*time.Second {
+		t.Errorf("parseRetryAfter(%q) = %v, want about 30s", date, got)
+	}
+	past := time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat)
+	if got := parseRetryAfter(past); got != 0 {
+		t.Errorf("parseRetryAfter(%q) = %v, want 0 for a past date", past, got)
+	}
+}
+
+func TestRateLimitRetries(t *testing.T) {
+	var attempts atomic.Int32
+	jc := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) <= 2 {
+			respondJSON(w, http.StatusTooManyRequests, `{"errorMessages":["Rate limit exceeded"]}`)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	if err := jc.DeleteIssue(context.Background(), "COM-1", false); err != nil {
+		t.Fatalf("DeleteIssue: %v", err)
+	}
+	if attempts.Load() != 3 {
+		t.Errorf("attempts = %d, want 3", attempts.Load())
+	}
+}
+
+func TestRateLimitExhausted(t *testing.T) {
+	var attempts atomic.Int32
+	jc := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.Header().Set("Retry-After", "0")
+		respondJSON(w, http.StatusTooManyRequests, `{"errorMessages":["Rate limit exceeded"]}`)
+	})
+	jc.MaxRateLimitRetries = 2
+
+	err := jc.DeleteIssue(context.Background(), "COM-1", false)
+	var rateLimitErr *RateLimitError
+	if !errors.As(err, &rateLimitErr) || rateLimitErr.Retries != 2 {
+		t.Fatalf("error = %v, want a RateLimitError after 2 retries", err)
+	}
+	if ErrorCode(err) != "rate_limited" {
+		t.Errorf("ErrorCode = %q, want rate_limited", ErrorCode(err))
+	}
+	if attempts.Load() != 3 {
+		t.Errorf("attempts = %d, want 3", attempts.Load())
+	}
+}
+
+func TestRateLimitHonorsRetryAfter(t *testing.T) {
+	var attempts atomic.Int32
+	jc := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.Header().Set("Retry-After", "120")
+		respondJSON(w, http.StatusTooManyRequests, `{}`)
+	})
+	// A two minute wait doesn't fit the budget, so the client gives up instead of sleeping
+	jc.MaxRetryElapsedTime = time.Second
+
+	start := time.Now()
+	err := jc.DeleteIssue(context.Background(), "COM-1", false)
+	var budgetErr *RetryBudgetError
+	if !errors.As(err, &budgetErr) || budgetErr.LastStatus != http.StatusTooManyRequests {
+		t.Fatalf("error = %v, want a RetryBudgetError for status 429", err)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("gave up after %v, want no wait", elapsed)
+	}
+	if attempts.Load() != 1 {
+		t.Errorf("attempts = %d, want 1", attempts.Load())
+	}
+}
+
+func TestTextToADF(t *testing.T) {
+	doc := textToADF("First line\r\n\nThird line")
+	if doc["type"] != "doc" || doc["version"] != 1 {
+		t.Fatalf("doc = %v, want an ADF version 1 document", doc)
+	}
+	paragraphs, _ := doc["content"].([]interface{})
+	if len(paragraphs) != 3 {
+		t.Fatalf("content = %v, want 3 paragraphs", doc["content"])
+	}
+	wantTexts := []string{"First line", "", "Third line"}
+	for i, want := range wantTexts {
+		paragraph, _ := paragraphs[i].(map[string]interface{})
+		content, _ := paragraph["content"].([]interface{})
+		if paragraph["type"] != "paragraph" {
+			t.Errorf("node %d = %v, want a paragraph", i, paragraph)
+		}
+		if want == "" {
+			// ADF rejects empty text nodes
+			if len(content) != 0 {
+				t.Errorf("paragraph %d = %v, want no text node", i, paragraph)
+			}
+			continue
+		}
+		text, _ := content[0].(map[string]interface{})
+		if len(content) != 1 || text["type"] != "text" || text["text"] != want {
+			t.Errorf("paragraph %d = %v, want text %q", i, paragraph, want)
+		}
+	}
+}
+
+func TestRichTextByAPIVersion(t *testing.T) {
+	for _, version := range []string{"2", "3"} {
+		t.Run("v"+version, func(t *testing.T) {
+			jc := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if want := "/rest/api/" + version + "/issue/COM-1/comment"; r.URL.Path != want {
+					t.Errorf("path = %s, want %s", r.URL.Path, want)
+				}
+				body := decodeJSONBody(t, r)
+				if version == "2" {
+					if body["body"] != "Deployed" {
+						t.Errorf("body = %v, want plain text", body["body"])
+					}
+				} else if doc, _ := body["body"].(map[string]any); doc["type"] != "doc" {
+					t.Errorf("body = %v, want an ADF document", body["body"])
+				}
+				respondJSON(w, http.StatusCreated, `{"id":"10100"}`)
+			})
+			jc.APIVersion = version
+
+			if _, err := jc.AddComment(context.Background(), "COM-1", "Deployed", nil, nil, false); err != nil {
+				t.Fatalf("AddComment: %v", err)
+			}
+		})
+	}
+}
+
+// concurrencyTracker records the highest number of requests a test server handled at once
+type concurrencyTracker struct {
+	inFlight atomic.Int32
+	peak     atomic.Int32
+}
+
+// enter marks a request as started and holds it briefly so concurrent requests overlap;
+// the returned function marks it as finished
+func (c *concurrencyTracker) enter() func() {
+	current := c.inFlight.Add(1)
+	for {
+		peak := c.peak.Load()
+		if current <= peak || c.peak.CompareAndSwap(peak, current) {
+			break
+		}
+	}
+	time.Sleep(5 * time.Millisecond)
+	return func() { c.inFlight.Add(-1) }
+}
+
+func TestBulkDeleteIssues(t *testing.T) {
+	var tracker concurrencyTracker
+	var mu sync.Mutex
+	deletes := map[string]int{}
+	jc := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		defer tracker.enter()()
+		key := strings.TrimPrefix(r.URL.Path, "/rest/api/2/issue/")
+		mu.Lock()
+		deletes[key]++
+		mu.Unlock()
+		if key == "COM-404" {
+			respondJSON(w, http.StatusNotFound, `{"errorMessages":["Issue does not exist"]}`)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	keys := []string{"COM-1", "COM-2", "COM-3", "COM-404", "COM-4", "COM-5", "COM-6", "COM-7", "COM-8", "COM-1"}
+	var progress atomic.Int32
+	result, err := jc.BulkDeleteIssues(context.Background(), keys, false, func(done, total int) {
+		progress.Add(1)
+		if total != 9 {
+			t.Errorf("progress total = %d, want 9 distinct keys", total)
+		}
+	})
+	if err != nil {
+		t.Fatalf("BulkDeleteIssues: %v", err)
+	}
+
+	if result["deleted"] != 8 || result["failed"] != 1 {
+		t.Errorf("deleted/failed = %v/%v, want 8/1", result["deleted"], result["failed"])
+	}
+	issues, _ := result["issues"].(map[string]interface{})
+	if len(issues) != 9 {
+		t.Errorf("issues = %v, want one result per distinct key", issues)
+	}
+	if outcome, _ := issues["COM-1"].(map[string]interface{}); outcome["deleted"] != true {
+		t.Errorf("COM-1 = %v, want deleted", issues["COM-1"])
+	}
+	if outcome, _ := issues["COM-404"].(map[string]interface{}); outcome["deleted"] != false || outcome["code"] != "not_found" {
+		t.Errorf("COM-404 = %v, want a not_found failure", issues["COM-404"])
+	}
+	// Repeated keys are deleted once, so they don't report a spurious not_found
+	if deletes["COM-1"] != 1 {
+		t.Errorf("COM-1 was deleted %d times, want once", deletes["COM-1"])
+	}
+	if progress.Load() != 9 {
+		t.Errorf("progress was reported %d times, want 9", progress.Load())
+	}
+	if peak := tracker.peak.Load(); peak > bulkIssueWorkers {
+		t.Errorf("%d deletes ran at once, want at most %d", peak, bulkIssueWorkers)
+	}
+}
+
+func TestGetProjectDetails(t *testing.T) {
+	var tracker concurrencyTracker
+	jc := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		defer tracker.enter()()
+		key := strings.TrimPrefix(r.URL.Path, "/rest/api/2/project/")
+		if key == "GONE" {
+			respondJSON(w, http.StatusNotFound, `{"errorMessages":["No project could be found with key 'GONE'."]}`)
+			return
+		}
+		respondJSON(w, http.StatusOK, fmt.Sprintf(`{"key":%q,"lead":{"displayName":"Ada"}}`, key))
+	})
+
+	var projects []map[string]interface{}
+	for _, key := range []string{"P1", "P2", "GONE", "P3", "P4", "P5", "P6"} {
+		projects = append(projects, map[string]interface{}{"key": key, "name": "Project " + key})
+	}
+	detailed := jc.GetProjectDetails(context.Background(), projects, 3, nil)
+
+	if len(detailed) != len(projects) {
+		t.Fatalf("got %d projects, want %d", len(detailed), len(projects))
+	}
+	for i, project := range detailed {
+		// The input order is kept
+		if project["key"] != projects[i]["key"] {
+			t.Errorf("detailed[%d] = %v, want %v", i, project["key"], projects[i]["key"])
+		}
+	}
+	if _, ok := detailed[0]["lead"]; !ok {
+		t.Errorf("detailed[0] = %v, want the project details", detailed[0])
+	}
+	gone := detailed[2]
+	if gone["name"] != "Project GONE" || gone["error"] == nil {
+		t.Errorf("detailed[2] = %v, want the list entry with an error", gone)
+	}
+	if _, ok := projects[2]["error"]; ok {
+		t.Error("the input project was modified")
+	}
+	if peak := tracker.peak.Load(); peak > 3 {
+		t.Errorf("%d requests ran at once, want at most 3", peak)
+	}
+}
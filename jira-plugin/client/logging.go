@@ -0,0 +1,92 @@
+package client
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/bytedance/sonic"
+)
+
+// logLevelEnv selects the log level; "debug" also logs (redacted) request and response bodies
+const logLevelEnv = "SOREN_LOG_LEVEL"
+
+// maxLoggedStringLength is the length after which string values in logged bodies are truncated
+const maxLoggedStringLength = 64
+
+// maxLoggedRawLength caps how much of a non-JSON body is logged
+const maxLoggedRawLength = 200
+
+// redactedValue replaces secret values in logged bodies
+const redactedValue = "[REDACTED]"
+
+// sensitiveKeyParts mark JSON keys whose values are never logged
+var sensitiveKeyParts = []string{"token", "password", "secret", "authorization", "apikey", "credential"}
+
+// DebugEnabled reports whether SOREN_LOG_LEVEL is set to debug
+func DebugEnabled() bool {
+	return strings.EqualFold(strings.TrimSpace(os.Getenv(logLevelEnv)), "debug")
+}
+
+// Debugf logs only when SOREN_LOG_LEVEL=debug
+func Debugf(format string, args ...any) {
+	if DebugEnabled() {
+		log.Printf("[debug] "+format, args...)
+	}
+}
+
+// RedactJSON renders a request or response body for logging: values of secret keys
+// (tokens, passwords, ...) are masked and long strings such as summaries and
+// descriptions are truncated. Bodies that aren't JSON are truncated as a whole.
+func RedactJSON(data []byte) string {
+	var value interface{}
+	if err := sonic.Unmarshal(data, &value); err != nil {
+		return truncateForLog(string(data), maxLoggedRawLength)
+	}
+	redacted, err := sonic.Marshal(redactValue(value))
+	if err != nil {
+		return fmt.Sprintf("[unloggable body, %d bytes]", len(data))
+	}
+	return string(redacted)
+}
+
+// redactValue walks a decoded JSON value and redacts it in place
+func redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			if isSensitiveKey(key) {
+				v[key] = redactedValue
+			} else {
+				v[key] = redactValue(item)
+			}
+		}
+		return v
+	case []interface{}:
+		for i, item := range v {
+			v[i] = redactValue(item)
+		}
+		return v
+	case string:
+		return truncateForLog(v, maxLoggedStringLength)
+	}
+	return value
+}
+
+func isSensitiveKey(key string) bool {
+	lower := strings.ToLower(key)
+	for _, part := range sensitiveKeyParts {
+		if strings.Contains(lower, part) {
+			return true
+		}
+	}
+	return false
+}
+
+func truncateForLog(s string, limit int) string {
+	if len(s) <= limit {
+		return s
+	}
+	return fmt.Sprintf("%s...(%d chars)", s[:limit], len(s))
+}
@@ -0,0 +1,115 @@
+package client
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+	"time"
+	"unicode"
+)
+
+// RequestMetrics describes one HTTP attempt against the Jira API
+type RequestMetrics struct {
+	// Action is the plugin action that made the call (see WithAction); empty if unknown
+	Action string
+	// Method is the HTTP method
+	Method string
+	// Endpoint is the request path with IDs and keys replaced by placeholders,
+	// e.g. /rest/api/2/issue/{id}/transitions, so it can be used as a metric label
+	Endpoint string
+	// StatusCode is the HTTP status, or 0 if no response was received
+	StatusCode int
+	// Duration is how long the attempt took until the response headers arrived
+	Duration time.Duration
+	// Attempt is 0 for the first try and counts up for retries
+	Attempt int
+	// Err is the transport error, if the attempt failed without a response
+	Err error
+}
+
+// MetricsRecorder receives a RequestMetrics for every Jira API attempt. Implementations
+// must be safe for concurrent use and should return quickly, since they run inline.
+type MetricsRecorder interface {
+	RecordRequest(m RequestMetrics)
+}
+
+// MetricsRecorderFunc adapts a function to MetricsRecorder
+type MetricsRecorderFunc func(m RequestMetrics)
+
+// RecordRequest calls f(m)
+func (f MetricsRecorderFunc) RecordRequest(m RequestMetrics) {
+	f(m)
+}
+
+// defaultMetricsRecorder is used by clients without WithMetricsRecorder; nil means no-op
+var defaultMetricsRecorder atomic.Pointer[MetricsRecorder]
+
+// SetMetricsRecorder installs a recorder for every client that doesn't set its own.
+// Pass nil to disable instrumentation again.
+func SetMetricsRecorder(recorder MetricsRecorder) {
+	if recorder == nil {
+		defaultMetricsRecorder.Store(nil)
+		return
+	}
+	defaultMetricsRecorder.Store(&recorder)
+}
+
+// WithMetricsRecorder sets the recorder of a single client, overriding SetMetricsRecorder
+func WithMetricsRecorder(recorder MetricsRecorder) ClientOption {
+	return func(jc *JiraClient) {
+		jc.MetricsRecorder = recorder
+	}
+}
+
+// actionContextKey is the context key holding the name of the running action
+type actionContextKey struct{}
+
+// WithAction returns a context that attributes the Jira calls made with it to an action
+func WithAction(ctx context.Context, action string) context.Context {
+	return context.WithValue(ctx, actionContextKey{}, action)
+}
+
+// metricsRecorder returns the recorder for the client, or nil when instrumentation is off
+func (jc *JiraClient) metricsRecorder() MetricsRecorder {
+	if jc.MetricsRecorder != nil {
+		return jc.MetricsRecorder
+	}
+	if recorder := defaultMetricsRecorder.Load(); recorder != nil {
+		return *recorder
+	}
+	return nil
+}
+
+// recordRequest reports one attempt to the metrics recorder, if any
+func (jc *JiraClient) recordRequest(ctx context.Context, m RequestMetrics) {
+	recorder := jc.metricsRecorder()
+	if recorder == nil {
+		return
+	}
+	m.Action, _ = ctx.Value(actionContextKey{}).(string)
+	m.Endpoint = templateEndpoint(m.Endpoint)
+	recorder.RecordRequest(m)
+}
+
+// templateEndpoint strips the query string and replaces path segments that identify a
+// resource (numeric IDs, issue keys, project keys, account IDs) with placeholders, so
+// every issue shares one endpoint label. The /rest/api/{version} and /rest/agile/1.0
+// prefixes are kept as they are.
+func templateEndpoint(endpoint string) string {
+	path, _, _ := strings.Cut(endpoint, "?")
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if segment == "" || (i == 3 && segments[1] == "rest") {
+			continue
+		}
+		switch {
+		case strings.ContainsFunc(segment, unicode.IsDigit):
+			segments[i] = "{id}"
+		case strings.Contains(segment, ":") || strings.Contains(segment, "%"):
+			segments[i] = "{id}"
+		case strings.IndexFunc(segment, unicode.IsLower) == -1:
+			segments[i] = "{key}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
@@ -0,0 +1,220 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bytedance/sonic"
+
+	"github.com/sorenhq/jira-plugin/credentials"
+)
+
+// oauthClientIDEnv and oauthClientSecretEnv name the Atlassian OAuth 2.0 (3LO) app
+// whose refresh tokens the plugin exchanges for new access tokens
+const (
+	oauthClientIDEnv     = "SOREN_JIRA_OAUTH_CLIENT_ID"
+	oauthClientSecretEnv = "SOREN_JIRA_OAUTH_CLIENT_SECRET"
+)
+
+// AtlassianAPIURL is the gateway OAuth requests go through: Jira Cloud only accepts
+// OAuth access tokens at {AtlassianAPIURL}/ex/jira/{cloudId}, not at the site URL
+const AtlassianAPIURL = "https://api.atlassian.com"
+
+// atlassianTokenURL is Atlassian's OAuth 2.0 token endpoint
+const atlassianTokenURL = "https://auth.atlassian.com/oauth/token"
+
+// ErrOAuthNotConfigured is returned when an access token must be refreshed but no
+// OAuth app is configured through the environment
+var ErrOAuthNotConfigured = errors.New("OAuth app not configured: set " + oauthClientIDEnv + " and " + oauthClientSecretEnv)
+
+// oauthBaseURL returns the API gateway base URL of a Jira Cloud site
+func oauthBaseURL(cloudID string) string {
+	return AtlassianAPIURL + "/ex/jira/" + cloudID
+}
+
+// oauthHTTPClient returns the HTTP client for calls to Atlassian's OAuth endpoints,
+// using the same proxy and TLS settings as Jira requests
+func oauthHTTPClient() *http.Client {
+	return &http.Client{
+		Timeout:   DefaultTimeout,
+		Transport: transportFor(os.Getenv(proxyEnv), nil),
+	}
+}
+
+// RefreshOAuthToken exchanges the refresh token of OAuth credentials for a new access
+// token and updates AccessToken, TokenExpiresAt and (since Atlassian rotates them)
+// RefreshToken in place. It matches credentials.TokenRefresher.
+func RefreshOAuthToken(creds *credentials.JiraCredentials) error {
+	clientID := os.Getenv(oauthClientIDEnv)
+	clientSecret := os.Getenv(oauthClientSecretEnv)
+	if clientID == "" || clientSecret == "" {
+		return ErrOAuthNotConfigured
+	}
+	if creds.RefreshToken == "" {
+		return errors.New("no refresh token is stored; onboard the profile again with a new access token")
+	}
+
+	bodyBytes, err := sonic.Marshal(map[string]string{
+		"grant_type":    "refresh_token",
+		"client_id":     clientID,
+		"client_secret": clientSecret,
+		"refresh_token": creds.RefreshToken,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal token request: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, "POST", atlassianTokenURL, bytes.NewReader(bodyBytes))
+	if err != nil {
+		return fmt.Errorf("failed to create token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", UserAgent)
+
+	resp, err := oauthHTTPClient().Do(req)
+	if err != nil {
+		return fmt.Errorf("token request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	bodyBytes, err = readResponseBody(resp)
+	if err != nil {
+		return err
+	}
+
+	// Parse response: {"access_token": "...", "refresh_token": "...", "expires_in": 3600, ...}
+	// or, on failure, {"error": "invalid_grant", "error_description": "..."}
+	var tokenResponse struct {
+		AccessToken      string `json:"access_token"`
+		RefreshToken     string `json:"refresh_token"`
+		ExpiresIn        int    `json:"expires_in"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	if err := sonic.Unmarshal(bodyBytes, &tokenResponse); err != nil {
+		log.Printf("Failed to unmarshal token response (status %d): %v", resp.StatusCode, err)
+		return fmt.Errorf("failed to unmarshal token response (status %d): %w", resp.StatusCode, err)
+	}
+	if resp.StatusCode != http.StatusOK || tokenResponse.AccessToken == "" {
+		// A revoked or expired refresh token can't be fixed by retrying
+		if tokenResponse.Error == "invalid_grant" || resp.StatusCode == http.StatusUnauthorized {
+			return fmt.Errorf("%s: %s: %w", tokenResponse.Error, tokenResponse.ErrorDescription, ErrUnauthorized)
+		}
+		return fmt.Errorf("token endpoint returned status %d: %s %s", resp.StatusCode, tokenResponse.Error, tokenResponse.ErrorDescription)
+	}
+
+	creds.AccessToken = tokenResponse.AccessToken
+	if tokenResponse.RefreshToken != "" {
+		creds.RefreshToken = tokenResponse.RefreshToken
+	}
+	creds.TokenExpiresAt = time.Time{}
+	if tokenResponse.ExpiresIn > 0 {
+		creds.TokenExpiresAt = time.Now().UTC().Add(time.Duration(tokenResponse.ExpiresIn) * time.Second)
+	}
+	return nil
+}
+
+// ResolveCloudID finds the cloud ID of the Jira site at siteURL among the sites an OAuth
+// access token was granted for
+func ResolveCloudID(ctx context.Context, accessToken, siteURL string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", AtlassianAPIURL+"/oauth/token/accessible-resources", nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", UserAgent)
+
+	resp, err := oauthHTTPClient().Do(req)
+	if err != nil {
+		return "", fmt.Errorf("accessible resources request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	bodyBytes, err := readResponseBody(resp)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", parseJiraError(resp.StatusCode, bodyBytes, "Errors")
+	}
+
+	// Parse response: [{"id": "<cloudId>", "url": "https://example.atlassian.net", "name": "example", ...}]
+	var resources []struct {
+		ID  string `json:"id"`
+		URL string `json:"url"`
+	}
+	if err := sonic.Unmarshal(bodyBytes, &resources); err != nil {
+		log.Printf("Failed to unmarshal accessible resources response: %v, body: %s", err, RedactJSON(bodyBytes))
+		return "", fmt.Errorf("failed to unmarshal accessible resources: %w", err)
+	}
+
+	sites := make([]string, 0, len(resources))
+	for _, resource := range resources {
+		if strings.EqualFold(strings.TrimRight(resource.URL, "/"), strings.TrimRight(siteURL, "/")) {
+			return resource.ID, nil
+		}
+		sites = append(sites, resource.URL)
+	}
+	return "", fmt.Errorf("the access token was not granted for %s (granted sites: %s): %w", siteURL, strings.Join(sites, ", "), ErrForbidden)
+}
+
+// oauthSession tracks the access token of an OAuth client, which may be refreshed while
+// the client is in use. It is shared by copies of the client, so a token refreshed
+// by one request is used by all of them.
+type oauthSession struct {
+	// mu makes concurrent requests of the client wait for a refresh in progress
+	// instead of starting their own
+	mu    sync.Mutex
+	creds *credentials.JiraCredentials
+}
+
+// accessToken returns the current access token
+func (s *oauthSession) accessToken() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.creds.AccessToken
+}
+
+// refreshIfExpired refreshes the access token if it has expired or is about to
+func (s *oauthSession) refreshIfExpired() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.creds.TokenExpired() {
+		return nil
+	}
+	return s.refreshLocked()
+}
+
+// refreshAfterRejection refreshes the access token after Jira rejected staleToken. If
+// the token was already replaced since, nothing is refreshed and the request can simply
+// be retried with the current one.
+func (s *oauthSession) refreshAfterRejection(staleToken string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.creds.AccessToken != staleToken {
+		return nil
+	}
+	return s.refreshLocked()
+}
+
+// refreshLocked refreshes the access token through the credentials storage, which saves
+// the new tokens and keeps other clients of the same profile from refreshing again.
+// s.mu must be held.
+func (s *oauthSession) refreshLocked() error {
+	refreshed, err := credentials.GetCredentialsStorage().RefreshAccessToken(s.creds)
+	if err != nil {
+		return err
+	}
+	s.creds = refreshed
+	return nil
+}
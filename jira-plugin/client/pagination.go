@@ -0,0 +1,98 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/bytedance/sonic"
+)
+
+// jiraPage is one page of a Jira list endpoint using the startAt/maxResults pattern:
+// {"startAt": 0, "maxResults": 50, "total": 120, "isLast": false, "values": [...]}
+type jiraPage[T any] struct {
+	Values []T  `json:"values"`
+	Total  int  `json:"total"`
+	IsLast bool `json:"isLast"`
+}
+
+// pageQuery describes a paginated list endpoint for fetchAllPages
+type pageQuery[T any] struct {
+	// name is what is listed (e.g. "sprints"), used in logs and errors
+	name string
+	// endpoint builds the URL of the page starting at startAt
+	endpoint func(startAt, maxResults int) string
+	// pageSize is the maxResults requested per page
+	pageSize int
+	// limit stops the pagination once this many items were collected; 0 means no limit
+	limit int
+	// keep optionally filters the items; only kept items count towards limit
+	keep func(T) bool
+	// notFound, if set, is returned when Jira answers 404
+	notFound error
+	// parse optionally replaces the default decoding of a page
+	parse func(bodyBytes []byte) (*jiraPage[T], error)
+}
+
+// fetchAllPages follows a paginated endpoint until Jira reports the last page, returns
+// an empty page, or query.limit items were collected. truncated reports whether the
+// limit cut the list short. Retries and backoff are left to makeRequest, so every
+// page is retried the same way as any other request.
+func fetchAllPages[T any](ctx context.Context, jc *JiraClient, query pageQuery[T]) (items []T, truncated bool, err error) {
+	items = []T{}
+	startAt := 0
+	for {
+		resp, err := jc.makeRequest(ctx, "GET", query.endpoint(startAt, query.pageSize), nil)
+		if err != nil {
+			return nil, false, err
+		}
+		bodyBytes, err := readResponseBody(resp)
+		resp.Body.Close()
+		if err != nil {
+			return nil, false, err
+		}
+
+		if resp.StatusCode == http.StatusNotFound && query.notFound != nil {
+			return nil, false, query.notFound
+		}
+		if resp.StatusCode != http.StatusOK {
+			return nil, false, parseJiraError(resp.StatusCode, bodyBytes, "Errors")
+		}
+
+		var page *jiraPage[T]
+		if query.parse != nil {
+			page, err = query.parse(bodyBytes)
+		} else {
+			page = &jiraPage[T]{}
+			if err = sonic.Unmarshal(bodyBytes, page); err != nil {
+				err = fmt.Errorf("failed to unmarshal %s: %w", query.name, err)
+			}
+		}
+		if err != nil {
+			log.Printf("Failed to parse %s response: %v, body: %s", query.name, err, RedactJSON(bodyBytes))
+			return nil, false, err
+		}
+
+		for i, item := range page.Values {
+			if query.keep != nil && !query.keep(item) {
+				continue
+			}
+			items = append(items, item)
+			if query.limit > 0 && len(items) >= query.limit {
+				// Only a cut if more items follow on this or a later page
+				more := !page.IsLast || i < len(page.Values)-1
+				if more {
+					log.Printf("Reached limit of %d %s, stopping pagination (total: %d)", query.limit, query.name, page.Total)
+				}
+				return items, more, nil
+			}
+		}
+
+		// Stop when Jira reports the last page or returns nothing more
+		startAt += len(page.Values)
+		if page.IsLast || len(page.Values) == 0 || (page.Total > 0 && startAt >= page.Total) {
+			return items, false, nil
+		}
+	}
+}
@@ -0,0 +1,182 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+// pageItem is the item type listed by the paginated test endpoint
+type pageItem struct {
+	ID int `json:"id"`
+}
+
+// newPagedClient serves items 1..count from /items in pages of maxResults, reporting
+// isLast only if withIsLast is set, and counts the pages requested
+func newPagedClient(t *testing.T, count int, withIsLast bool) (*JiraClient, *atomic.Int32) {
+	t.Helper()
+	var requests atomic.Int32
+	jc := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		startAt, _ := strconv.Atoi(r.URL.Query().Get("startAt"))
+		maxResults, _ := strconv.Atoi(r.URL.Query().Get("maxResults"))
+		var values []string
+		for id := startAt + 1; id <= min(startAt+maxResults, count); id++ {
+			values = append(values, fmt.Sprintf(`{"id":%d}`, id))
+		}
+		isLast := ""
+		if withIsLast {
+			isLast = fmt.Sprintf(`"isLast":%t,`, startAt+maxResults >= count)
+		}
+		respondJSON(w, http.StatusOK, fmt.Sprintf(`{"startAt":%d,"maxResults":%d,"total":%d,%s"values":[%s]}`,
+			startAt, maxResults, count, isLast, strings.Join(values, ",")))
+	})
+	return jc, &requests
+}
+
+// itemsQuery lists the test endpoint in pages of two
+func itemsQuery() pageQuery[pageItem] {
+	return pageQuery[pageItem]{
+		name: "items",
+		endpoint: func(startAt, maxResults int) string {
+			return fmt.Sprintf("/items?startAt=%d&maxResults=%d", startAt, maxResults)
+		},
+		pageSize: 2,
+	}
+}
+
+// itemIDs renders the IDs of items for comparison
+func itemIDs(items []pageItem) string {
+	ids := make([]string, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, strconv.Itoa(item.ID))
+	}
+	return strings.Join(ids, ",")
+}
+
+func TestFetchAllPages(t *testing.T) {
+	tests := []struct {
+		name          string
+		count         int
+		withIsLast    bool
+		limit         int
+		keep          func(pageItem) bool
+		wantIDs       string
+		wantTruncated bool
+		wantRequests  int32
+	}{
+		{name: "all pages until isLast", count: 5, withIsLast: true, wantIDs: "1,2,3,4,5", wantRequests: 3},
+		{name: "all pages until total", count: 4, wantIDs: "1,2,3,4", wantRequests: 2},
+		{name: "no items", count: 0, withIsLast: true, wantIDs: "", wantRequests: 1},
+		{name: "limit cuts the list", count: 5, withIsLast: true, limit: 3, wantIDs: "1,2,3", wantTruncated: true, wantRequests: 2},
+		{name: "limit at the page end", count: 5, withIsLast: true, limit: 4, wantIDs: "1,2,3,4", wantTruncated: true, wantRequests: 2},
+		{name: "limit equal to the total", count: 4, withIsLast: true, limit: 4, wantIDs: "1,2,3,4", wantRequests: 2},
+		{
+			name:         "keep filters items",
+			count:        5,
+			withIsLast:   true,
+			keep:         func(item pageItem) bool { return item.ID%2 == 1 },
+			wantIDs:      "1,3,5",
+			wantRequests: 3,
+		},
+		{
+			name:          "only kept items count towards the limit",
+			count:         6,
+			withIsLast:    true,
+			limit:         2,
+			keep:          func(item pageItem) bool { return item.ID%2 == 0 },
+			wantIDs:       "2,4",
+			wantTruncated: true,
+			wantRequests:  2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jc, requests := newPagedClient(t, tt.count, tt.withIsLast)
+			query := itemsQuery()
+			query.limit = tt.limit
+			query.keep = tt.keep
+
+			items, truncated, err := fetchAllPages(context.Background(), jc, query)
+			if err != nil {
+				t.Fatalf("fetchAllPages: %v", err)
+			}
+			if got := itemIDs(items); got != tt.wantIDs {
+				t.Errorf("items = %s, want %s", got, tt.wantIDs)
+			}
+			if truncated != tt.wantTruncated {
+				t.Errorf("truncated = %v, want %v", truncated, tt.wantTruncated)
+			}
+			if requests.Load() != tt.wantRequests {
+				t.Errorf("requests = %d, want %d", requests.Load(), tt.wantRequests)
+			}
+		})
+	}
+}
+
+func TestFetchAllPagesErrors(t *testing.T) {
+	errNoBoard := errors.New("board not found")
+
+	t.Run("notFound", func(t *testing.T) {
+		jc := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			respondJSON(w, http.StatusNotFound, `{"errorMessages":["Board does not exist"]}`)
+		})
+		query := itemsQuery()
+		query.notFound = errNoBoard
+		if _, _, err := fetchAllPages(context.Background(), jc, query); !errors.Is(err, errNoBoard) {
+			t.Errorf("error = %v, want the notFound error", err)
+		}
+	})
+
+	t.Run("error on a later page", func(t *testing.T) {
+		jc := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("startAt") == "0" {
+				respondJSON(w, http.StatusOK, `{"total":4,"values":[{"id":1},{"id":2}]}`)
+				return
+			}
+			respondJSON(w, http.StatusForbidden, `{"errorMessages":["You do not have permission."]}`)
+		})
+		items, _, err := fetchAllPages(context.Background(), jc, itemsQuery())
+		checkAPIError(t, err, http.StatusForbidden, ErrForbidden, []string{"You do not have permission."})
+		if items != nil {
+			t.Errorf("items = %v, want none on error", items)
+		}
+	})
+
+	t.Run("invalid JSON", func(t *testing.T) {
+		jc := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			respondJSON(w, http.StatusOK, `<html>`)
+		})
+		_, _, err := fetchAllPages(context.Background(), jc, itemsQuery())
+		if err == nil || !strings.Contains(err.Error(), "failed to unmarshal items") {
+			t.Errorf("error = %v, want an unmarshal error naming the items", err)
+		}
+	})
+}
+
+func TestFetchAllPagesCustomParse(t *testing.T) {
+	jc := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		// A legacy endpoint returning a bare array
+		respondJSON(w, http.StatusOK, `[{"id":7},{"id":8},{"id":9}]`)
+	})
+	query := itemsQuery()
+	query.parse = func(bodyBytes []byte) (*jiraPage[pageItem], error) {
+		page := &jiraPage[pageItem]{IsLast: true}
+		err := json.Unmarshal(bodyBytes, &page.Values)
+		return page, err
+	}
+
+	items, truncated, err := fetchAllPages(context.Background(), jc, query)
+	if err != nil {
+		t.Fatalf("fetchAllPages: %v", err)
+	}
+	if itemIDs(items) != "7,8,9" || truncated {
+		t.Errorf("items = %s (truncated %v), want 7,8,9", itemIDs(items), truncated)
+	}
+}
@@ -0,0 +1,160 @@
+package client
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// proxyEnv forces all Jira requests through a proxy, overriding HTTP_PROXY/HTTPS_PROXY
+const proxyEnv = "SOREN_JIRA_PROXY"
+
+// caBundleEnv points at a PEM file of extra CA certificates to trust (e.g. an internal CA)
+const caBundleEnv = "SOREN_JIRA_CA_BUNDLE"
+
+// insecureSkipVerifyEnv disables TLS certificate verification. For development only.
+const insecureSkipVerifyEnv = "SOREN_JIRA_INSECURE_SKIP_VERIFY"
+
+// transportKey identifies a pooled transport by its proxy and TLS settings
+type transportKey struct {
+	proxyURL  string
+	tlsConfig *tls.Config
+}
+
+var (
+	// baseTransportOnce builds the default transport on first use, after env.plugin is loaded
+	baseTransportOnce sync.Once
+	baseTransport     *http.Transport
+
+	// transports holds one pooled transport per proxy/TLS combination. Clients are
+	// created per action, so sharing transports keeps idle connections (and their TLS
+	// sessions) alive across actions instead of re-establishing them on every call.
+	transportsMu sync.Mutex
+	transports   = map[transportKey]*http.Transport{}
+)
+
+// defaultTransport returns the tuned transport used by clients without proxy or TLS overrides.
+// Proxies are taken from HTTP_PROXY, HTTPS_PROXY and NO_PROXY, and TLS settings from
+// SOREN_JIRA_CA_BUNDLE and SOREN_JIRA_INSECURE_SKIP_VERIFY.
+func defaultTransport() *http.Transport {
+	baseTransportOnce.Do(func() {
+		baseTransport = &http.Transport{
+			Proxy: http.ProxyFromEnvironment,
+			DialContext: (&net.Dialer{
+				Timeout:   10 * time.Second,
+				KeepAlive: 30 * time.Second,
+			}).DialContext,
+			ForceAttemptHTTP2:     true,
+			MaxIdleConns:          100,
+			MaxIdleConnsPerHost:   20,
+			IdleConnTimeout:       90 * time.Second,
+			TLSHandshakeTimeout:   10 * time.Second,
+			ExpectContinueTimeout: 1 * time.Second,
+			TLSClientConfig:       tlsConfigFromEnv(),
+		}
+	})
+	return baseTransport
+}
+
+// tlsConfigFromEnv builds the TLS settings configured through the environment,
+// or returns nil to use Go's defaults
+func tlsConfigFromEnv() *tls.Config {
+	caBundle := os.Getenv(caBundleEnv)
+	insecure, _ := strconv.ParseBool(os.Getenv(insecureSkipVerifyEnv))
+	if caBundle == "" && !insecure {
+		return nil
+	}
+
+	config := &tls.Config{MinVersion: tls.VersionTLS12}
+	if caBundle != "" {
+		pem, err := os.ReadFile(caBundle)
+		if err != nil {
+			log.Printf("Error: failed to read %s %q, using system CAs only: %v", caBundleEnv, caBundle, err)
+		} else {
+			// Trust the bundle in addition to the system roots
+			pool, err := x509.SystemCertPool()
+			if err != nil || pool == nil {
+				pool = x509.NewCertPool()
+			}
+			if pool.AppendCertsFromPEM(pem) {
+				config.RootCAs = pool
+				log.Printf("Loaded CA bundle for Jira from %s", caBundle)
+			} else {
+				log.Printf("Error: %s %q contains no PEM certificates, using system CAs only", caBundleEnv, caBundle)
+			}
+		}
+	}
+	if insecure {
+		log.Printf("WARNING: %s is enabled - TLS certificates of Jira instances are NOT verified. Never use this in production!", insecureSkipVerifyEnv)
+		config.InsecureSkipVerify = true
+	}
+	return config
+}
+
+// transportFor returns the pooled transport for the given proxy URL and TLS config.
+// Both are optional; without either the default transport is returned.
+func transportFor(proxyURL string, tlsConfig *tls.Config) *http.Transport {
+	base := defaultTransport()
+	if proxyURL == "" && tlsConfig == nil {
+		return base
+	}
+
+	transportsMu.Lock()
+	defer transportsMu.Unlock()
+
+	key := transportKey{proxyURL: proxyURL, tlsConfig: tlsConfig}
+	if transport, ok := transports[key]; ok {
+		return transport
+	}
+	transport := base.Clone()
+	if proxyURL != "" {
+		// WithProxy has already validated the URL
+		if parsed, err := url.Parse(proxyURL); err == nil {
+			transport.Proxy = http.ProxyURL(parsed)
+		}
+	}
+	if tlsConfig != nil {
+		transport.TLSClientConfig = tlsConfig
+	}
+	transports[key] = transport
+	return transport
+}
+
+// WithProxy sends the client's requests through an explicit HTTP(S) or SOCKS5 proxy
+// (e.g. http://proxy.internal:3128 or socks5://127.0.0.1:1080). Without it, the
+// HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables are honored.
+// An empty or invalid URL leaves the client's proxy settings unchanged.
+func WithProxy(proxyURL string) ClientOption {
+	return func(jc *JiraClient) {
+		if proxyURL == "" {
+			return
+		}
+		parsed, err := url.Parse(proxyURL)
+		if err != nil || parsed.Host == "" {
+			log.Printf("Warning: ignoring invalid proxy URL %q", proxyURL)
+			return
+		}
+		switch parsed.Scheme {
+		case "http", "https", "socks5", "socks5h":
+		default:
+			log.Printf("Warning: ignoring proxy URL %q with unsupported scheme %q", proxyURL, parsed.Scheme)
+			return
+		}
+		jc.ProxyURL = proxyURL
+	}
+}
+
+// WithTLSConfig overrides the TLS settings (e.g. custom RootCAs or client certificates)
+// of the client's transport. Clients passing the same *tls.Config share a connection pool.
+func WithTLSConfig(config *tls.Config) ClientOption {
+	return func(jc *JiraClient) {
+		jc.TLSConfig = config
+	}
+}
@@ -0,0 +1,100 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// proxyFor returns the proxy URL the client's transport picks for a request to target
+func proxyFor(t *testing.T, jc *JiraClient, target string) string {
+	t.Helper()
+	transport, ok := jc.HTTPClient.Transport.(*http.Transport)
+	if !ok {
+		t.Fatalf("transport is %T, want *http.Transport", jc.HTTPClient.Transport)
+	}
+	req, err := http.NewRequest(http.MethodGet, target, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	proxy, err := transport.Proxy(req)
+	if err != nil {
+		t.Fatalf("Proxy: %v", err)
+	}
+	if proxy == nil {
+		return ""
+	}
+	return proxy.String()
+}
+
+func TestWithProxy(t *testing.T) {
+	tests := []struct {
+		name      string
+		proxyURL  string
+		wantProxy string
+	}{
+		{name: "http proxy", proxyURL: "http://proxy.internal:3128", wantProxy: "http://proxy.internal:3128"},
+		{name: "socks5 proxy", proxyURL: "socks5://127.0.0.1:1080", wantProxy: "socks5://127.0.0.1:1080"},
+		{name: "unsupported scheme is ignored", proxyURL: "ftp://proxy.internal:21"},
+		{name: "URL without host is ignored", proxyURL: "proxy.internal:3128"},
+		{name: "empty URL is ignored"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(proxyEnv, "")
+			t.Setenv("HTTPS_PROXY", "")
+			jc := NewJiraClient(testCredentials("https://example.atlassian.net"), WithProxy(tt.proxyURL))
+			if jc.ProxyURL != tt.wantProxy {
+				t.Errorf("ProxyURL = %q, want %q", jc.ProxyURL, tt.wantProxy)
+			}
+			if tt.wantProxy == "" {
+				return
+			}
+			if got := proxyFor(t, jc, "https://example.atlassian.net/rest/api/2/myself"); got != tt.wantProxy {
+				t.Errorf("transport proxy = %q, want %q", got, tt.wantProxy)
+			}
+		})
+	}
+}
+
+func TestProxyFromEnv(t *testing.T) {
+	t.Setenv(proxyEnv, "http://env-proxy.internal:3128")
+
+	jc := NewJiraClient(testCredentials("https://example.atlassian.net"))
+	if got := proxyFor(t, jc, "https://example.atlassian.net/rest/api/2/myself"); got != "http://env-proxy.internal:3128" {
+		t.Errorf("transport proxy = %q, want the SOREN_JIRA_PROXY value", got)
+	}
+
+	// An explicit option overrides the environment
+	jc = NewJiraClient(testCredentials("https://example.atlassian.net"), WithProxy("http://option-proxy.internal:8080"))
+	if got := proxyFor(t, jc, "https://example.atlassian.net/rest/api/2/myself"); got != "http://option-proxy.internal:8080" {
+		t.Errorf("transport proxy = %q, want the WithProxy value", got)
+	}
+}
+
+func TestProxyTransportIsShared(t *testing.T) {
+	t.Setenv(proxyEnv, "")
+	first := NewJiraClient(testCredentials("https://one.atlassian.net"), WithProxy("http://shared-proxy.internal:3128"))
+	second := NewJiraClient(testCredentials("https://two.atlassian.net"), WithProxy("http://shared-proxy.internal:3128"))
+	if first.HTTPClient.Transport != second.HTTPClient.Transport {
+		t.Error("clients using the same proxy don't share a transport")
+	}
+}
+
+func TestRequestsGoThroughProxy(t *testing.T) {
+	t.Setenv(proxyEnv, "")
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// A forward proxy receives the absolute URL of the Jira request
+		if r.URL.Host != "jira.example.test" || r.URL.Path != "/rest/api/2/myself" {
+			t.Errorf("proxied request = %s, want http://jira.example.test/rest/api/2/myself", r.URL)
+		}
+		respondJSON(w, http.StatusOK, `{"accountId":"abc","displayName":"Ada"}`)
+	}))
+	t.Cleanup(proxy.Close)
+
+	jc := NewJiraClient(testCredentials("http://jira.example.test"), WithProxy(proxy.URL), WithCacheTTL(0))
+	if _, err := jc.TestConnection(context.Background()); err != nil {
+		t.Fatalf("TestConnection through proxy: %v", err)
+	}
+}
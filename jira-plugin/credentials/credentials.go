@@ -2,25 +2,139 @@ package credentials
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"log"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
 )
 
 const credentialsFileName = "jira_credentials.json"
 
+// DefaultProfile is the credentials profile used when an action doesn't name one
+const DefaultProfile = "default"
+
+const (
+	// AuthTypeAPIToken authenticates with an API token or personal access token (the default)
+	AuthTypeAPIToken = "api_token"
+	// AuthTypeOAuth authenticates with an OAuth 2.0 (3LO) access token for Jira Cloud
+	AuthTypeOAuth = "oauth"
+)
+
+// tokenRefreshMargin refreshes OAuth access tokens this long before they expire, so a
+// token doesn't run out in the middle of an action
+const tokenRefreshMargin = time.Minute
+
+// credentialsFileVersion is the current on-disk format of the credentials file.
+// Version 1 (unversioned) mapped each space directly to one set of credentials.
+const credentialsFileVersion = 2
+
+// credentialsFile is the versioned on-disk layout: space -> profile -> credentials
+type credentialsFile struct {
+	Version int                                   `json:"version"`
+	Spaces  map[string]map[string]JiraCredentials `json:"spaces"`
+}
+
 // JiraCredentials represents the stored Jira credentials
 type JiraCredentials struct {
 	InstanceURL string `json:"instanceUrl"`
 	Email       string `json:"email"`
 	APIToken    string `json:"apiToken"`
+	// APIVersion is the Jira REST API version ("2" or "3"); empty means "2"
+	APIVersion string `json:"apiVersion,omitempty"`
+	// AuthMode is "basic" or "bearer" as detected at onboarding; empty means it is
+	// derived from the instance URL
+	AuthMode string `json:"authMode,omitempty"`
+	// DeploymentType is the deployment detected at onboarding ("Cloud", "Server" or "DataCenter")
+	DeploymentType string `json:"deploymentType,omitempty"`
+	// DefaultProjectKey is used by issues.create when the request names no project
+	DefaultProjectKey string `json:"defaultProjectKey,omitempty"`
+	// TimeoutSeconds overrides the HTTP request timeout for this instance; 0 means the default (30s)
+	TimeoutSeconds int `json:"timeoutSeconds,omitempty"`
+	// CreatedAt is when the profile was first saved; UpdatedAt when it was last saved.
+	// Both are set by SaveCredentialsProfile and are zero in files written before they existed.
+	CreatedAt time.Time `json:"createdAt,omitzero"`
+	UpdatedAt time.Time `json:"updatedAt,omitzero"`
+	// LastValidatedAt is when the credentials last passed a connection test
+	LastValidatedAt time.Time `json:"lastValidatedAt,omitzero"`
+
+	// AuthType is AuthTypeAPIToken or AuthTypeOAuth; empty means AuthTypeAPIToken
+	AuthType string `json:"authType,omitempty"`
+	// AccessToken, RefreshToken and TokenExpiresAt hold the OAuth tokens. The access
+	// token is replaced using the refresh token shortly before it expires (see
+	// SetTokenRefresher); a zero TokenExpiresAt means the expiry is unknown.
+	AccessToken    string    `json:"accessToken,omitempty"`
+	RefreshToken   string    `json:"refreshToken,omitempty"`
+	TokenExpiresAt time.Time `json:"tokenExpiresAt,omitzero"`
+	// CloudID identifies the Jira Cloud site in OAuth API URLs
+	CloudID string `json:"cloudId,omitempty"`
+
+	// spaceID and profile record where loaded credentials are stored (see MarkValidated)
+	spaceID string
+	profile string
+}
+
+// IsOAuth reports whether the credentials use OAuth access tokens
+func (c *JiraCredentials) IsOAuth() bool {
+	return c.AuthType == AuthTypeOAuth
+}
+
+// StorageKey identifies where credentials loaded from storage are kept, as
+// "{space}/{profile}"; it is empty for credentials that weren't loaded from storage
+func (c *JiraCredentials) StorageKey() string {
+	if c.spaceID == "" {
+		return ""
+	}
+	return c.spaceID + "/" + c.profile
+}
+
+// TokenExpired reports whether the OAuth access token has expired or expires within
+// tokenRefreshMargin. Tokens without a known expiry never count as expired.
+func (c *JiraCredentials) TokenExpired() bool {
+	return c.IsOAuth() && !c.TokenExpiresAt.IsZero() && time.Until(c.TokenExpiresAt) < tokenRefreshMargin
 }
 
 // CredentialsStorage handles storing and retrieving credentials
 type CredentialsStorage struct {
 	filePath string
+	// fileMu guards the credentials file: every read and every read-modify-write of it
+	// holds the lock, so concurrent saves can't write back a stale copy of each other
+	fileMu sync.Mutex
+	// validator checks patched credentials in UpdateCredentialField (see SetValidator)
+	validator Validator
+	// tokenRefresher renews expired OAuth access tokens (see SetTokenRefresher)
+	tokenRefresher TokenRefresher
+	// refreshMu serializes token refreshes: Atlassian rotates refresh tokens, so two
+	// concurrent refreshes with the same token would make the second one fail. It is
+	// held while Atlassian is called; fileMu is only taken to read and save the tokens.
+	refreshMu sync.Mutex
 }
 
+// TokenRefresher exchanges the refresh token of OAuth credentials for a new access
+// token, updating AccessToken, RefreshToken and TokenExpiresAt in place. Like
+// Validator, it lives outside this package because it calls Atlassian.
+type TokenRefresher func(creds *JiraCredentials) error
+
+// Validator checks that credentials work, typically by connecting to Jira. It may
+// adjust derived fields (such as the auth mode) before the credentials are saved.
+// It lives outside this package because the Jira client depends on credentials.
+type Validator func(creds *JiraCredentials) error
+
+// ErrInvalidUpdate is returned by UpdateCredentialField for updates that are rejected
+// before the credentials are tested (unknown or empty fields, missing profile)
+var ErrInvalidUpdate = errors.New("invalid credentials update")
+
+// ErrValidationFailed is returned by UpdateCredentialField when the patched credentials
+// don't work; nothing is saved in that case
+var ErrValidationFailed = errors.New("updated credentials failed validation")
+
+// UpdatableFields are the credential fields UpdateCredentialField accepts
+var UpdatableFields = []string{"instanceUrl", "email", "apiToken"}
+
 var globalCredentialsStorage *CredentialsStorage
 
 // GetCredentialsStorage returns the global credentials storage instance
@@ -43,87 +157,521 @@ func NewCredentialsStorage() *CredentialsStorage {
 	}
 }
 
-// SaveCredentials saves credentials to file using spaceID as the key
+// SetValidator sets the check UpdateCredentialField runs before saving patched credentials
+func (cs *CredentialsStorage) SetValidator(validator Validator) {
+	cs.validator = validator
+}
+
+// SetTokenRefresher sets how GetCredentialsProfile renews expired OAuth access tokens
+func (cs *CredentialsStorage) SetTokenRefresher(refresher TokenRefresher) {
+	cs.tokenRefresher = refresher
+}
+
+// UpdateCredentialField patches some fields (see UpdatableFields) of the default
+// profile of a space, e.g. to rotate the API token, keeping every other field
+func (cs *CredentialsStorage) UpdateCredentialField(spaceID string, updates map[string]string) error {
+	_, err := cs.UpdateCredentialFieldProfile(spaceID, DefaultProfile, updates)
+	return err
+}
+
+// UpdateCredentialFieldProfile patches some fields of a named profile of a space. The
+// patched credentials are checked with the validator and only saved if it passes,
+// so an update can never leave the profile unusable. It returns the saved credentials.
+func (cs *CredentialsStorage) UpdateCredentialFieldProfile(spaceID, profile string, updates map[string]string) (*JiraCredentials, error) {
+	if len(updates) == 0 {
+		return nil, fmt.Errorf("%w: no fields to update", ErrInvalidUpdate)
+	}
+	if cs.validator == nil {
+		return nil, fmt.Errorf("%w: no credentials validator configured", ErrInvalidUpdate)
+	}
+
+	creds, err := cs.GetCredentialsProfile(spaceID, profile)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidUpdate, err)
+	}
+
+	for field, value := range updates {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			return nil, fmt.Errorf("%w: %s cannot be empty", ErrInvalidUpdate, field)
+		}
+		switch field {
+		case "instanceUrl":
+			if value != creds.InstanceURL {
+				// A different site may be a different deployment; let the validator redetect it
+				creds.DeploymentType = ""
+				creds.AuthMode = ""
+			}
+			creds.InstanceURL = value
+		case "email":
+			creds.Email = value
+		case "apiToken":
+			creds.APIToken = value
+		default:
+			return nil, fmt.Errorf("%w: unknown field %q (allowed: %s)", ErrInvalidUpdate, field, strings.Join(UpdatableFields, ", "))
+		}
+	}
+
+	if err := cs.validator(creds); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrValidationFailed, err)
+	}
+	now := time.Now().UTC()
+	creds.LastValidatedAt = now
+
+	// The validator talks to Jira, so the stored profile may have changed meanwhile:
+	// apply the patch to the current copy and keep its OAuth tokens, which a concurrent
+	// refresh may have rotated
+	found, err := cs.updateProfile(creds.spaceID, creds.profile, func(stored *JiraCredentials) {
+		patched := *creds
+		patched.AccessToken = stored.AccessToken
+		patched.RefreshToken = stored.RefreshToken
+		patched.TokenExpiresAt = stored.TokenExpiresAt
+		patched.CreatedAt = stored.CreatedAt
+		patched.UpdatedAt = now
+		*stored = patched
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, fmt.Errorf("%w: credentials profile %q was removed during the update", ErrInvalidUpdate, creds.profile)
+	}
+	return cs.GetCredentialsProfile(spaceID, profile)
+}
+
+// SaveCredentials saves credentials to file as the default profile of the space
 func (cs *CredentialsStorage) SaveCredentials(spaceID string, creds JiraCredentials) error {
+	return cs.SaveCredentialsProfile(spaceID, DefaultProfile, creds)
+}
+
+// SaveCredentialsProfile saves credentials to file under a named profile of the space
+func (cs *CredentialsStorage) SaveCredentialsProfile(spaceID, profile string, creds JiraCredentials) error {
+	cs.fileMu.Lock()
+	defer cs.fileMu.Unlock()
+
 	// Read existing credentials if file exists
 	allCreds, err := cs.loadAllCredentials()
 	if err != nil && !os.IsNotExist(err) {
 		return fmt.Errorf("failed to load existing credentials: %w", err)
 	}
 
-	// Use spaceID as the key; if empty, use "default"
-	spaceKey := spaceID
-	if spaceKey == "" {
-		spaceKey = "default"
+	if allCreds == nil {
+		allCreds = make(map[string]map[string]JiraCredentials)
 	}
 
-	if allCreds == nil {
-		allCreds = make(map[string]JiraCredentials)
+	// Store credentials for this space and profile (neither is stored in the struct)
+	spaceKey := spaceKeyFor(spaceID)
+	profileKey := profileKeyFor(profile)
+	if allCreds[spaceKey] == nil {
+		allCreds[spaceKey] = make(map[string]JiraCredentials)
 	}
 
-	// Store credentials for this space (spaceID is the key, not stored in the struct)
-	allCreds[spaceKey] = creds
+	// Replacing a profile keeps its original creation time
+	now := time.Now().UTC()
+	creds.CreatedAt = now
+	if existing, exists := allCreds[spaceKey][profileKey]; exists && !existing.CreatedAt.IsZero() {
+		creds.CreatedAt = existing.CreatedAt
+	}
+	creds.UpdatedAt = now
+	allCreds[spaceKey][profileKey] = creds
 
 	// Write back to file
-	data, err := json.MarshalIndent(allCreds, "", "  ")
+	return cs.writeAllCredentials(allCreds)
+}
+
+// GetCredentials retrieves the default profile credentials for a specific space
+// If spaceID is empty, returns default credentials
+func (cs *CredentialsStorage) GetCredentials(spaceID string) (*JiraCredentials, error) {
+	return cs.GetCredentialsProfile(spaceID, DefaultProfile)
+}
+
+// GetCredentialsProfile retrieves the credentials of a named profile for a specific space.
+// An empty profile means DefaultProfile. An OAuth access token that has expired (or is
+// about to) is refreshed and the new tokens are saved before the credentials are returned.
+func (cs *CredentialsStorage) GetCredentialsProfile(spaceID, profile string) (*JiraCredentials, error) {
+	creds, err := cs.loadCredentialsProfile(spaceID, profile)
+	if err != nil || !creds.TokenExpired() || creds.RefreshToken == "" || cs.tokenRefresher == nil {
+		return creds, err
+	}
+	return cs.RefreshAccessToken(creds)
+}
+
+// RefreshAccessToken replaces the OAuth access token of creds using its refresh token
+// and returns the refreshed credentials; creds itself is left unchanged. Credentials
+// loaded from storage are saved with the new tokens.
+//
+// Refreshes are serialized, so concurrent callers holding the same stale token trigger
+// only one refresh: the others find the new token already stored and get it without
+// calling Atlassian again.
+func (cs *CredentialsStorage) RefreshAccessToken(creds *JiraCredentials) (*JiraCredentials, error) {
+	if cs.tokenRefresher == nil {
+		return nil, errors.New("no OAuth token refresher configured")
+	}
+
+	cs.refreshMu.Lock()
+	defer cs.refreshMu.Unlock()
+
+	refreshed := *creds
+	if creds.spaceID != "" {
+		stored, err := cs.loadCredentialsProfile(creds.spaceID, creds.profile)
+		if err != nil {
+			return nil, err
+		}
+		// Another request may have refreshed the token while this one waited
+		if stored.AccessToken != creds.AccessToken && !stored.TokenExpired() {
+			return stored, nil
+		}
+		refreshed = *stored
+	}
+
+	if err := cs.tokenRefresher(&refreshed); err != nil {
+		return nil, fmt.Errorf("failed to refresh the OAuth access token: %w", err)
+	}
+	if refreshed.spaceID == "" {
+		// Not stored yet (e.g. during onboarding): nothing to save
+		return &refreshed, nil
+	}
+	// Only the tokens are written back, so changes saved while Atlassian was being
+	// called (such as MarkValidated) are kept
+	found, err := cs.updateProfile(refreshed.spaceID, refreshed.profile, func(stored *JiraCredentials) {
+		stored.AccessToken = refreshed.AccessToken
+		stored.RefreshToken = refreshed.RefreshToken
+		stored.TokenExpiresAt = refreshed.TokenExpiresAt
+		stored.UpdatedAt = time.Now().UTC()
+	})
 	if err != nil {
-		return fmt.Errorf("failed to marshal credentials: %w", err)
+		return nil, fmt.Errorf("failed to save the refreshed OAuth access token: %w", err)
+	}
+	if !found {
+		return nil, fmt.Errorf("credentials profile %q of space %s was removed while its OAuth access token was refreshed", refreshed.profile, refreshed.spaceID)
 	}
+	log.Printf("Refreshed the OAuth access token for space %s (profile %s)", refreshed.spaceID, refreshed.profile)
+	return &refreshed, nil
+}
 
-	err = os.WriteFile(cs.filePath, data, 0600) // 0600 = read/write for owner only
+// loadCredentialsProfile reads the stored credentials of a profile without refreshing them
+func (cs *CredentialsStorage) loadCredentialsProfile(spaceID, profile string) (*JiraCredentials, error) {
+	cs.fileMu.Lock()
+	allCreds, err := cs.loadAllCredentials()
+	cs.fileMu.Unlock()
 	if err != nil {
-		return fmt.Errorf("failed to write credentials file: %w", err)
+		return nil, err
 	}
 
-	return nil
+	spaceKey := spaceKeyFor(spaceID)
+	profileKey := profileKeyFor(profile)
+	creds, exists := allCreds[spaceKey][profileKey]
+	if !exists {
+		if profileKey == DefaultProfile {
+			return nil, fmt.Errorf("credentials not found for space: %s", spaceKey)
+		}
+		return nil, fmt.Errorf("credentials profile %q not found for space: %s", profileKey, spaceKey)
+	}
+
+	creds.spaceID = spaceKey
+	creds.profile = profileKey
+	return &creds, nil
 }
 
-// GetCredentials retrieves credentials for a specific space
-// If spaceID is empty, returns default credentials
-func (cs *CredentialsStorage) GetCredentials(spaceID string) (*JiraCredentials, error) {
+// MarkValidated records that credentials loaded with GetCredentials or
+// GetCredentialsProfile passed a connection test at the given time. It is a no-op for
+// credentials that weren't loaded from storage or have since been removed.
+func (cs *CredentialsStorage) MarkValidated(creds *JiraCredentials, at time.Time) error {
+	if creds.spaceID == "" {
+		return nil
+	}
+	creds.LastValidatedAt = at
+
+	_, err := cs.updateProfile(creds.spaceID, creds.profile, func(stored *JiraCredentials) {
+		stored.LastValidatedAt = at
+	})
+	return err
+}
+
+// updateProfile applies update to the stored copy of a profile and saves it, holding
+// fileMu for the whole read-modify-write. It reports whether the profile exists; a
+// missing profile or credentials file is not an error.
+func (cs *CredentialsStorage) updateProfile(spaceKey, profileKey string, update func(stored *JiraCredentials)) (bool, error) {
+	cs.fileMu.Lock()
+	defer cs.fileMu.Unlock()
+
+	allCreds, err := cs.loadAllCredentials()
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to load existing credentials: %w", err)
+	}
+	stored, exists := allCreds[spaceKey][profileKey]
+	if !exists {
+		return false, nil
+	}
+	update(&stored)
+	allCreds[spaceKey][profileKey] = stored
+	return true, cs.writeAllCredentials(allCreds)
+}
+
+// ListProfiles returns the names of the credential profiles stored for a space
+func (cs *CredentialsStorage) ListProfiles(spaceID string) ([]string, error) {
+	cs.fileMu.Lock()
 	allCreds, err := cs.loadAllCredentials()
+	cs.fileMu.Unlock()
 	if err != nil {
+		if os.IsNotExist(err) {
+			return []string{}, nil
+		}
 		return nil, err
 	}
 
-	spaceKey := spaceID
-	if spaceKey == "" {
-		spaceKey = "default"
+	profiles := make([]string, 0, len(allCreds[spaceKeyFor(spaceID)]))
+	for profile := range allCreds[spaceKeyFor(spaceID)] {
+		profiles = append(profiles, profile)
+	}
+	sort.Strings(profiles)
+	return profiles, nil
+}
+
+// DeleteCredentials removes all credential profiles stored for a specific space.
+// It is a no-op if the space has no credentials. The file is removed once it holds no spaces.
+func (cs *CredentialsStorage) DeleteCredentials(spaceID string) error {
+	return cs.deleteCredentials(spaceID, "")
+}
+
+// DeleteCredentialsProfile removes a single named profile of a space.
+// It is a no-op if the profile does not exist.
+func (cs *CredentialsStorage) DeleteCredentialsProfile(spaceID, profile string) error {
+	return cs.deleteCredentials(spaceID, profileKeyFor(profile))
+}
+
+// deleteCredentials removes one profile of a space, or all of them if profile is empty
+func (cs *CredentialsStorage) deleteCredentials(spaceID, profile string) error {
+	cs.fileMu.Lock()
+	defer cs.fileMu.Unlock()
+
+	allCreds, err := cs.loadAllCredentials()
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to load existing credentials: %w", err)
 	}
 
-	creds, exists := allCreds[spaceKey]
+	spaceKey := spaceKeyFor(spaceID)
+	profiles, exists := allCreds[spaceKey]
 	if !exists {
-		return nil, fmt.Errorf("credentials not found for space: %s", spaceKey)
+		return nil
+	}
+	if profile == "" {
+		delete(allCreds, spaceKey)
+	} else {
+		if _, exists := profiles[profile]; !exists {
+			return nil
+		}
+		delete(profiles, profile)
+		if len(profiles) == 0 {
+			delete(allCreds, spaceKey)
+		}
 	}
 
-	return &creds, nil
+	if len(allCreds) == 0 {
+		err = os.Remove(cs.filePath)
+		if err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove credentials file: %w", err)
+		}
+		return nil
+	}
+
+	return cs.writeAllCredentials(allCreds)
 }
 
-// HasCredentials checks if credentials exist for a specific space
+// HasCredentials checks if default profile credentials exist for a specific space
 func (cs *CredentialsStorage) HasCredentials(spaceID string) bool {
-	creds, err := cs.GetCredentials(spaceID)
+	return cs.HasCredentialsProfile(spaceID, DefaultProfile)
+}
+
+// HasCredentialsProfile checks if credentials exist for a named profile of a space
+func (cs *CredentialsStorage) HasCredentialsProfile(spaceID, profile string) bool {
+	creds, err := cs.loadCredentialsProfile(spaceID, profile)
 	return err == nil && creds != nil
 }
 
-// loadAllCredentials loads all credentials from file
-func (cs *CredentialsStorage) loadAllCredentials() (map[string]JiraCredentials, error) {
+// loadAllCredentials loads all credentials from file, keyed by space and then profile.
+// Files in the legacy unversioned format are migrated: they are upgraded to the
+// current format and written back in place. Callers must hold fileMu.
+func (cs *CredentialsStorage) loadAllCredentials() (map[string]map[string]JiraCredentials, error) {
 	data, err := os.ReadFile(cs.filePath)
 	if err != nil {
 		return nil, err
 	}
 
-	var allCreds map[string]JiraCredentials
-	err = json.Unmarshal(data, &allCreds)
+	var rawFile map[string]json.RawMessage
+	err = json.Unmarshal(data, &rawFile)
 	if err != nil {
 		return nil, fmt.Errorf("failed to unmarshal credentials: %w", err)
 	}
 
+	var allCreds map[string]map[string]JiraCredentials
+	if isVersionedCredentialsFile(rawFile) {
+		var file credentialsFile
+		err = json.Unmarshal(data, &file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal credentials: %w", err)
+		}
+		if file.Version > credentialsFileVersion {
+			return nil, fmt.Errorf("credentials file version %d is newer than supported version %d", file.Version, credentialsFileVersion)
+		}
+		allCreds = file.Spaces
+		if allCreds == nil {
+			allCreds = make(map[string]map[string]JiraCredentials)
+		}
+	} else {
+		allCreds, err = migrateLegacyCredentials(rawFile)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Decrypt API and OAuth tokens (plaintext tokens from older files are kept as-is)
+	for spaceKey, profiles := range allCreds {
+		for profile, creds := range profiles {
+			for _, secret := range creds.secrets() {
+				*secret, err = decryptSecret(*secret)
+				if err != nil {
+					return nil, fmt.Errorf("failed to decrypt credentials for space %s (profile %s): %w", spaceKey, profile, err)
+				}
+			}
+			profiles[profile] = creds
+		}
+	}
+
+	if !isVersionedCredentialsFile(rawFile) {
+		// Upgrade the file in place; the credentials are still usable if this fails
+		if err := cs.writeAllCredentials(allCreds); err != nil {
+			log.Printf("Failed to migrate credentials file to version %d: %v", credentialsFileVersion, err)
+		} else {
+			log.Printf("Migrated credentials file to version %d (%d spaces)", credentialsFileVersion, len(allCreds))
+		}
+	}
+
+	return allCreds, nil
+}
+
+// isVersionedCredentialsFile reports whether the decoded file uses the versioned
+// wrapper. Legacy files are keyed by space ID and have no "version" entry.
+func isVersionedCredentialsFile(rawFile map[string]json.RawMessage) bool {
+	var version int
+	raw, ok := rawFile["version"]
+	if !ok || json.Unmarshal(raw, &version) != nil {
+		return false
+	}
+	_, hasSpaces := rawFile["spaces"]
+	return hasSpaces
+}
+
+// migrateLegacyCredentials converts a legacy unversioned file (space -> credentials)
+// into the current space -> profile -> credentials layout
+func migrateLegacyCredentials(rawSpaces map[string]json.RawMessage) (map[string]map[string]JiraCredentials, error) {
+	allCreds := make(map[string]map[string]JiraCredentials, len(rawSpaces))
+	for spaceKey, raw := range rawSpaces {
+		profiles, err := decodeSpaceProfiles(raw)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal legacy credentials for space %s: %w", spaceKey, err)
+		}
+		allCreds[spaceKey] = profiles
+	}
 	return allCreds, nil
 }
 
+// decodeSpaceProfiles decodes the legacy entry of one space, which is either a
+// single set of credentials or an unversioned profile map
+func decodeSpaceProfiles(raw json.RawMessage) (map[string]JiraCredentials, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return nil, err
+	}
+	if _, legacy := fields["instanceUrl"]; legacy {
+		var creds JiraCredentials
+		if err := json.Unmarshal(raw, &creds); err != nil {
+			return nil, err
+		}
+		return map[string]JiraCredentials{DefaultProfile: creds}, nil
+	}
+
+	var profiles map[string]JiraCredentials
+	if err := json.Unmarshal(raw, &profiles); err != nil {
+		return nil, err
+	}
+	if profiles == nil {
+		profiles = make(map[string]JiraCredentials)
+	}
+	return profiles, nil
+}
+
+// secrets returns the fields that are encrypted at rest
+func (c *JiraCredentials) secrets() []*string {
+	return []*string{&c.APIToken, &c.AccessToken, &c.RefreshToken}
+}
+
+// writeAllCredentials encrypts API and OAuth tokens and writes all credentials to file.
+// Callers must hold fileMu.
+func (cs *CredentialsStorage) writeAllCredentials(allCreds map[string]map[string]JiraCredentials) error {
+	encrypted := make(map[string]map[string]JiraCredentials, len(allCreds))
+	for spaceKey, profiles := range allCreds {
+		encrypted[spaceKey] = make(map[string]JiraCredentials, len(profiles))
+		for profile, creds := range profiles {
+			for _, secret := range creds.secrets() {
+				token, err := encryptSecret(*secret)
+				if err != nil {
+					return fmt.Errorf("failed to encrypt credentials for space %s (profile %s): %w", spaceKey, profile, err)
+				}
+				*secret = token
+			}
+			encrypted[spaceKey][profile] = creds
+		}
+	}
+
+	data, err := json.MarshalIndent(credentialsFile{
+		Version: credentialsFileVersion,
+		Spaces:  encrypted,
+	}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal credentials: %w", err)
+	}
+
+	return writeFileAtomic(cs.filePath, data)
+}
+
+// writeFileAtomic writes data to a temporary file next to path and renames it over
+// path, so readers see either the old or the new file and never a partial one
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp") // created with 0600
+	if err != nil {
+		return fmt.Errorf("failed to write credentials file: %w", err)
+	}
+	defer os.Remove(tmp.Name()) // no-op once renamed
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write credentials file: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write credentials file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write credentials file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to write credentials file: %w", err)
+	}
+	return nil
+}
+
 // GetAllSpaces returns a list of all space IDs that have credentials
 func (cs *CredentialsStorage) GetAllSpaces() ([]string, error) {
+	cs.fileMu.Lock()
 	allCreds, err := cs.loadAllCredentials()
+	cs.fileMu.Unlock()
 	if err != nil {
 		return []string{}, err
 	}
@@ -135,3 +683,20 @@ func (cs *CredentialsStorage) GetAllSpaces() ([]string, error) {
 
 	return spaces, nil
 }
+
+// spaceKeyFor returns the storage key of a space; an empty spaceID uses "default"
+func spaceKeyFor(spaceID string) string {
+	if spaceID == "" {
+		return "default"
+	}
+	return spaceID
+}
+
+// profileKeyFor returns the storage key of a profile; an empty profile uses DefaultProfile
+func profileKeyFor(profile string) string {
+	profile = strings.TrimSpace(profile)
+	if profile == "" {
+		return DefaultProfile
+	}
+	return profile
+}
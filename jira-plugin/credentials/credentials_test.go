@@ -0,0 +1,266 @@
+package credentials
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newTestStorage returns a storage backed by a credentials file in a temporary
+// directory, seeded with contents unless it is empty
+func newTestStorage(t *testing.T, contents string) *CredentialsStorage {
+	t.Helper()
+	t.Setenv(credentialsKeyEnv, "")
+	cs := &CredentialsStorage{filePath: filepath.Join(t.TempDir(), credentialsFileName)}
+	if contents != "" {
+		if err := os.WriteFile(cs.filePath, []byte(contents), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return cs
+}
+
+// readCredentialsFile decodes the versioned credentials file written by cs
+func readCredentialsFile(t *testing.T, cs *CredentialsStorage) credentialsFile {
+	t.Helper()
+	data, err := os.ReadFile(cs.filePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var file credentialsFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		t.Fatalf("credentials file is not versioned JSON: %v (%s)", err, data)
+	}
+	return file
+}
+
+func TestMigrateLegacyCredentials(t *testing.T) {
+	cs := newTestStorage(t, `{
+		"space-1": {"instanceUrl": "https://one.atlassian.net", "email": "ada@example.com", "apiToken": "token-1", "apiVersion": "3"},
+		"space-2": {"instanceUrl": "https://two.atlassian.net", "email": "bob@example.com", "apiToken": "token-2"}
+	}`)
+
+	creds, err := cs.GetCredentials("space-1")
+	if err != nil {
+		t.Fatalf("GetCredentials: %v", err)
+	}
+	if creds.InstanceURL != "https://one.atlassian.net" || creds.Email != "ada@example.com" || creds.APIToken != "token-1" || creds.APIVersion != "3" {
+		t.Errorf("migrated credentials = %+v, want the legacy space-1 entry", creds)
+	}
+	if creds.StorageKey() != "space-1/"+DefaultProfile {
+		t.Errorf("StorageKey = %q, want the default profile", creds.StorageKey())
+	}
+
+	// The file is rewritten in the versioned format with each space as its default profile
+	file := readCredentialsFile(t, cs)
+	if file.Version != credentialsFileVersion {
+		t.Errorf("version = %d, want %d", file.Version, credentialsFileVersion)
+	}
+	for space, email := range map[string]string{"space-1": "ada@example.com", "space-2": "bob@example.com"} {
+		profiles := file.Spaces[space]
+		if len(profiles) != 1 || profiles[DefaultProfile].Email != email {
+			t.Errorf("spaces[%s] = %+v, want only the default profile for %s", space, profiles, email)
+		}
+	}
+
+	// Loading the migrated file again reads it as-is
+	creds, err = cs.GetCredentials("space-2")
+	if err != nil || creds.APIToken != "token-2" {
+		t.Errorf("GetCredentials after migration = %+v, %v", creds, err)
+	}
+}
+
+func TestMigrateLegacyProfileMap(t *testing.T) {
+	cs := newTestStorage(t, `{
+		"space-1": {
+			"default": {"instanceUrl": "https://one.atlassian.net", "email": "ada@example.com", "apiToken": "token-1"},
+			"staging": {"instanceUrl": "https://staging.atlassian.net", "email": "ada@example.com", "apiToken": "token-2"}
+		}
+	}`)
+
+	profiles, err := cs.ListProfiles("space-1")
+	if err != nil {
+		t.Fatalf("ListProfiles: %v", err)
+	}
+	if strings.Join(profiles, ",") != "default,staging" {
+		t.Errorf("profiles = %v, want default,staging", profiles)
+	}
+	creds, err := cs.GetCredentialsProfile("space-1", "staging")
+	if err != nil || creds.InstanceURL != "https://staging.atlassian.net" {
+		t.Errorf("GetCredentialsProfile(staging) = %+v, %v", creds, err)
+	}
+	if file := readCredentialsFile(t, cs); file.Version != credentialsFileVersion || len(file.Spaces["space-1"]) != 2 {
+		t.Errorf("migrated file = %+v, want both profiles at version %d", file, credentialsFileVersion)
+	}
+}
+
+func TestSaveCredentialsWritesVersionedFile(t *testing.T) {
+	cs := newTestStorage(t, "")
+
+	err := cs.SaveCredentialsProfile("space-1", "staging", JiraCredentials{
+		InstanceURL: "https://staging.atlassian.net",
+		Email:       "ada@example.com",
+		APIToken:    "token-1",
+	})
+	if err != nil {
+		t.Fatalf("SaveCredentialsProfile: %v", err)
+	}
+
+	file := readCredentialsFile(t, cs)
+	if file.Version != credentialsFileVersion {
+		t.Errorf("version = %d, want %d", file.Version, credentialsFileVersion)
+	}
+	if file.Spaces["space-1"]["staging"].InstanceURL != "https://staging.atlassian.net" {
+		t.Errorf("spaces = %+v, want space-1/staging", file.Spaces)
+	}
+	creds, err := cs.GetCredentialsProfile("space-1", "staging")
+	if err != nil || creds.APIToken != "token-1" {
+		t.Errorf("GetCredentialsProfile = %+v, %v", creds, err)
+	}
+}
+
+func TestLoadRejectsNewerFileVersion(t *testing.T) {
+	contents := `{"version": 3, "spaces": {"space-1": {"default": {"instanceUrl": "https://one.atlassian.net"}}}}`
+	cs := newTestStorage(t, contents)
+
+	_, err := cs.GetCredentials("space-1")
+	if err == nil || !strings.Contains(err.Error(), "newer than supported") {
+		t.Fatalf("GetCredentials error = %v, want a newer-version error", err)
+	}
+	// The file is left untouched for the newer plugin version
+	data, _ := os.ReadFile(cs.filePath)
+	if string(data) != contents {
+		t.Errorf("credentials file was rewritten: %s", data)
+	}
+}
+
+// expiredOAuthCredentials are stored OAuth credentials whose access token has expired
+const expiredOAuthCredentials = `{"version": 2, "spaces": {"space-1": {"default": {
+	"instanceUrl": "https://one.atlassian.net",
+	"authType": "oauth",
+	"accessToken": "old-access",
+	"refreshToken": "old-refresh",
+	"tokenExpiresAt": "2020-01-01T00:00:00Z",
+	"cloudId": "cloud-1"
+}}}}`
+
+// rotatingRefresher returns a TokenRefresher that issues new tokens (rotating the
+// refresh token like Atlassian does), counting its calls and running during first
+func rotatingRefresher(calls *atomic.Int32, during func()) TokenRefresher {
+	return func(creds *JiraCredentials) error {
+		n := calls.Add(1)
+		if creds.RefreshToken != "old-refresh" {
+			return fmt.Errorf("refresh token %q was already used", creds.RefreshToken)
+		}
+		if during != nil && n == 1 {
+			during()
+		}
+		time.Sleep(10 * time.Millisecond)
+		creds.AccessToken = "new-access"
+		creds.RefreshToken = "new-refresh"
+		creds.TokenExpiresAt = time.Now().Add(time.Hour)
+		return nil
+	}
+}
+
+func TestConcurrentRefreshIsSingleFlight(t *testing.T) {
+	cs := newTestStorage(t, expiredOAuthCredentials)
+	var calls atomic.Int32
+	cs.SetTokenRefresher(rotatingRefresher(&calls, nil))
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 10)
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			creds, err := cs.GetCredentialsProfile("space-1", "")
+			if err == nil && creds.AccessToken != "new-access" {
+				err = fmt.Errorf("access token = %q, want the refreshed one", creds.AccessToken)
+			}
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Error(err)
+		}
+	}
+
+	if calls.Load() != 1 {
+		t.Errorf("token refresher called %d times, want once", calls.Load())
+	}
+	stored := readCredentialsFile(t, cs).Spaces["space-1"]["default"]
+	if stored.AccessToken != "new-access" || stored.RefreshToken != "new-refresh" {
+		t.Errorf("stored tokens = %q/%q, want the refreshed ones", stored.AccessToken, stored.RefreshToken)
+	}
+}
+
+func TestMarkValidatedDuringRefreshKeepsNewTokens(t *testing.T) {
+	cs := newTestStorage(t, expiredOAuthCredentials)
+	loaded, err := cs.loadCredentialsProfile("space-1", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A connection test finishes while Atlassian is refreshing the token
+	validatedAt := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	var calls atomic.Int32
+	cs.SetTokenRefresher(rotatingRefresher(&calls, func() {
+		if err := cs.MarkValidated(loaded, validatedAt); err != nil {
+			t.Errorf("MarkValidated: %v", err)
+		}
+	}))
+	if _, err := cs.GetCredentialsProfile("space-1", ""); err != nil {
+		t.Fatalf("GetCredentialsProfile: %v", err)
+	}
+
+	// A stale copy written back by either side would lose the other's change
+	stored := readCredentialsFile(t, cs).Spaces["space-1"]["default"]
+	if stored.AccessToken != "new-access" || stored.RefreshToken != "new-refresh" {
+		t.Errorf("stored tokens = %q/%q, want the refreshed ones", stored.AccessToken, stored.RefreshToken)
+	}
+	if !stored.LastValidatedAt.Equal(validatedAt) {
+		t.Errorf("LastValidatedAt = %v, want %v", stored.LastValidatedAt, validatedAt)
+	}
+
+	// MarkValidated after the refresh, with the stale copy, keeps the new tokens too
+	if err := cs.MarkValidated(loaded, validatedAt.Add(time.Hour)); err != nil {
+		t.Fatalf("MarkValidated: %v", err)
+	}
+	if stored := readCredentialsFile(t, cs).Spaces["space-1"]["default"]; stored.RefreshToken != "new-refresh" {
+		t.Errorf("refresh token = %q after MarkValidated, want new-refresh", stored.RefreshToken)
+	}
+}
+
+func TestSaveCredentialsLeavesNoTempFiles(t *testing.T) {
+	cs := newTestStorage(t, "")
+	for i := range 3 {
+		if err := cs.SaveCredentials("space-1", JiraCredentials{InstanceURL: fmt.Sprintf("https://site%d.atlassian.net", i)}); err != nil {
+			t.Fatalf("SaveCredentials: %v", err)
+		}
+	}
+
+	entries, err := os.ReadDir(filepath.Dir(cs.filePath))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Name() != credentialsFileName {
+		t.Errorf("directory holds %v, want only %s", entries, credentialsFileName)
+	}
+	info, err := os.Stat(cs.filePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("credentials file mode = %v, want 0600", info.Mode().Perm())
+	}
+}
@@ -0,0 +1,110 @@
+package credentials
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"sync"
+)
+
+// credentialsKeyEnv is the environment variable holding the secret used to encrypt stored tokens
+const credentialsKeyEnv = "SOREN_CREDENTIALS_KEY"
+
+// encryptedPrefix marks a value encrypted with AES-GCM (base64 of nonce + ciphertext follows)
+const encryptedPrefix = "enc:v1:"
+
+var plaintextWarningOnce sync.Once
+
+// encryptionKey derives a 256-bit AES key from SOREN_CREDENTIALS_KEY.
+// It returns nil if the env var is not set.
+func encryptionKey() []byte {
+	secret := os.Getenv(credentialsKeyEnv)
+	if secret == "" {
+		return nil
+	}
+	key := sha256.Sum256([]byte(secret))
+	return key[:]
+}
+
+// encryptSecret encrypts a secret value for storage.
+// If no key is configured, the value is returned unchanged and a warning is logged once.
+func encryptSecret(value string) (string, error) {
+	if value == "" || strings.HasPrefix(value, encryptedPrefix) {
+		return value, nil
+	}
+
+	key := encryptionKey()
+	if key == nil {
+		plaintextWarningOnce.Do(func() {
+			log.Printf("Warning: %s is not set, Jira credentials will be stored in plaintext", credentialsKeyEnv)
+		})
+		return value, nil
+	}
+
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	sealed := gcm.Seal(nonce, nonce, []byte(value), nil)
+	return encryptedPrefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// decryptSecret decrypts a value produced by encryptSecret.
+// Plaintext values (written before encryption was enabled) are returned unchanged.
+func decryptSecret(value string) (string, error) {
+	if !strings.HasPrefix(value, encryptedPrefix) {
+		return value, nil
+	}
+
+	key := encryptionKey()
+	if key == nil {
+		return "", fmt.Errorf("stored credentials are encrypted but %s is not set", credentialsKeyEnv)
+	}
+
+	sealed, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(value, encryptedPrefix))
+	if err != nil {
+		return "", fmt.Errorf("failed to decode encrypted value: %w", err)
+	}
+
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
+
+	if len(sealed) < gcm.NonceSize() {
+		return "", errors.New("encrypted value is too short")
+	}
+	nonce, ciphertext := sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():]
+
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt value (wrong %s?): %w", credentialsKeyEnv, err)
+	}
+	return string(plaintext), nil
+}
+
+// newGCM creates an AES-GCM cipher for the given key
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GCM: %w", err)
+	}
+	return gcm, nil
+}
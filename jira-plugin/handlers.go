@@ -1,23 +1,47 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
+	"net/url"
+	"os"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/bytedance/sonic"
 	"github.com/nats-io/nats.go"
+	sdkv2 "github.com/sorenhq/go-plugin-sdk/gosdk"
+	models "github.com/sorenhq/go-plugin-sdk/gosdk/models"
 
+	"github.com/sorenhq/jira-plugin/actions/handler"
+	"github.com/sorenhq/jira-plugin/client"
 	"github.com/sorenhq/jira-plugin/credentials"
+	"github.com/sorenhq/jira-plugin/shutdown"
 )
 
+// onboardingTimeout bounds the credential check performed during onboarding
+const onboardingTimeout = 30 * time.Second
+
 // onboardingHandler handles the onboarding/requirements submission
 func onboardingHandler(msg *nats.Msg) any {
 	// Extract spaceId from the NATS message subject
-	spaceID := extractSpaceIdFromSubject(msg.Subject)
+	spaceID := handler.SpaceIDFromSubject(msg.Subject)
 	log.Printf("Onboarding request received for space '%s' (extracted from subject: %s)", spaceID, msg.Subject)
 
+	if !shutdown.Begin() {
+		response, _ := json.Marshal(map[string]any{
+			"status": "error",
+			"error":  "The Jira plugin is shutting down, please retry shortly",
+		})
+		msg.Respond(response)
+		return nil
+	}
+	defer shutdown.End()
+
 	var onboardingData map[string]any
 	err := sonic.Unmarshal(msg.Data, &onboardingData)
 	if err != nil {
@@ -35,10 +59,45 @@ func onboardingHandler(msg *nats.Msg) any {
 		InstanceURL: getStringValue(onboardingData, "instanceUrl"),
 		Email:       getStringValue(onboardingData, "email"),
 		APIToken:    getStringValue(onboardingData, "apiToken"),
+		APIVersion:  getStringValue(onboardingData, "apiVersion"),
+		// Project keys are upper case in Jira
+		DefaultProjectKey: strings.ToUpper(strings.TrimSpace(getStringValue(onboardingData, "defaultProjectKey"))),
+		AuthType:          strings.TrimSpace(getStringValue(onboardingData, "authType")),
+		AccessToken:       strings.TrimSpace(getStringValue(onboardingData, "accessToken")),
+		RefreshToken:      strings.TrimSpace(getStringValue(onboardingData, "refreshToken")),
+	}
+	profile := strings.TrimSpace(getStringValue(onboardingData, "profile"))
+	if profile == "" {
+		profile = credentials.DefaultProfile
+	}
+	// JSON numbers decode as float64
+	if timeoutSeconds, ok := onboardingData["timeoutSeconds"].(float64); ok {
+		creds.TimeoutSeconds = int(timeoutSeconds)
+	}
+	// expiresIn is the access token lifetime in seconds, as returned with it by Atlassian
+	if expiresIn, ok := onboardingData["expiresIn"].(float64); ok && expiresIn > 0 {
+		creds.TokenExpiresAt = time.Now().UTC().Add(time.Duration(expiresIn) * time.Second)
 	}
 
 	// Validate required fields
-	if creds.InstanceURL == "" || creds.Email == "" || creds.APIToken == "" {
+	if creds.AuthType != "" && creds.AuthType != credentials.AuthTypeAPIToken && creds.AuthType != credentials.AuthTypeOAuth {
+		response, _ := json.Marshal(map[string]any{
+			"status": "error",
+			"code":   "validation_error",
+			"error":  fmt.Sprintf("Invalid authType: must be %q or %q", credentials.AuthTypeAPIToken, credentials.AuthTypeOAuth),
+		})
+		msg.Respond(response)
+		return nil
+	}
+	if creds.IsOAuth() && (creds.InstanceURL == "" || creds.AccessToken == "") {
+		response, _ := json.Marshal(map[string]any{
+			"status": "error",
+			"error":  "Missing required fields: instanceUrl and accessToken are required for OAuth",
+		})
+		msg.Respond(response)
+		return nil
+	}
+	if !creds.IsOAuth() && (creds.InstanceURL == "" || creds.Email == "" || creds.APIToken == "") {
 		response, _ := json.Marshal(map[string]any{
 			"status": "error",
 			"error":  "Missing required fields: instanceUrl, email, and apiToken are required",
@@ -46,10 +105,107 @@ func onboardingHandler(msg *nats.Msg) any {
 		msg.Respond(response)
 		return nil
 	}
+	instanceURL, err := normalizeInstanceURL(creds.InstanceURL)
+	if err != nil {
+		response, _ := json.Marshal(map[string]any{
+			"status": "error",
+			"code":   "validation_error",
+			"error":  fmt.Sprintf("Invalid instanceUrl: %v. Use the address of your Jira site, e.g. https://yourcompany.atlassian.net", err),
+		})
+		msg.Respond(response)
+		return nil
+	}
+	creds.InstanceURL = instanceURL
+	explicitAPIVersion := creds.APIVersion != ""
+	if explicitAPIVersion && creds.APIVersion != "2" && creds.APIVersion != "3" {
+		response, _ := json.Marshal(map[string]any{
+			"status": "error",
+			"error":  "Invalid apiVersion: must be \"2\" (Jira Server/Data Center) or \"3\" (Jira Cloud)",
+		})
+		msg.Respond(response)
+		return nil
+	}
+
+	if creds.TimeoutSeconds < 0 || time.Duration(creds.TimeoutSeconds)*time.Second > client.MaxTimeout {
+		response, _ := json.Marshal(map[string]any{
+			"status": "error",
+			"code":   "validation_error",
+			"error":  fmt.Sprintf("Invalid timeoutSeconds: must be between 1 and %d, or empty for the default", int(client.MaxTimeout.Seconds())),
+		})
+		msg.Respond(response)
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), credentialCheckTimeout(&creds))
+	defer cancel()
+
+	if creds.IsOAuth() {
+		// OAuth is Jira Cloud only; requests go through Atlassian's API gateway, which
+		// identifies the site by its cloud ID
+		if err := prepareOAuthCredentials(ctx, &creds); err != nil {
+			log.Printf("OAuth setup failed for space '%s': %v", spaceID, err)
+			response, _ := json.Marshal(map[string]any{
+				"status": "error",
+				"code":   client.ErrorCode(err),
+				"error":  fmt.Sprintf("Failed to set up OAuth access: %v", err),
+			})
+			msg.Respond(response)
+			return nil
+		}
+		if !explicitAPIVersion {
+			creds.APIVersion = "3"
+		}
+	} else if deploymentType, err := client.NewJiraClient(&creds).DetectDeploymentType(ctx); err != nil {
+		// Detect Cloud vs Server/Data Center to pick the auth mode and (unless given) the API version.
+		// If detection fails, the auth mode is derived from the URL and API v2 is used.
+		log.Printf("Could not detect Jira deployment type for space '%s', falling back to URL-based auth and API v%s: %v", spaceID, client.DefaultAPIVersion, err)
+		if !explicitAPIVersion {
+			creds.APIVersion = client.DefaultAPIVersion
+		}
+	} else {
+		authMode, apiVersion := client.DeploymentSettings(deploymentType)
+		creds.DeploymentType = deploymentType
+		creds.AuthMode = authMode
+		if !explicitAPIVersion {
+			creds.APIVersion = apiVersion
+		}
+		log.Printf("Jira for space '%s' is %s: using %s auth and API v%s", spaceID, deploymentType, creds.AuthMode, creds.APIVersion)
+	}
 
-	// Save credentials using spaceID as the key
+	// Verify the credentials work before saving them. The check may take as long as the
+	// instance's request timeout allows, unlike the quick health checks.
+	jiraClient := client.NewJiraClient(&creds, client.WithTestConnectionTimeout(0))
+	user, err := jiraClient.TestConnection(ctx)
+	if err != nil {
+		log.Printf("Credential validation failed for space '%s': %v", spaceID, err)
+		errorMsg := fmt.Sprintf("Failed to connect to Jira: %v", err)
+		if errors.Is(err, client.ErrUnauthorized) || errors.Is(err, client.ErrForbidden) {
+			errorMsg = "Jira rejected the provided credentials. Please check that the instance URL, email, and API token are correct."
+			if creds.IsOAuth() {
+				errorMsg = "Jira rejected the provided OAuth access token. Please check that it is valid and has the read:jira-user and read:jira-work scopes."
+			}
+		}
+		response, _ := json.Marshal(map[string]any{
+			"status": "error",
+			"error":  errorMsg,
+		})
+		msg.Respond(response)
+		return nil
+	}
+	displayName := getStringValue(user, "displayName")
+	creds.LastValidatedAt = time.Now().UTC()
+	// OAuth onboarding has no email; record who the token belongs to instead, since the
+	// email keeps different users' cached metadata apart
+	if creds.Email == "" {
+		creds.Email = getStringValue(user, "emailAddress")
+	}
+	if creds.Email == "" {
+		creds.Email = getStringValue(user, "accountId")
+	}
+
+	// Save credentials using spaceID and profile as the key
 	credsStorage := credentials.GetCredentialsStorage()
-	err = credsStorage.SaveCredentials(spaceID, creds)
+	err = credsStorage.SaveCredentialsProfile(spaceID, profile, creds)
 	if err != nil {
 		log.Printf("Failed to save credentials: %v", err)
 		response, _ := json.Marshal(map[string]any{
@@ -60,27 +216,656 @@ func onboardingHandler(msg *nats.Msg) any {
 		return nil
 	}
 
-	log.Printf("Credentials saved successfully for space: %s", spaceID)
+	log.Printf("Credentials saved successfully for space: %s, profile: %s (connected as %s)", spaceID, profile, displayName)
 	response, _ := json.Marshal(map[string]any{
-		"status":  "accepted",
-		"message": "Credentials saved successfully",
+		"status":         "accepted",
+		"message":        fmt.Sprintf("Credentials saved successfully. Connected to Jira as %s", displayName),
+		"displayName":    displayName,
+		"profile":        profile,
+		"deploymentType": creds.DeploymentType,
+		"authType":       authTypeOf(&creds),
+		"authMode":       jiraClient.AuthMode,
+		"apiVersion":     creds.APIVersion,
+		"timeoutSeconds": int(jiraClient.HTTPClient.Timeout.Seconds()),
 	})
 	msg.Respond(response)
 	return nil
 }
 
-// extractSpaceIdFromSubject extracts the entityId (spaceId) from NATS message subject
-// Subject pattern: soren.v2.bin.{entityId}.{pluginId}.{path} or soren.cpu.bin.{entityId}.{pluginId}.{path}
-func extractSpaceIdFromSubject(subject string) string {
-	parts := strings.Split(subject, ".")
-	// Look for "bin" in the subject, entityId should be right after it
-	for i, part := range parts {
-		if part == "bin" && i+1 < len(parts) {
-			return parts[i+1]
+// prepareOAuthCredentials readies OAuth credentials for use: an expired access token is
+// refreshed first, then the site's cloud ID is looked up
+func prepareOAuthCredentials(ctx context.Context, creds *credentials.JiraCredentials) error {
+	if creds.TokenExpired() {
+		if err := client.RefreshOAuthToken(creds); err != nil {
+			return fmt.Errorf("the access token has expired and could not be refreshed: %w", err)
 		}
 	}
-	// If pattern doesn't match, return empty string (will use default)
-	return ""
+	cloudID, err := client.ResolveCloudID(ctx, creds.AccessToken, creds.InstanceURL)
+	if err != nil {
+		return err
+	}
+	creds.CloudID = cloudID
+	creds.DeploymentType = "Cloud"
+	return nil
+}
+
+// authTypeOf returns the credentials' auth type, naming the default explicitly
+func authTypeOf(creds *credentials.JiraCredentials) string {
+	if creds.AuthType == "" {
+		return credentials.AuthTypeAPIToken
+	}
+	return creds.AuthType
+}
+
+// credentialCheckTimeout bounds checking credentials (deployment detection plus a
+// connection test), leaving room for instances configured with a long request timeout
+func credentialCheckTimeout(creds *credentials.JiraCredentials) time.Duration {
+	return max(onboardingTimeout, 2*time.Duration(creds.TimeoutSeconds)*time.Second)
+}
+
+// validateCredentials is the credentials storage validator: it redetects the deployment
+// when it isn't known (e.g. after the instance URL changed) and tests the connection
+func validateCredentials(creds *credentials.JiraCredentials) error {
+	ctx, cancel := context.WithTimeout(context.Background(), credentialCheckTimeout(creds))
+	defer cancel()
+
+	if creds.IsOAuth() && creds.DeploymentType == "" {
+		// The instance URL changed, so the cloud ID must be looked up again
+		if err := prepareOAuthCredentials(ctx, creds); err != nil {
+			return err
+		}
+	} else if creds.DeploymentType == "" {
+		deploymentType, err := client.NewJiraClient(creds).DetectDeploymentType(ctx)
+		if err != nil {
+			log.Printf("Could not detect Jira deployment type of %s, using URL-based auth: %v", creds.InstanceURL, err)
+		} else {
+			creds.DeploymentType = deploymentType
+			creds.AuthMode, _ = client.DeploymentSettings(deploymentType)
+		}
+	}
+
+	_, err := client.NewJiraClient(creds, client.WithTestConnectionTimeout(0)).TestConnection(ctx)
+	return err
+}
+
+// normalizeInstanceURL cleans up a user-supplied Jira URL: https:// is prepended when
+// no scheme is given, trailing slashes are removed, and non-http(s) schemes or
+// hosts without a dot (other than localhost) are rejected. A context path
+// (https://host/jira) is kept; a pasted REST API path after it is dropped.
+func normalizeInstanceURL(raw string) (string, error) {
+	raw = strings.TrimSpace(raw)
+	if !strings.Contains(raw, "://") {
+		raw = "https://" + raw
+	}
+
+	parsed, err := url.Parse(raw)
+	if err != nil {
+		return "", fmt.Errorf("could not parse URL")
+	}
+	scheme := strings.ToLower(parsed.Scheme)
+	if scheme != "http" && scheme != "https" {
+		return "", fmt.Errorf("unsupported scheme %q (only http and https are allowed)", parsed.Scheme)
+	}
+	host := parsed.Hostname()
+	if host == "" || strings.ContainsAny(host, " _") || (!strings.Contains(host, ".") && host != "localhost") {
+		return "", fmt.Errorf("invalid host %q", host)
+	}
+
+	parsed.Scheme = scheme
+	// Collapse repeated slashes and cut a REST path copied from an API URL,
+	// e.g. https://host/jira/rest/api/2/ becomes https://host/jira
+	path := strings.Join(strings.FieldsFunc(parsed.Path, func(r rune) bool { return r == '/' }), "/")
+	if path == "rest" || strings.HasPrefix(path, "rest/") {
+		path = ""
+	} else if prefix, _, found := strings.Cut(path, "/rest/"); found {
+		path = prefix
+	}
+	parsed.Path = ""
+	if path != "" {
+		parsed.Path = "/" + path
+	}
+	parsed.RawPath = ""
+	parsed.RawQuery = ""
+	parsed.Fragment = ""
+	return strings.TrimRight(parsed.String(), "/"), nil
+}
+
+// getCredentialsActions returns the actions for managing the space's stored credentials
+func getCredentialsActions() []models.Action {
+	return []models.Action{
+		{
+			Method:      "credentials.delete",
+			Title:       "Disconnect Jira",
+			Description: "Remove the Jira credentials stored for this space, or a single named profile",
+			Form: models.ActionFormBuilder{
+				Jsonui: map[string]any{
+					"type": "VerticalLayout",
+					"elements": []map[string]any{
+						{
+							"type":  "Control",
+							"scope": "#/properties/profile",
+						},
+					},
+				},
+				Jsonschema: map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"profile": map[string]any{
+							"type":        "string",
+							"title":       "Profile",
+							"description": "Credentials profile to remove. Leave empty to remove every profile of this space",
+						},
+					},
+				},
+			},
+			RequestHandler: deleteCredentialsHandler,
+		},
+		{
+			Method:      "credentials.get",
+			Title:       "Get Jira Connection",
+			Description: "Show the non-secret details of the Jira credentials stored for this space, including when they were created, updated and last validated",
+			Form: models.ActionFormBuilder{
+				Jsonui: map[string]any{
+					"type": "VerticalLayout",
+					"elements": []map[string]any{
+						{
+							"type":  "Control",
+							"scope": "#/properties/profile",
+						},
+					},
+				},
+				Jsonschema: map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"profile": map[string]any{
+							"type":        "string",
+							"title":       "Profile",
+							"description": "Credentials profile to show. Leave empty to show every profile of this space",
+						},
+					},
+				},
+			},
+			RequestHandler: getCredentialsHandler,
+		},
+		{
+			Method:      "credentials.update",
+			Title:       "Update Jira Credentials",
+			Description: "Update some of the stored Jira credentials (e.g. rotate the API token) without re-entering the others. The updated credentials are tested before they are saved",
+			Form: models.ActionFormBuilder{
+				Jsonui: map[string]any{
+					"type": "VerticalLayout",
+					"elements": []map[string]any{
+						{
+							"type":  "Control",
+							"scope": "#/properties/apiToken",
+						},
+						{
+							"type":  "Control",
+							"scope": "#/properties/email",
+						},
+						{
+							"type":  "Control",
+							"scope": "#/properties/instanceUrl",
+						},
+						{
+							"type":  "Control",
+							"scope": "#/properties/profile",
+						},
+					},
+				},
+				Jsonschema: map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"apiToken": map[string]any{
+							"type":        "string",
+							"title":       "API Token",
+							"description": "New Jira API token",
+							"format":      "password",
+						},
+						"email": map[string]any{
+							"type":        "string",
+							"title":       "Email",
+							"description": "New Jira account email",
+						},
+						"instanceUrl": map[string]any{
+							"type":        "string",
+							"title":       "Instance URL",
+							"description": "New Jira instance URL",
+						},
+						"profile": map[string]any{
+							"type":        "string",
+							"title":       "Profile",
+							"description": "Credentials profile to update. Defaults to the default profile",
+						},
+					},
+				},
+			},
+			RequestHandler: updateCredentialsHandler,
+		},
+	}
+}
+
+// updateCredentialsHandler handles the credentials.update action
+func updateCredentialsHandler(msg *nats.Msg) {
+	spaceID := handler.SpaceIDFromSubject(msg.Subject)
+	log.Printf("Action credentials.update called for space '%s' (extracted from subject: %s)", spaceID, msg.Subject)
+
+	// Track the job so a shutdown waits for it; new jobs are refused while shutting down
+	if !shutdown.Begin() {
+		sdkv2.RejectWithBody(msg, map[string]any{
+			"error":   "shutting_down",
+			"message": "The Jira plugin is shutting down, please retry shortly",
+		})
+		return
+	}
+	defer shutdown.End()
+
+	var requestData models.ActionRequestContent
+	if err := sonic.Unmarshal(msg.Data, &requestData); err != nil {
+		log.Printf("Failed to unmarshal action request: %v", err)
+		sdkv2.RejectWithBody(msg, map[string]any{
+			"error":   "invalid_request",
+			"message": "Failed to parse request",
+		})
+		return
+	}
+	profile := strings.TrimSpace(getStringValue(requestData.Body, "profile"))
+	if profile == "" {
+		profile = credentials.DefaultProfile
+	}
+
+	// Only the fields that were given are updated
+	updates := map[string]string{}
+	for _, field := range credentials.UpdatableFields {
+		if value := strings.TrimSpace(getStringValue(requestData.Body, field)); value != "" {
+			updates[field] = value
+		}
+	}
+
+	// Handshake via SDK (stores entityId and responds)
+	jobID := sdkv2.Accept(msg)
+	if jobID == "" {
+		sdkv2.RejectWithBody(msg, map[string]any{
+			"error":   "job_creation_failed",
+			"message": "Failed to create job",
+		})
+		return
+	}
+
+	var result map[string]any
+	credsStorage := credentials.GetCredentialsStorage()
+	if len(updates) == 0 {
+		result = map[string]any{
+			"error":   "validation_error",
+			"message": "Nothing to update: provide at least one of apiToken, email or instanceUrl",
+		}
+	} else if !credsStorage.HasCredentialsProfile(spaceID, profile) {
+		result = map[string]any{
+			"error":   "credentials_not_found",
+			"message": fmt.Sprintf("Jira credentials profile '%s' is not configured for this space. Please complete onboarding first.", profile),
+		}
+	} else {
+		var err error
+		if instanceURL, ok := updates["instanceUrl"]; ok {
+			updates["instanceUrl"], err = normalizeInstanceURL(instanceURL)
+		}
+		if err != nil {
+			result = map[string]any{
+				"error":   "validation_error",
+				"message": fmt.Sprintf("Invalid instanceUrl: %v", err),
+			}
+		} else if creds, err := credsStorage.UpdateCredentialFieldProfile(spaceID, profile, updates); err != nil {
+			log.Printf("Failed to update credentials profile %s for space '%s': %v", profile, spaceID, err)
+			switch {
+			case errors.Is(err, client.ErrUnauthorized) || errors.Is(err, client.ErrForbidden):
+				result = map[string]any{
+					"error":   "validation_error",
+					"message": "Jira rejected the updated credentials; the stored credentials were left unchanged",
+				}
+			case errors.Is(err, credentials.ErrInvalidUpdate) || errors.Is(err, credentials.ErrValidationFailed):
+				result = map[string]any{
+					"error":   "validation_error",
+					"message": fmt.Sprintf("%v; the stored credentials were left unchanged", err),
+				}
+			default:
+				result = map[string]any{
+					"error":   "credentials_error",
+					"message": fmt.Sprintf("Failed to update credentials: %v", err),
+				}
+			}
+		} else {
+			fields := make([]string, 0, len(updates))
+			for field := range updates {
+				fields = append(fields, field)
+			}
+			sort.Strings(fields)
+			log.Printf("Credentials profile %s updated for space '%s' (fields: %s)", profile, spaceID, strings.Join(fields, ", "))
+			result = map[string]any{
+				"result":      "success",
+				"message":     fmt.Sprintf("Jira credentials updated and verified (%s)", strings.Join(fields, ", ")),
+				"updated":     fields,
+				"credentials": credentialsSummary(profile, creds),
+			}
+		}
+	}
+
+	if plugin := sdkv2.GetPlugin(); plugin != nil {
+		plugin.Done(jobID, result)
+	} else {
+		log.Printf("Failed to publish result: plugin instance not found")
+	}
+}
+
+// credentialsSummary returns the non-secret details of stored credentials; the API
+// token is never included
+func credentialsSummary(profile string, creds *credentials.JiraCredentials) map[string]any {
+	summary := map[string]any{
+		"profile":        profile,
+		"instanceUrl":    creds.InstanceURL,
+		"email":          creds.Email,
+		"deploymentType": creds.DeploymentType,
+		"apiVersion":     creds.APIVersion,
+		"authType":       authTypeOf(creds),
+	}
+	if creds.DefaultProjectKey != "" {
+		summary["defaultProjectKey"] = creds.DefaultProjectKey
+	}
+	// Timestamps are missing for credentials saved before they were tracked
+	for name, at := range map[string]time.Time{
+		"createdAt":       creds.CreatedAt,
+		"updatedAt":       creds.UpdatedAt,
+		"lastValidatedAt": creds.LastValidatedAt,
+		"tokenExpiresAt":  creds.TokenExpiresAt,
+	} {
+		if !at.IsZero() {
+			summary[name] = at.Format(time.RFC3339)
+		}
+	}
+	return summary
+}
+
+// getCredentialsHandler handles the credentials.get action
+func getCredentialsHandler(msg *nats.Msg) {
+	spaceID := handler.SpaceIDFromSubject(msg.Subject)
+	log.Printf("Action credentials.get called for space '%s' (extracted from subject: %s)", spaceID, msg.Subject)
+
+	// Track the job so a shutdown waits for it; new jobs are refused while shutting down
+	if !shutdown.Begin() {
+		sdkv2.RejectWithBody(msg, map[string]any{
+			"error":   "shutting_down",
+			"message": "The Jira plugin is shutting down, please retry shortly",
+		})
+		return
+	}
+	defer shutdown.End()
+
+	// The body is optional; it only carries the profile to show
+	var requestData models.ActionRequestContent
+	if len(msg.Data) > 0 {
+		if err := sonic.Unmarshal(msg.Data, &requestData); err != nil {
+			log.Printf("Failed to unmarshal action request: %v", err)
+			sdkv2.RejectWithBody(msg, map[string]any{
+				"error":   "invalid_request",
+				"message": "Failed to parse request",
+			})
+			return
+		}
+	}
+	profile := strings.TrimSpace(getStringValue(requestData.Body, "profile"))
+
+	// Handshake via SDK (stores entityId and responds)
+	jobID := sdkv2.Accept(msg)
+	if jobID == "" {
+		sdkv2.RejectWithBody(msg, map[string]any{
+			"error":   "job_creation_failed",
+			"message": "Failed to create job",
+		})
+		return
+	}
+
+	credsStorage := credentials.GetCredentialsStorage()
+	profiles := []string{profile}
+	if profile == "" {
+		var err error
+		profiles, err = credsStorage.ListProfiles(spaceID)
+		if err != nil {
+			log.Printf("Failed to list credentials profiles: %v", err)
+			profiles = nil
+		}
+	}
+
+	var result map[string]any
+	connections := make([]map[string]any, 0, len(profiles))
+	for _, name := range profiles {
+		creds, err := credsStorage.GetCredentialsProfile(spaceID, name)
+		if err != nil {
+			continue
+		}
+		connections = append(connections, credentialsSummary(name, creds))
+	}
+
+	if len(connections) == 0 {
+		message := "Jira credentials not configured for this space. Please complete onboarding first."
+		if profile != "" {
+			message = fmt.Sprintf("Jira credentials profile '%s' is not configured for this space", profile)
+		}
+		result = map[string]any{
+			"error":   "credentials_not_found",
+			"message": message,
+		}
+	} else {
+		result = map[string]any{
+			"result":   "success",
+			"message":  fmt.Sprintf("%d Jira credentials profiles configured for this space", len(connections)),
+			"profiles": connections,
+			"count":    len(connections),
+		}
+	}
+
+	if plugin := sdkv2.GetPlugin(); plugin != nil {
+		plugin.Done(jobID, result)
+	} else {
+		log.Printf("Failed to publish result: plugin instance not found")
+	}
+}
+
+// deleteCredentialsHandler handles the credentials.delete action
+func deleteCredentialsHandler(msg *nats.Msg) {
+	spaceID := handler.SpaceIDFromSubject(msg.Subject)
+	log.Printf("Action credentials.delete called for space '%s' (extracted from subject: %s)", spaceID, msg.Subject)
+
+	// Track the job so a shutdown waits for it; new jobs are refused while shutting down
+	if !shutdown.Begin() {
+		sdkv2.RejectWithBody(msg, map[string]any{
+			"error":   "shutting_down",
+			"message": "The Jira plugin is shutting down, please retry shortly",
+		})
+		return
+	}
+	defer shutdown.End()
+
+	// The body is optional; it only carries the profile to remove
+	var requestData models.ActionRequestContent
+	if len(msg.Data) > 0 {
+		if err := sonic.Unmarshal(msg.Data, &requestData); err != nil {
+			log.Printf("Failed to unmarshal action request: %v", err)
+			sdkv2.RejectWithBody(msg, map[string]any{
+				"error":   "invalid_request",
+				"message": "Failed to parse request",
+			})
+			return
+		}
+	}
+	profile := strings.TrimSpace(getStringValue(requestData.Body, "profile"))
+
+	// Handshake via SDK (stores entityId and responds)
+	jobID := sdkv2.Accept(msg)
+	if jobID == "" {
+		sdkv2.RejectWithBody(msg, map[string]any{
+			"error":   "job_creation_failed",
+			"message": "Failed to create job",
+		})
+		return
+	}
+
+	// Deleting is a no-op if the space was never onboarded
+	credsStorage := credentials.GetCredentialsStorage()
+	var result map[string]any
+	var err error
+	if profile == "" {
+		err = credsStorage.DeleteCredentials(spaceID)
+	} else {
+		err = credsStorage.DeleteCredentialsProfile(spaceID, profile)
+	}
+	if err != nil {
+		log.Printf("Failed to delete credentials: %v", err)
+		result = map[string]any{
+			"error":   "credentials_error",
+			"message": fmt.Sprintf("Failed to delete credentials: %v", err),
+		}
+	} else if profile == "" {
+		log.Printf("Credentials deleted for space: %s", spaceID)
+		result = map[string]any{
+			"result":                "success",
+			"message":               "Jira credentials removed for this space",
+			"credentialsConfigured": credsStorage.HasCredentials(spaceID),
+		}
+	} else {
+		log.Printf("Credentials profile %s deleted for space: %s", profile, spaceID)
+		result = map[string]any{
+			"result":                "success",
+			"message":               fmt.Sprintf("Jira credentials profile '%s' removed for this space", profile),
+			"profile":               profile,
+			"credentialsConfigured": credsStorage.HasCredentialsProfile(spaceID, profile),
+		}
+	}
+
+	if plugin := sdkv2.GetPlugin(); plugin != nil {
+		plugin.Done(jobID, result)
+	} else {
+		log.Printf("Failed to publish result: plugin instance not found")
+	}
+}
+
+// adminSpacesEnv lists (comma-separated) the space IDs allowed to call admin actions.
+// Admin actions are not registered at all when it is unset.
+const adminSpacesEnv = "SOREN_JIRA_ADMIN_SPACES"
+
+// adminSpaces returns the space IDs allowed to call admin actions
+func adminSpaces() map[string]bool {
+	spaces := map[string]bool{}
+	for _, spaceID := range strings.Split(os.Getenv(adminSpacesEnv), ",") {
+		if spaceID = strings.TrimSpace(spaceID); spaceID != "" {
+			spaces[spaceID] = true
+		}
+	}
+	return spaces
+}
+
+// getAdminActions returns the operator actions, or none if no admin space is configured
+func getAdminActions() []models.Action {
+	if len(adminSpaces()) == 0 {
+		return nil
+	}
+	return []models.Action{
+		{
+			Method:      "admin.spaces.list",
+			Title:       "List Connected Spaces",
+			Description: "List every space with Jira credentials, its instance URL and when the credentials were last validated (admin only)",
+			Form: models.ActionFormBuilder{
+				Jsonui:     map[string]any{},
+				Jsonschema: map[string]any{"type": "object", "properties": map[string]any{}},
+			},
+			RequestHandler: listSpacesHandler,
+		},
+	}
+}
+
+// listSpacesHandler handles the admin.spaces.list action. Only non-secret details
+// are returned: no tokens or emails.
+func listSpacesHandler(msg *nats.Msg) {
+	spaceID := handler.SpaceIDFromSubject(msg.Subject)
+	log.Printf("Action admin.spaces.list called for space '%s' (extracted from subject: %s)", spaceID, msg.Subject)
+
+	if !adminSpaces()[spaceID] {
+		log.Printf("Action admin.spaces.list rejected for space '%s': not an admin space", spaceID)
+		sdkv2.RejectWithBody(msg, map[string]any{
+			"error":   "forbidden",
+			"message": "This action is only available to admin spaces",
+		})
+		return
+	}
+
+	// Track the job so a shutdown waits for it; new jobs are refused while shutting down
+	if !shutdown.Begin() {
+		sdkv2.RejectWithBody(msg, map[string]any{
+			"error":   "shutting_down",
+			"message": "The Jira plugin is shutting down, please retry shortly",
+		})
+		return
+	}
+	defer shutdown.End()
+
+	// Handshake via SDK (stores entityId and responds)
+	jobID := sdkv2.Accept(msg)
+	if jobID == "" {
+		sdkv2.RejectWithBody(msg, map[string]any{
+			"error":   "job_creation_failed",
+			"message": "Failed to create job",
+		})
+		return
+	}
+
+	var result map[string]any
+	credsStorage := credentials.GetCredentialsStorage()
+	spaceIDs, err := credsStorage.GetAllSpaces()
+	if err != nil && !os.IsNotExist(err) {
+		log.Printf("Failed to list spaces: %v", err)
+		result = map[string]any{
+			"error":   "credentials_error",
+			"message": fmt.Sprintf("Failed to list spaces: %v", err),
+		}
+	} else {
+		sort.Strings(spaceIDs)
+		spaces := make([]map[string]any, 0, len(spaceIDs))
+		for _, id := range spaceIDs {
+			profiles, err := credsStorage.ListProfiles(id)
+			if err != nil {
+				log.Printf("Failed to list profiles of space %s: %v", id, err)
+				continue
+			}
+			connections := make([]map[string]any, 0, len(profiles))
+			for _, profile := range profiles {
+				creds, err := credsStorage.GetCredentialsProfile(id, profile)
+				if err != nil {
+					log.Printf("Failed to read profile %s of space %s: %v", profile, id, err)
+					continue
+				}
+				// The email is left out as well: admins only need to see where each space points
+				connection := credentialsSummary(profile, creds)
+				delete(connection, "email")
+				connections = append(connections, connection)
+			}
+			spaces = append(spaces, map[string]any{
+				"spaceId":  id,
+				"profiles": connections,
+			})
+		}
+
+		log.Printf("Listed %d connected spaces", len(spaces))
+		result = map[string]any{
+			"result":  "success",
+			"message": fmt.Sprintf("%d spaces have Jira credentials", len(spaces)),
+			"spaces":  spaces,
+			"count":   len(spaces),
+		}
+	}
+
+	if plugin := sdkv2.GetPlugin(); plugin != nil {
+		plugin.Done(jobID, result)
+	} else {
+		log.Printf("Failed to publish result: plugin instance not found")
+	}
 }
 
 // getStringValue safely extracts a string value from a map
@@ -0,0 +1,37 @@
+package main
+
+import "testing"
+
+func TestNormalizeInstanceURL(t *testing.T) {
+	tests := []struct {
+		raw     string
+		want    string
+		wantErr bool
+	}{
+		{raw: "https://example.atlassian.net", want: "https://example.atlassian.net"},
+		{raw: "  example.atlassian.net/  ", want: "https://example.atlassian.net"},
+		{raw: "HTTPS://example.atlassian.net///", want: "https://example.atlassian.net"},
+		{raw: "http://localhost:8080", want: "http://localhost:8080"},
+		{raw: "https://host.io/jira", want: "https://host.io/jira"},
+		{raw: "https://host.io/jira/", want: "https://host.io/jira"},
+		{raw: "https://host.io//tools//jira/", want: "https://host.io/tools/jira"},
+		{raw: "https://host.io/jira/rest/api/2/", want: "https://host.io/jira"},
+		{raw: "https://host.io/rest/api/3/myself", want: "https://host.io"},
+		{raw: "ftp://host.io", wantErr: true},
+		{raw: "https://intranet", wantErr: true},
+		{raw: "https://bad_host.io", wantErr: true},
+		{raw: "https://", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := normalizeInstanceURL(tt.raw)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("normalizeInstanceURL(%q) = %q, want an error", tt.raw, got)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("normalizeInstanceURL(%q) = %q, %v, want %q", tt.raw, got, err, tt.want)
+		}
+	}
+}
@@ -1,18 +1,33 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/joho/godotenv"
 	sdkv2 "github.com/sorenhq/go-plugin-sdk/gosdk"
 	models "github.com/sorenhq/go-plugin-sdk/gosdk/models"
 
+	"github.com/sorenhq/jira-plugin/actions/agile"
 	"github.com/sorenhq/jira-plugin/actions/issues"
 	"github.com/sorenhq/jira-plugin/actions/projects"
+	"github.com/sorenhq/jira-plugin/actions/system"
+	"github.com/sorenhq/jira-plugin/actions/users"
+	"github.com/sorenhq/jira-plugin/actions/versions"
+	"github.com/sorenhq/jira-plugin/client"
+	"github.com/sorenhq/jira-plugin/credentials"
+	"github.com/sorenhq/jira-plugin/shutdown"
+	"github.com/sorenhq/jira-plugin/webhook"
 )
 
+// shutdownTimeout bounds how long a SIGINT/SIGTERM waits for in-flight jobs before exiting
+const shutdownTimeout = 30 * time.Second
+
 var PluginInstance *sdkv2.Plugin
 
 func main() {
@@ -43,10 +58,15 @@ func main() {
 	plugin := sdkv2.NewPlugin(sdkInstance)
 	PluginInstance = plugin
 
+	// credentials.update tests patched credentials against Jira before saving them
+	credentials.GetCredentialsStorage().SetValidator(validateCredentials)
+	// Expired OAuth access tokens are refreshed when credentials are loaded
+	credentials.GetCredentialsStorage().SetTokenRefresher(client.RefreshOAuthToken)
+
 	// Set up plugin intro with onboarding requirements
 	plugin.SetIntro(models.PluginIntro{
 		Name:    "Jira Plugin",
-		Version: "1.0.0",
+		Version: client.PluginVersion,
 		Author:  "Soren Team",
 		Requirements: &models.Requirements{
 			ReplyTo: "onboarding",
@@ -57,6 +77,10 @@ func main() {
 						"type":  "Control",
 						"scope": "#/properties/instanceUrl",
 					},
+					{
+						"type":  "Control",
+						"scope": "#/properties/authType",
+					},
 					{
 						"type":  "Control",
 						"scope": "#/properties/email",
@@ -65,6 +89,34 @@ func main() {
 						"type":  "Control",
 						"scope": "#/properties/apiToken",
 					},
+					{
+						"type":  "Control",
+						"scope": "#/properties/accessToken",
+					},
+					{
+						"type":  "Control",
+						"scope": "#/properties/refreshToken",
+					},
+					{
+						"type":  "Control",
+						"scope": "#/properties/expiresIn",
+					},
+					{
+						"type":  "Control",
+						"scope": "#/properties/apiVersion",
+					},
+					{
+						"type":  "Control",
+						"scope": "#/properties/defaultProjectKey",
+					},
+					{
+						"type":  "Control",
+						"scope": "#/properties/timeoutSeconds",
+					},
+					{
+						"type":  "Control",
+						"scope": "#/properties/profile",
+					},
 				},
 			},
 			Jsonschema: map[string]any{
@@ -75,10 +127,17 @@ func main() {
 						"title":       "Jira Instance URL",
 						"description": "Your Jira instance URL (e.g., https://yourcompany.atlassian.net)",
 					},
+					"authType": map[string]any{
+						"type":        "string",
+						"title":       "Authentication",
+						"description": "api_token (default) to sign in with your email and an API token, or oauth to use an OAuth 2.0 access token (Jira Cloud only)",
+						"enum":        []string{"api_token", "oauth"},
+						"default":     "api_token",
+					},
 					"email": map[string]any{
 						"type":        "string",
 						"title":       "Email Address",
-						"description": "Your Jira account email address",
+						"description": "Your Jira account email address (API token authentication)",
 					},
 					"apiToken": map[string]any{
 						"type":        "string",
@@ -86,8 +145,51 @@ func main() {
 						"description": "Your Jira API token (create one at https://id.atlassian.com/manage-profile/security/api-tokens)",
 						"format":      "password",
 					},
+					"accessToken": map[string]any{
+						"type":        "string",
+						"title":       "OAuth Access Token",
+						"description": "OAuth 2.0 (3LO) access token with the read:jira-user and read:jira-work scopes (plus write:jira-work to make changes)",
+						"format":      "password",
+					},
+					"refreshToken": map[string]any{
+						"type":        "string",
+						"title":       "OAuth Refresh Token",
+						"description": "Optional refresh token (requires the offline_access scope). With it, expired access tokens are renewed automatically",
+						"format":      "password",
+					},
+					"expiresIn": map[string]any{
+						"type":        "integer",
+						"title":       "Access Token Lifetime (seconds)",
+						"description": "Optional expires_in value returned with the access token, so it is refreshed before Jira rejects it",
+						"minimum":     1,
+					},
+					"apiVersion": map[string]any{
+						"type":        "string",
+						"title":       "API Version",
+						"description": "Jira REST API version: 2 for Jira Server/Data Center, 3 for Jira Cloud (uses Atlassian Document Format for rich text). Leave empty to detect it automatically",
+						"enum":        []string{"2", "3"},
+					},
+					"defaultProjectKey": map[string]any{
+						"type":        "string",
+						"title":       "Default Project Key",
+						"description": "Optional project key (e.g., PROJ) used when an issue is created without one",
+					},
+					"timeoutSeconds": map[string]any{
+						"type":        "integer",
+						"title":       "Request Timeout (seconds)",
+						"description": "Optional timeout for each request to this Jira instance (1-300, default 30). Raise it for slow on-prem instances",
+						"minimum":     1,
+						"maximum":     300,
+					},
+					"profile": map[string]any{
+						"type":        "string",
+						"title":       "Profile",
+						"description": "Optional name for this set of credentials, so one space can connect to several Jira sites or accounts. Leave empty for the default profile",
+					},
 				},
-				"required": []string{"instanceUrl", "email", "apiToken"},
+				// email and apiToken are only required for API token authentication, which
+				// the onboarding handler checks
+				"required": []string{"instanceUrl"},
 			},
 		},
 	}, onboardingHandler)
@@ -96,9 +198,63 @@ func main() {
 	var allActions []models.Action
 	allActions = append(allActions, projects.GetActions()...)
 	allActions = append(allActions, issues.GetActions()...)
+	allActions = append(allActions, versions.GetActions()...)
+	allActions = append(allActions, users.GetActions()...)
+	allActions = append(allActions, agile.GetActions()...)
+	allActions = append(allActions, getCredentialsActions()...)
+	allActions = append(allActions, getAdminActions()...)
+	allActions = append(allActions, system.GetActions()...)
 
 	// Add all actions to the plugin
 	plugin.AddActions(allActions)
 
-	plugin.Start()
+	// Optionally receive Jira webhooks and republish them as plugin events
+	webhookConfig, webhookEnabled, err := webhook.ConfigFromEnv()
+	if err != nil {
+		log.Fatalf("Invalid webhook configuration: %v", err)
+	}
+	var webhookServer *webhook.Server
+	if webhookEnabled {
+		events := sdkv2.NewEventLogger(sdkInstance)
+		webhookServer = webhook.NewServer(webhookConfig, func(event string, data map[string]any) error {
+			return events.EmitEvent(models.EventType(event), data)
+		})
+		go func() {
+			if err := webhookServer.ListenAndServe(); err != nil {
+				log.Printf("Jira webhook listener stopped: %v", err)
+			}
+		}()
+	}
+
+	// Run the plugin until it stops on its own or a SIGINT/SIGTERM arrives
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	stopped := make(chan struct{})
+	go func() {
+		plugin.Start()
+		close(stopped)
+	}()
+
+	select {
+	case sig := <-signals:
+		log.Printf("Received %v, shutting down", sig)
+	case <-stopped:
+		log.Printf("Plugin stopped, shutting down")
+	}
+
+	// Refuse new jobs and let running ones finish and report Done before the
+	// deferred sdkInstance.Close() disconnects from NATS
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if webhookServer != nil {
+		if err := webhookServer.Shutdown(ctx); err != nil {
+			log.Printf("Failed to stop Jira webhook listener: %v", err)
+		}
+	}
+	deadline, _ := ctx.Deadline()
+	if shutdown.Drain(time.Until(deadline)) {
+		log.Printf("All in-flight jobs finished")
+	} else {
+		log.Printf("Timed out after %v with %d jobs still running", shutdownTimeout, shutdown.InFlight())
+	}
 }
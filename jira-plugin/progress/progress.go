@@ -0,0 +1,23 @@
+package progress
+
+import (
+	sdkv2 "github.com/sorenhq/go-plugin-sdk/gosdk"
+	sdkv2Models "github.com/sorenhq/go-plugin-sdk/gosdk/models"
+)
+
+// Func reports intermediate progress (0-100) of a running action to the platform
+type Func func(percent int, message string)
+
+// ForJob returns the Func reporting the progress of a job accepted with sdkv2.Accept.
+// Percentages are clamped to 0-100; reports are dropped if no plugin is registered.
+func ForJob(jobID string) Func {
+	return func(percent int, message string) {
+		percent = min(max(percent, 0), 100)
+		if plugin := sdkv2.GetPlugin(); plugin != nil {
+			plugin.Progress(jobID, sdkv2Models.ProgressCommand, sdkv2Models.JobProgress{
+				Progress: percent,
+				Details:  map[string]any{"message": message},
+			})
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package shutdown
+
+import (
+	"sync"
+	"time"
+)
+
+var (
+	// mu guards draining so no job can start once Drain has begun waiting
+	mu       sync.Mutex
+	draining bool
+	inFlight int
+	jobs     sync.WaitGroup
+)
+
+// Begin registers an in-flight job. It returns false once the plugin is shutting
+// down, in which case the job must be rejected and End must not be called.
+func Begin() bool {
+	mu.Lock()
+	defer mu.Unlock()
+	if draining {
+		return false
+	}
+	inFlight++
+	jobs.Add(1)
+	return true
+}
+
+// End marks a job registered with Begin as finished
+func End() {
+	mu.Lock()
+	inFlight--
+	mu.Unlock()
+	jobs.Done()
+}
+
+// InFlight returns the number of jobs currently running
+func InFlight() int {
+	mu.Lock()
+	defer mu.Unlock()
+	return inFlight
+}
+
+// Drain stops new jobs from starting and waits up to timeout for running ones to
+// finish. It reports whether all jobs finished in time.
+func Drain(timeout time.Duration) bool {
+	mu.Lock()
+	draining = true
+	mu.Unlock()
+
+	finished := make(chan struct{})
+	go func() {
+		jobs.Wait()
+		close(finished)
+	}()
+
+	select {
+	case <-finished:
+		return true
+	case <-time.After(timeout):
+		return false
+	}
+}
+
+// Draining reports whether the plugin is shutting down
+func Draining() bool {
+	mu.Lock()
+	defer mu.Unlock()
+	return draining
+}
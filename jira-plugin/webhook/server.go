@@ -0,0 +1,249 @@
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bytedance/sonic"
+
+	"github.com/sorenhq/jira-plugin/client"
+)
+
+// portEnv enables the webhook listener on the given port; the listener is off when unset
+const portEnv = "SOREN_JIRA_WEBHOOK_PORT"
+
+// secretEnv is the optional master secret that per-space webhook secrets are derived
+// from (see SpaceSecret)
+const secretEnv = "SOREN_JIRA_WEBHOOK_SECRET"
+
+// eventsEnv is a comma-separated list of webhook events to republish (e.g.
+// jira:issue_created,jira:issue_updated); all events are republished when unset
+const eventsEnv = "SOREN_JIRA_WEBHOOK_EVENTS"
+
+// secretHeader carries the space's secret in plain form (for senders that can set headers)
+const secretHeader = "X-Soren-Webhook-Secret"
+
+// signatureHeader carries the HMAC-SHA256 of the body that Jira sends for webhooks
+// registered with a secret, formatted as "sha256=<hex>"
+const signatureHeader = "X-Hub-Signature"
+
+// pathPrefix is the path webhooks are posted to: /webhook/{spaceId}
+const pathPrefix = "/webhook/"
+
+// maxPayloadSize caps the size of an accepted webhook body
+const maxPayloadSize = 5 << 20
+
+// Publisher republishes an accepted webhook event to the platform
+type Publisher func(event string, data map[string]any) error
+
+// Config configures the webhook listener
+type Config struct {
+	// Port is the TCP port the listener binds to
+	Port int
+	// Secret, if set, is the master secret: deliveries for a space must present that
+	// space's SpaceSecret via X-Soren-Webhook-Secret or use it to sign the body
+	Secret string
+	// Events lists the webhook events to republish; empty means all
+	Events []string
+}
+
+// ConfigFromEnv reads the listener configuration from the environment. It returns
+// false if SOREN_JIRA_WEBHOOK_PORT is unset, meaning the listener is disabled.
+func ConfigFromEnv() (Config, bool, error) {
+	rawPort := strings.TrimSpace(os.Getenv(portEnv))
+	if rawPort == "" {
+		return Config{}, false, nil
+	}
+	port, err := strconv.Atoi(rawPort)
+	if err != nil || port <= 0 || port > 65535 {
+		return Config{}, false, fmt.Errorf("invalid %s %q: must be a port number", portEnv, rawPort)
+	}
+
+	cfg := Config{
+		Port:   port,
+		Secret: os.Getenv(secretEnv),
+	}
+	for _, event := range strings.Split(os.Getenv(eventsEnv), ",") {
+		if event = strings.TrimSpace(event); event != "" {
+			cfg.Events = append(cfg.Events, event)
+		}
+	}
+	return cfg, true, nil
+}
+
+// Server receives Jira webhooks over HTTP and republishes them through a Publisher
+type Server struct {
+	cfg     Config
+	publish Publisher
+	events  map[string]bool
+	http    *http.Server
+}
+
+// NewServer creates a webhook server; call ListenAndServe to start it
+func NewServer(cfg Config, publish Publisher) *Server {
+	s := &Server{
+		cfg:     cfg,
+		publish: publish,
+		events:  make(map[string]bool, len(cfg.Events)),
+	}
+	for _, event := range cfg.Events {
+		s.events[strings.ToLower(event)] = true
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(pathPrefix, s.handleWebhook)
+	s.http = &http.Server{
+		Addr:              net.JoinHostPort("", strconv.Itoa(cfg.Port)),
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+		ReadTimeout:       30 * time.Second,
+	}
+	return s
+}
+
+// ListenAndServe blocks serving webhooks until Shutdown is called
+func (s *Server) ListenAndServe() error {
+	if s.cfg.Secret == "" {
+		log.Printf("Warning: %s is not set, inbound webhooks are not authenticated", secretEnv)
+	}
+	log.Printf("Jira webhook listener started on port %d (events: %s)", s.cfg.Port, s.eventsDescription())
+	err := s.http.ListenAndServe()
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+	return err
+}
+
+// Shutdown stops accepting webhooks and waits for in-flight ones to finish
+func (s *Server) Shutdown(ctx context.Context) error {
+	return s.http.Shutdown(ctx)
+}
+
+// handleWebhook verifies, parses and republishes one webhook delivery
+func (s *Server) handleWebhook(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// The space the events belong to is part of the URL registered in Jira
+	spaceID := strings.Trim(strings.TrimPrefix(r.URL.Path, pathPrefix), "/")
+	if spaceID == "" || strings.Contains(spaceID, "/") {
+		http.Error(w, "expected /webhook/{spaceId}", http.StatusNotFound)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxPayloadSize+1))
+	if err != nil {
+		http.Error(w, "failed to read body", http.StatusBadRequest)
+		return
+	}
+	if len(body) > maxPayloadSize {
+		http.Error(w, "payload too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	if !s.verify(r, spaceID, body) {
+		log.Printf("Rejected Jira webhook for space '%s': invalid secret or signature", spaceID)
+		http.Error(w, "invalid secret or signature", http.StatusUnauthorized)
+		return
+	}
+
+	var payload map[string]any
+	if err := sonic.Unmarshal(body, &payload); err != nil {
+		log.Printf("Failed to unmarshal Jira webhook payload: %v, body: %s", err, client.RedactJSON(body))
+		http.Error(w, "invalid JSON payload", http.StatusBadRequest)
+		return
+	}
+	event, _ := payload["webhookEvent"].(string)
+	if event == "" {
+		http.Error(w, "missing webhookEvent", http.StatusBadRequest)
+		return
+	}
+
+	// Events nobody registered for are acknowledged so Jira doesn't retry them
+	if len(s.events) > 0 && !s.events[strings.ToLower(event)] {
+		client.Debugf("Ignoring Jira webhook event %s for space '%s'", event, spaceID)
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	data := map[string]any{
+		"event":   event,
+		"spaceId": spaceID,
+		"payload": payload,
+	}
+	if timestamp, ok := payload["timestamp"]; ok {
+		data["timestamp"] = timestamp
+	}
+	if issue, ok := payload["issue"].(map[string]any); ok {
+		data["issueKey"], _ = issue["key"].(string)
+		data["issueId"], _ = issue["id"].(string)
+	}
+
+	if err := s.publish(event, data); err != nil {
+		log.Printf("Failed to publish Jira webhook event %s for space '%s': %v", event, spaceID, err)
+		http.Error(w, "failed to publish event", http.StatusServiceUnavailable)
+		return
+	}
+
+	log.Printf("Published Jira webhook event %s for space '%s'", event, spaceID)
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// SpaceSecret derives the webhook secret of a space from the master secret, as the hex
+// HMAC-SHA256 of the space ID. Each Jira site is given only its own space's secret, so
+// it can't authenticate deliveries for another space's path.
+func SpaceSecret(secret, spaceID string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(spaceID))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// verify checks the secret header or the body signature against the secret of the
+// space the delivery is addressed to. Without a configured secret every delivery is
+// accepted.
+func (s *Server) verify(r *http.Request, spaceID string, body []byte) bool {
+	if s.cfg.Secret == "" {
+		return true
+	}
+	spaceSecret := SpaceSecret(s.cfg.Secret, spaceID)
+
+	if secret := r.Header.Get(secretHeader); secret != "" {
+		return subtle.ConstantTimeCompare([]byte(secret), []byte(spaceSecret)) == 1
+	}
+
+	signature, ok := strings.CutPrefix(r.Header.Get(signatureHeader), "sha256=")
+	if !ok {
+		return false
+	}
+	given, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(spaceSecret))
+	mac.Write(body)
+	return hmac.Equal(given, mac.Sum(nil))
+}
+
+// eventsDescription renders the registered events for logging
+func (s *Server) eventsDescription() string {
+	if len(s.cfg.Events) == 0 {
+		return "all"
+	}
+	return strings.Join(s.cfg.Events, ", ")
+}
@@ -0,0 +1,249 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// sign returns the X-Hub-Signature value Jira sends for body
+func sign(secret, body string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(body))
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// published records the events a test server publishes
+type published struct {
+	event string
+	data  map[string]any
+}
+
+func TestHandleWebhook(t *testing.T) {
+	const secret = "s3cret"
+	// Deliveries for space-1 authenticate with its derived secret
+	spaceSecret := SpaceSecret(secret, "space-1")
+	const body = `{"webhookEvent":"jira:issue_created","timestamp":1700000000000,"issue":{"id":"10001","key":"COM-1"}}`
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		headers    map[string]string
+		body       string
+		events     []string
+		publishErr error
+		wantStatus int
+		wantEvent  string
+	}{
+		{
+			name:       "wrong method",
+			method:     http.MethodGet,
+			path:       "/webhook/space-1",
+			wantStatus: http.StatusMethodNotAllowed,
+		},
+		{
+			name:       "missing space",
+			method:     http.MethodPost,
+			path:       "/webhook/",
+			body:       body,
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "bad signature",
+			method:     http.MethodPost,
+			path:       "/webhook/space-1",
+			headers:    map[string]string{signatureHeader: sign("other", body)},
+			body:       body,
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "missing signature",
+			method:     http.MethodPost,
+			path:       "/webhook/space-1",
+			body:       body,
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "signed for another space",
+			method:     http.MethodPost,
+			path:       "/webhook/space-1",
+			headers:    map[string]string{signatureHeader: sign(SpaceSecret(secret, "space-2"), body)},
+			body:       body,
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "secret of another space",
+			method:     http.MethodPost,
+			path:       "/webhook/space-1",
+			headers:    map[string]string{secretHeader: SpaceSecret(secret, "space-2")},
+			body:       body,
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "signed with the master secret",
+			method:     http.MethodPost,
+			path:       "/webhook/space-1",
+			headers:    map[string]string{signatureHeader: sign(secret, body)},
+			body:       body,
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "master secret",
+			method:     http.MethodPost,
+			path:       "/webhook/space-1",
+			headers:    map[string]string{secretHeader: secret},
+			body:       body,
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "wrong shared secret",
+			method:     http.MethodPost,
+			path:       "/webhook/space-1",
+			headers:    map[string]string{secretHeader: "guess"},
+			body:       body,
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "valid HMAC",
+			method:     http.MethodPost,
+			path:       "/webhook/space-1",
+			headers:    map[string]string{signatureHeader: sign(spaceSecret, body)},
+			body:       body,
+			wantStatus: http.StatusAccepted,
+			wantEvent:  "jira:issue_created",
+		},
+		{
+			name:       "valid shared secret",
+			method:     http.MethodPost,
+			path:       "/webhook/space-1",
+			headers:    map[string]string{secretHeader: spaceSecret},
+			body:       body,
+			wantStatus: http.StatusAccepted,
+			wantEvent:  "jira:issue_created",
+		},
+		{
+			name:       "filtered event",
+			method:     http.MethodPost,
+			path:       "/webhook/space-1",
+			headers:    map[string]string{signatureHeader: sign(spaceSecret, body)},
+			body:       body,
+			events:     []string{"jira:issue_updated"},
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name:       "registered event matches case-insensitively",
+			method:     http.MethodPost,
+			path:       "/webhook/space-1",
+			headers:    map[string]string{signatureHeader: sign(spaceSecret, body)},
+			body:       body,
+			events:     []string{"JIRA:ISSUE_CREATED"},
+			wantStatus: http.StatusAccepted,
+			wantEvent:  "jira:issue_created",
+		},
+		{
+			name:       "invalid JSON",
+			method:     http.MethodPost,
+			path:       "/webhook/space-1",
+			headers:    map[string]string{signatureHeader: sign(spaceSecret, "{")},
+			body:       "{",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "missing webhookEvent",
+			method:     http.MethodPost,
+			path:       "/webhook/space-1",
+			headers:    map[string]string{signatureHeader: sign(spaceSecret, "{}")},
+			body:       "{}",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "publisher error",
+			method:     http.MethodPost,
+			path:       "/webhook/space-1",
+			headers:    map[string]string{signatureHeader: sign(spaceSecret, body)},
+			body:       body,
+			publishErr: errors.New("event channel not configured"),
+			wantStatus: http.StatusServiceUnavailable,
+			wantEvent:  "jira:issue_created",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []published
+			s := NewServer(Config{Secret: secret, Events: tt.events}, func(event string, data map[string]any) error {
+				got = append(got, published{event: event, data: data})
+				return tt.publishErr
+			})
+
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			for key, value := range tt.headers {
+				req.Header.Set(key, value)
+			}
+			rec := httptest.NewRecorder()
+			s.handleWebhook(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d (body: %s)", rec.Code, tt.wantStatus, rec.Body.String())
+			}
+			if tt.wantEvent == "" {
+				if len(got) != 0 {
+					t.Fatalf("published %d events, want none", len(got))
+				}
+				return
+			}
+			if len(got) != 1 {
+				t.Fatalf("published %d events, want 1", len(got))
+			}
+			if got[0].event != tt.wantEvent {
+				t.Errorf("event = %q, want %q", got[0].event, tt.wantEvent)
+			}
+			data := got[0].data
+			if data["spaceId"] != "space-1" || data["issueKey"] != "COM-1" || data["issueId"] != "10001" {
+				t.Errorf("unexpected event data: %v", data)
+			}
+			if _, ok := data["payload"].(map[string]any); !ok {
+				t.Errorf("payload missing from event data: %v", data)
+			}
+		})
+	}
+}
+
+func TestHandleWebhookWithoutSecret(t *testing.T) {
+	var events []string
+	s := NewServer(Config{}, func(event string, data map[string]any) error {
+		events = append(events, event)
+		return nil
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/webhook/space-1", strings.NewReader(`{"webhookEvent":"comment_created"}`))
+	rec := httptest.NewRecorder()
+	s.handleWebhook(rec, req)
+
+	if rec.Code != http.StatusAccepted {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusAccepted)
+	}
+	if len(events) != 1 || events[0] != "comment_created" {
+		t.Fatalf("published events = %v, want [comment_created]", events)
+	}
+}
+
+func TestSpaceSecret(t *testing.T) {
+	// Matches: printf %s space-1 | openssl dgst -sha256 -hmac s3cret
+	a := SpaceSecret("s3cret", "space-1")
+	if a != "f92fee9b038c93b9d17ff79310561964838c468ddbf8b110034e7b50383102b4" {
+		t.Errorf("SpaceSecret = %q, want the HMAC-SHA256 of the space ID", a)
+	}
+	if a != SpaceSecret("s3cret", "space-1") {
+		t.Error("SpaceSecret is not deterministic")
+	}
+	if a == SpaceSecret("s3cret", "space-2") || a == SpaceSecret("other", "space-1") {
+		t.Error("SpaceSecret doesn't depend on both the secret and the space")
+	}
+}